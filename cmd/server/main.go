@@ -2,12 +2,20 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"github.com/labstack/echo/v4"
 	"github.com/nmluci/da-farm-be/docs"
 	"github.com/nmluci/da-farm-be/internal/config"
 	"github.com/nmluci/da-farm-be/internal/database/postgres"
 	"github.com/nmluci/da-farm-be/internal/domain"
+	"github.com/nmluci/da-farm-be/internal/domain/farms"
+	"github.com/nmluci/da-farm-be/internal/domain/ponds"
+	"github.com/nmluci/da-farm-be/internal/domain/users"
 	"github.com/nmluci/da-farm-be/internal/logger"
+	"github.com/nmluci/da-farm-be/internal/mqtt"
+	"github.com/nmluci/da-farm-be/internal/scheduler"
 )
 
 // @title			DA Farm Backend
@@ -16,6 +24,16 @@ import (
 // @termsOfService	http://swagger.io/terms/
 //
 // @BasePath		/api/v1
+//
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description				access token prefixed with "Bearer "
+//
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						Authorization
+// @description				API key prefixed with "ApiKey "
 func main() {
 	// bootstrapping
 	config := config.New()
@@ -32,7 +50,41 @@ func main() {
 	ec.HideBanner = true
 	ec.HidePort = true
 
-	domain.InitDomain(logger, db, ec)
+	dom := domain.InitDomain(logger, db, ec, config.JWTConf)
+
+	// field gateways push readings through MQTT, only when a broker is configured
+	if config.MQTTConf.Broker != "" {
+		subscriber, err := mqtt.New(logger, config.MQTTConf, dom.DeviceService)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to start mqtt subscriber")
+		} else {
+			defer subscriber.Close()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = dom.UserService.Bootstrap(logger.WithContext(ctx), &users.UserPayload{
+		Username: config.AdminUsername,
+		Password: config.AdminPassword,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create initial user")
+	}
+
+	// keep aggregated sensor readings close to real-time
+	scheduler.Every(ctx, logger, "sensor-rollup", time.Minute, dom.DeviceService.Rollup)
+	scheduler.Every(ctx, logger, "work-order-due", time.Minute, dom.WorkOrderService.OpenDue)
+	scheduler.Every(ctx, logger, "checklist-generate", 15*time.Minute, dom.ChecklistService.Generate)
+
+	// deleted farms and ponds stay restorable until the retention window passes
+	scheduler.Every(ctx, logger, "farm-purge", time.Hour, func(ctx context.Context) error {
+		return dom.FarmService.Purge(ctx, &farms.PurgePayload{Retention: config.PurgeRetention})
+	})
+	scheduler.Every(ctx, logger, "pond-purge", time.Hour, func(ctx context.Context) error {
+		return dom.PondService.Purge(ctx, &ponds.PurgePayload{Retention: config.PurgeRetention})
+	})
 
 	logger.Info().Msgf("starting service, listening at %s", config.ServiceAddress)
 	if err := ec.Start(config.ServiceAddress); err != nil {
@@ -16,20 +16,25 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/farms": {
+        "/api-keys": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Farm"
+                    "API Key"
                 ],
-                "summary": "get all farm",
+                "summary": "get API keys owned by the logged in user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Keyword to search",
-                        "name": "keyword",
+                        "type": "boolean",
+                        "description": "include revoked and expired keys",
+                        "name": "revoked",
                         "in": "query"
                     },
                     {
@@ -49,7 +54,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/farms.ListFarmResponse"
+                            "$ref": "#/definitions/apikeys.ListAPIKeyResponse"
                         }
                     },
                     "404": {
@@ -67,6 +72,11 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -74,17 +84,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Farm"
+                    "API Key"
                 ],
-                "summary": "create a new farm",
+                "summary": "issue a new API key acting on behalf of the logged in user within its scopes, the key is only shown once",
                 "parameters": [
                     {
-                        "description": "farm payload",
+                        "description": "API key payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/farms.FarmPayload"
+                            "$ref": "#/definitions/apikeys.APIKeyPayload"
                         }
                     }
                 ],
@@ -92,11 +102,11 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/apikeys.APIKeySecretResponse"
                         }
                     },
-                    "409": {
-                        "description": "farm with same name already exists",
+                    "400": {
+                        "description": "missing name or scopes, unknown scope or past expiry",
                         "schema": {
                             "$ref": "#/definitions/httpres.ErrorResponse"
                         }
@@ -110,20 +120,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/farms/{farmID}": {
+        "/api-keys/{keyID}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Farm"
+                    "API Key"
                 ],
-                "summary": "get specific farm by ID",
+                "summary": "get specific API key by ID, the key itself is never shown again",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Farm ID",
-                        "name": "farmID",
+                        "description": "API Key ID",
+                        "name": "keyID",
                         "in": "path",
                         "required": true
                     }
@@ -132,7 +147,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/farms.FarmResponse"
+                            "$ref": "#/definitions/apikeys.APIKeyResponse"
                         }
                     },
                     "404": {
@@ -149,33 +164,26 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "consumes": [
-                    "application/json"
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Farm"
+                    "API Key"
                 ],
-                "summary": "update farm data",
+                "summary": "permanently revoke specific API key by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Farm ID",
-                        "name": "farmID",
+                        "description": "API Key ID",
+                        "name": "keyID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "farm payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/farms.FarmPayload"
-                        }
                     }
                 ],
                 "responses": {
@@ -186,13 +194,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "farm not existed",
-                        "schema": {
-                            "$ref": "#/definitions/httpres.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "duplicated farm found",
+                        "description": "key not existed or already revoked",
                         "schema": {
                             "$ref": "#/definitions/httpres.ErrorResponse"
                         }
@@ -204,20 +206,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api-keys/{keyID}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Farm"
+                    "API Key"
                 ],
-                "summary": "delete specific farm by ID",
+                "summary": "replace the secret of an active API key, the previous secret stops working immediately",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Farm ID",
-                        "name": "farmID",
+                        "description": "API Key ID",
+                        "name": "keyID",
                         "in": "path",
                         "required": true
                     }
@@ -226,11 +235,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/farms.FarmResponse"
+                            "$ref": "#/definitions/apikeys.APIKeySecretResponse"
                         }
                     },
                     "404": {
-                        "description": "farm not existed",
+                        "description": "key not existed, revoked or expired",
                         "schema": {
                             "$ref": "#/definitions/httpres.ErrorResponse"
                         }
@@ -244,27 +253,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/farms/{farmID}/ponds": {
+        "/audit": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pond"
+                    "Audit"
                 ],
-                "summary": "get all pond",
+                "summary": "get history of changes made on a farm or its ponds, newest first",
                 "parameters": [
+                    {
+                        "enum": [
+                            "farm",
+                            "pond"
+                        ],
+                        "type": "string",
+                        "description": "only return entries of the entity type",
+                        "name": "entity",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "farm ID",
-                        "name": "farmID",
-                        "in": "path",
-                        "required": true
+                        "description": "only return entries of the entity, requires entity",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "farm of the entries, required when entity and id are empty",
+                        "name": "farm_id",
+                        "in": "query"
                     },
                     {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore"
+                        ],
                         "type": "string",
-                        "description": "Keyword to search",
-                        "name": "keyword",
+                        "description": "only return entries of the action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return entries made by the user",
+                        "name": "actor_id",
                         "in": "query"
                     },
                     {
@@ -284,7 +325,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ponds.ListPondResponse"
+                            "$ref": "#/definitions/audit.ListAuditResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown entity or missing farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not permitted reading audit of the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
                         }
                     },
                     "404": {
@@ -300,7 +353,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -309,36 +364,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pond"
+                    "Auth"
                 ],
-                "summary": "create a new pond",
+                "summary": "exchange username and password for an access and refresh token pair",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Farm ID",
-                        "name": "farmID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "pond payload",
+                        "description": "credential",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ponds.PondPayload"
+                            "$ref": "#/definitions/users.LoginPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/users.TokenResponse"
                         }
                     },
-                    "409": {
-                        "description": "pond with same name already exists",
+                    "400": {
+                        "description": "missing username or password",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid credential",
                         "schema": {
                             "$ref": "#/definitions/httpres.ErrorResponse"
                         }
@@ -352,40 +406,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/farms/{farmID}/ponds/{pondID}": {
-            "get": {
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pond"
+                    "Auth"
                 ],
-                "summary": "get specific pond by ID",
+                "summary": "end the current session, or every session of the user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Farm ID",
-                        "name": "farmID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Pond ID",
-                        "name": "pondID",
-                        "in": "path",
-                        "required": true
+                        "description": "logout option",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/users.LogoutPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ponds.PondResponse"
+                            "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/httpres.ErrorResponse"
                         }
@@ -397,8 +453,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/refresh": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -406,31 +464,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pond"
+                    "Auth"
                 ],
-                "summary": "update pond data",
+                "summary": "exchange a refresh token for a new token pair, each refresh token can only be exchanged once",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Farm ID",
-                        "name": "farmID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Pond ID",
-                        "name": "pondID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "pond payload",
+                        "description": "refresh token",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ponds.PondPayload"
+                            "$ref": "#/definitions/users.RefreshPayload"
                         }
                     }
                 ],
@@ -438,17 +482,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "pond not existed",
-                        "schema": {
-                            "$ref": "#/definitions/httpres.ErrorResponse"
+                            "$ref": "#/definitions/users.TokenResponse"
                         }
                     },
-                    "409": {
-                        "description": "duplicated pond found",
+                    "401": {
+                        "description": "expired, revoked or already exchanged refresh token",
                         "schema": {
                             "$ref": "#/definitions/httpres.ErrorResponse"
                         }
@@ -460,40 +498,60 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/devices/{sensorID}/readings": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pond"
+                    "Device"
                 ],
-                "summary": "delete specific pond by ID",
+                "summary": "push batched readings of a device, authenticated by its device key",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Farm ID",
-                        "name": "farmID",
+                        "type": "string",
+                        "description": "Sensor ID",
+                        "name": "sensorID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Pond ID",
-                        "name": "pondID",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Device key",
+                        "name": "X-Device-Key",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "batched readings",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/devices.IngestPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/ponds.PondResponse"
+                            "$ref": "#/definitions/devices.IngestResponse"
                         }
                     },
-                    "404": {
-                        "description": "pond not existed",
+                    "400": {
+                        "description": "batch too large or missing value",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unknown device or invalid key",
                         "schema": {
                             "$ref": "#/definitions/httpres.ErrorResponse"
                         }
@@ -507,156 +565,9263 @@ const docTemplate = `{
                 }
             }
         },
-        "/misc/ping": {
+        "/farms": {
             "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Misc"
-                ],
-                "summary": "check server status",
-                "responses": {
-                    "200": {
-                        "description": "OK"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
                     }
-                }
-            }
-        },
-        "/telemetry/request-metrics": {
-            "get": {
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Misc"
+                    "Farm"
                 ],
-                "summary": "get request metrics for all registered API",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/telemetry.ListRequestMetricResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
+                "summary": "get all farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search by name, region, address or owner, matched by word prefix and tolerating typos, ordered by relevance unless near is given",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return farm within the region",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return farm around coordinate, formatted as lat,lon",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "search radius around near in km, default to 10",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "list soft-deleted farms instead, which can be restored",
+                        "name": "deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated id, name, region, owner_name, area, created_at or updated_at, prefixed by - for descending, ex: -created_at,name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter[name|region|timezone|owner_name]=a,b matches any of the values, filter[created_at|updated_at][gt|gte|lt|lte] accepts RFC3339 or YYYY-MM-DD, ex: filter[created_at][gte]=2024-08-01",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to fetch along with id, the rest are omitted from response, ex: id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/farms.ListFarmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "field not allowed to sort, filter or select",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "create a new farm",
+                "parameters": [
+                    {
+                        "description": "farm payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/farms.FarmPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid coordinate or timezone",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "farm with same name already exists within the organization",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
                             "$ref": "#/definitions/httpres.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "farms.FarmPayload": {
+        },
+        "/farms/{farmID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "get specific farm by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/farms.FarmResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "update farm data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "farm payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/farms.FarmPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid coordinate or timezone",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not an owner or manager of the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "farm not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "duplicated farm found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "delete specific farm by ID along with its ponds and their records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only report records which would be deleted",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/farms.DeleteFarmResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not an owner of the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "farm not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/alert-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "get all alert rules of a farm",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return rules bound to the pond",
+                        "name": "pond_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/alerts.ListRuleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "create a new alert rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "rule payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/alerts.RulePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid parameter or threshold",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "farm or pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/alert-rules/{ruleID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "get specific alert rule by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "ruleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/alerts.RuleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "update alert rule data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "ruleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "rule payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/alerts.RulePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid parameter or threshold",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "rule not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "delete specific alert rule by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "ruleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "rule not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "get all alerts of a farm",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return alerts of the pond",
+                        "name": "pond_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "acknowledged",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "description": "alert status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/alerts.ListAlertResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/alerts/{alertID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "get specific alert by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alertID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/alerts.AlertResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "acknowledge or resolve an alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alertID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "status payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/alerts.AlertStatusPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "alert not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "alert can't be moved into requested status",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert"
+                ],
+                "summary": "delete specific alert by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alertID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "alert not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/checklist-report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "get checklist completion per worker per day",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day formatted as YYYY-MM-DD, default to today",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day (inclusive) formatted as YYYY-MM-DD, default to date_from, at most 31 days",
+                        "name": "date_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/checklists.ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/checklist-tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "get checklist tasks of a day ordered by due time",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "day in farm's timezone formatted as YYYY-MM-DD, default to today",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return task of the pond",
+                        "name": "pond_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return task with the status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return task completed by the worker",
+                        "name": "completed_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/checklists.ListTaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/checklist-tasks/{taskID}/complete": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "mark a pending task done or skipped, task with reading type requires the reading",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "completion payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/checklists.CompleteTaskPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "missing worker or reading",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "task not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "task already handled",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/checklist-templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "get daily checklist templates of a farm ordered by due time",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return template of the pond",
+                        "name": "pond_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/checklists.ListTemplateResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "create a daily checklist template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "template payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/checklists.TemplatePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid due time",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "farm or pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/checklist-templates/{templateID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "get specific checklist template by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/checklists.TemplateResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "update checklist template, already generated tasks are left as is",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "template payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/checklists.TemplatePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid due time",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "template or pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "delete specific checklist template by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "template not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/devices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "get all registered devices of a farm",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return device installed in the pond",
+                        "name": "pond_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return device measuring the parameter",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/devices.ListDeviceResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "register a new device, the returned key is only shown once",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "device payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/devices.DevicePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/devices.DeviceKeyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "pond not existed in the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "device with same sensor ID already registered",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/devices/{deviceID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "get specific device by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "deviceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/devices.DeviceResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "update device data, ex: move it into another pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "deviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "device payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/devices.DevicePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "device or pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "device with same sensor ID already registered",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "delete specific device by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "deviceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "device not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/devices/{deviceID}/key": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "generate a new device key, invalidating the previous one",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "deviceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/devices.DeviceKeyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "device not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/energy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "get energy consumed by equipments of a farm within a time window, broken down per pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of time window, RFC3339, default to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of time window, RFC3339, default to now",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/equipments.FarmEnergyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid time window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/equipments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "get all equipments of a farm along with their cumulative run hours",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return equipment attached into the pond",
+                        "name": "pond_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return equipment of the type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return equipment with the status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/equipments.ListEquipmentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "register a new equipment into a farm or one of its ponds",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "equipment payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/equipments.EquipmentPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown type or status",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "pond not existed in the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/equipments/{equipmentID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "get specific equipment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "equipmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/equipments.EquipmentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "update equipment data, ex: move it into another pond or retire it",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "equipmentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "equipment payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/equipments.EquipmentPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown type or status",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "equipment or pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "delete specific equipment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "equipmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "equipment not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/equipments/{equipmentID}/runtimes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "get on/off cycles of an equipment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "equipmentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of time window, RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of time window, RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/equipments.ListRuntimeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid time window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "log equipment being turned on or off, switching into its current state is ignored",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "equipmentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "on/off event",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/equipments.SwitchPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown state or equipment isn't active",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "equipment not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "get users having a role on the farm",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return member with the role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/access.ListMemberResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/members/{userID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "grant a role on the farm to a user, replacing the previous one. Only owner may manage members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "member payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/access.MemberPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown role, or demoting the last owner",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "farm or user not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "revoke role of a user on the farm. Only owner may manage members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "removing the last owner",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user isn't a member",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pond"
+                ],
+                "summary": "get all pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by name or type, matched by word prefix and tolerating typos, ordered by relevance",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "list soft-deleted ponds instead, which can be restored",
+                        "name": "deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated id, name, area, depth, volume, type, aeration_capacity, status, created_at or updated_at, prefixed by - for descending, ex: -created_at,name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter[name|type|status]=a,b matches any of the values, filter[created_at|updated_at][gt|gte|lt|lte] accepts RFC3339 or YYYY-MM-DD, ex: filter[status]=stocked",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to fetch along with id, the rest are omitted from response, ex: id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ponds.ListPondResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "field not allowed to sort, filter or select",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pond"
+                ],
+                "summary": "create a new pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "pond payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ponds.PondPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "pond with same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds.geojson": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "Pond"
+                ],
+                "summary": "get boundaries of every pond within a farm as GeoJSON FeatureCollection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/geo.FeatureCollection"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pond"
+                ],
+                "summary": "get specific pond by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "growth"
+                        ],
+                        "type": "string",
+                        "description": "include optional attribute",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ponds.PondResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pond"
+                ],
+                "summary": "update pond data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "pond payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ponds.PondPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "no write access on the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "duplicated pond found or invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pond"
+                ],
+                "summary": "delete specific pond by ID along with its records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "growth"
+                        ],
+                        "type": "string",
+                        "description": "include optional attribute",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ponds.PondResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not an owner or manager of the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/energy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "get energy consumed by equipments of a pond within a time window",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of time window, RFC3339, default to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of time window, RFC3339, default to now",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/equipments.PondEnergyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid time window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/feedings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeding"
+                ],
+                "summary": "get all feeding logs of a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return feeding of the batch",
+                        "name": "stocking_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of time window (inclusive), RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of time window (exclusive), RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/feeding.ListFeedingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid time window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeding"
+                ],
+                "summary": "record feed given in a feeding session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "feeding payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/feeding.FeedingPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "batch not existed in the pond",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/feedings/{feedingID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeding"
+                ],
+                "summary": "get specific feeding log by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feeding ID",
+                        "name": "feedingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/feeding.FeedingResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeding"
+                ],
+                "summary": "update feeding log data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feeding ID",
+                        "name": "feedingID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "feeding payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/feeding.FeedingPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "feeding not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeding"
+                ],
+                "summary": "delete specific feeding log by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feeding ID",
+                        "name": "feedingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "feeding not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/growth": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Growth"
+                ],
+                "summary": "estimate current body weight, biomass and growth rate of a batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "batch to estimate, default to latest active batch in the pond",
+                        "name": "stocking_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/growth.EstimateResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "batch not existed or never sampled",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/harvests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harvest"
+                ],
+                "summary": "get all harvests of a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return harvest of the batch",
+                        "name": "stocking_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of date window (inclusive), RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of date window (exclusive), RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/harvest.ListHarvestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harvest"
+                ],
+                "summary": "record partial or total harvest of a batch, total harvest close out the batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "harvest payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/harvest.HarvestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "batch not existed in the pond",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "batch already closed by total harvest",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/harvests/{harvestID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harvest"
+                ],
+                "summary": "get specific harvest by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Harvest ID",
+                        "name": "harvestID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/harvest.HarvestResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harvest"
+                ],
+                "summary": "update harvest data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Harvest ID",
+                        "name": "harvestID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "harvest payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/harvest.HarvestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "harvest not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "batch already closed by other total harvest",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harvest"
+                ],
+                "summary": "delete specific harvest by ID, deleting total harvest reopen the batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Harvest ID",
+                        "name": "harvestID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "harvest not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/mortalities": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mortality"
+                ],
+                "summary": "get all mortality records of a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return mortality of the batch",
+                        "name": "stocking_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of date window (inclusive), RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of date window (exclusive), RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mortality.ListMortalityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mortality"
+                ],
+                "summary": "record dead fish found in a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "mortality payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mortality.MortalityPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "count exceed live headcount",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "batch not existed in the pond",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/mortalities/{mortalityID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mortality"
+                ],
+                "summary": "get specific mortality record by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Mortality ID",
+                        "name": "mortalityID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mortality.MortalityResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mortality"
+                ],
+                "summary": "update mortality record data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Mortality ID",
+                        "name": "mortalityID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "mortality payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mortality.MortalityPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "count exceed live headcount",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "mortality not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mortality"
+                ],
+                "summary": "delete specific mortality record by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Mortality ID",
+                        "name": "mortalityID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "mortality not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/readings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Water Quality"
+                ],
+                "summary": "get all water quality readings of a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of time window (inclusive), RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of time window (exclusive), RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/waterquality.ListReadingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid time window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Water Quality"
+                ],
+                "summary": "record a new water quality reading",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reading payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/waterquality.ReadingPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/readings/{readingID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Water Quality"
+                ],
+                "summary": "get specific water quality reading by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Reading ID",
+                        "name": "readingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/waterquality.ReadingResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Water Quality"
+                ],
+                "summary": "delete specific water quality reading by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Reading ID",
+                        "name": "readingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "reading not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pond"
+                ],
+                "summary": "restore soft-deleted pond by ID along with records deleted together with it",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "not permitted deleting pond of the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "pond not deleted or farm not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "name taken by an active pond or its sensor registered elsewhere",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/samples": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Growth"
+                ],
+                "summary": "get all growth samples of a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return sample of the batch",
+                        "name": "stocking_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of date window (inclusive), RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of date window (exclusive), RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/growth.ListSampleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Growth"
+                ],
+                "summary": "record growth sample of a batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "sample payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/growth.SamplePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "batch not existed in the pond",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/samples/{sampleID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Growth"
+                ],
+                "summary": "get specific growth sample by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sample ID",
+                        "name": "sampleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/growth.SampleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Growth"
+                ],
+                "summary": "update growth sample data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sample ID",
+                        "name": "sampleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "sample payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/growth.SamplePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "sample not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Growth"
+                ],
+                "summary": "delete specific growth sample by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sample ID",
+                        "name": "sampleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "sample not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/sensor-readings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "get readings reported by devices of a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return reading of the device",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return reading of the parameter",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of time window (inclusive), RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of time window (exclusive), RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/devices.ListSensorReadingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid time window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/sensor-readings/series": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "get min/avg/max readings of a pond aggregated per bucket, resolution is picked from the time range unless specified",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "measured parameter",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only aggregate reading of the device",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of time window (inclusive), RFC3339, default to 24 hours before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of time window (exclusive), RFC3339, default to now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "raw, 5m, 1h, 1d or auto (default)",
+                        "name": "resolution",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/devices.SeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid time window or too many buckets for the resolution",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/sensors/{type}/readings": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "push readings of a pond's sensor on behalf of a field gateway, HTTP counterpart of the MQTT topic farm/{farmID}/pond/{pondID}/sensor/{type}",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sensor type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device key",
+                        "name": "X-Device-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "readings",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/devices.TopicIngestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/devices.IngestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "batch too large, missing value or ambiguous sensor",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid device key",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "no device of the type in the pond",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/stockings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocking"
+                ],
+                "summary": "get all stocking batches of a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only return batch which hasn't been totally harvested",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stocking.ListStockingResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocking"
+                ],
+                "summary": "record a new stocking batch into a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "stocking payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stocking.StockingPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "pond not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "batch with same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/stockings/{stockingID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocking"
+                ],
+                "summary": "get specific stocking batch by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Stocking ID",
+                        "name": "stockingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stocking.StockingResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocking"
+                ],
+                "summary": "update stocking batch data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Stocking ID",
+                        "name": "stockingID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "stocking payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stocking.StockingPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "stocking not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "duplicated batch found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocking"
+                ],
+                "summary": "delete specific stocking batch by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Stocking ID",
+                        "name": "stockingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "stocking not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/stockings/{stockingID}/fcr": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeding"
+                ],
+                "summary": "compute cumulative feed conversion ratio of a batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Stocking ID",
+                        "name": "stockingID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "average body weight in gram, default to the latest growth sample",
+                        "name": "avg_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "estimated live fish, default to live headcount from mortality records",
+                        "name": "headcount",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/feeding.FCRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "batch never sampled",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "batch not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/stockings/{stockingID}/survival": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mortality"
+                ],
+                "summary": "get live headcount and survival rate over time of a batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Stocking ID",
+                        "name": "stockingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mortality.BatchSurvivalResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "batch not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/ponds/{pondID}/survival": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mortality"
+                ],
+                "summary": "get live headcount and survival rate of every batch in a pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pond ID",
+                        "name": "pondID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mortality.PondSurvivalResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "no batch stocked in the pond",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "restore soft-deleted farm by ID along with records deleted together with it",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "not an owner of the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "farm not deleted",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "name taken by an active farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/survival": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mortality"
+                ],
+                "summary": "get live headcount and survival rate rolled up per farm",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mortality.FarmSurvivalResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "no batch stocked in the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/work-orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Maintenance"
+                ],
+                "summary": "get work orders of a farm ordered by due date",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return work order of the pond",
+                        "name": "pond_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return work order of the equipment",
+                        "name": "equipment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return work order of the type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return work order with the status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return work order assigned to the person",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return work order due at or after, RFC3339",
+                        "name": "due_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return work order due before, RFC3339",
+                        "name": "due_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only return unfinished work order past its due date",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/maintenance.ListWorkOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid due date window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Maintenance"
+                ],
+                "summary": "create a scheduled or ad-hoc work order against a pond or equipment, open blocking work order moves the pond into maintenance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "work order payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/maintenance.WorkOrderPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown type or invalid recurrence",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "pond or equipment not existed in the farm",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "pond can't be moved into maintenance",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/work-orders/{workOrderID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Maintenance"
+                ],
+                "summary": "get specific work order by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Work Order ID",
+                        "name": "workOrderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/maintenance.WorkOrderResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Maintenance"
+                ],
+                "summary": "update work order details, use the status endpoint to move it",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Work Order ID",
+                        "name": "workOrderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "work order payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/maintenance.WorkOrderPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown type or invalid recurrence",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "work order, pond or equipment not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "pond can't be moved into maintenance",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Maintenance"
+                ],
+                "summary": "delete specific work order by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Work Order ID",
+                        "name": "workOrderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "work order not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/work-orders/{workOrderID}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Maintenance"
+                ],
+                "summary": "move work order into the next status, finishing a recurring one schedules its next occurrence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Work Order ID",
+                        "name": "workOrderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "status transition",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/maintenance.WorkOrderStatusPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown status",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "work order not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "status can't be moved into the requested one",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/yield": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harvest"
+                ],
+                "summary": "get harvest yield and revenue of a farm per pond",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of date window (inclusive), RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of date window (exclusive), RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/harvest.FarmYieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "no harvest recorded",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{farmID}/yield/seasons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harvest"
+                ],
+                "summary": "get harvest yield and revenue of a farm per season",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Farm ID",
+                        "name": "farmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "quarter",
+                            "year"
+                        ],
+                        "type": "string",
+                        "description": "length of a season, default to quarter",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of date window (inclusive), RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of date window (exclusive), RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/harvest.ListSeasonYieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid season or date window",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "no harvest recorded",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/misc/ping": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Misc"
+                ],
+                "summary": "check server status",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "get all organizations, non-superadmin only sees its own",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/organizations.ListOrganizationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "create a new organization, farms and users of an organization are invisible to another",
+                "parameters": [
+                    {
+                        "description": "organization payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organizations.OrganizationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "non-superadmin user",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "name already taken",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organizationID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "get specific organization by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organizationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/organizations.OrganizationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/telemetry/request-metrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Misc"
+                ],
+                "summary": "get request metrics for all registered API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/telemetry.ListRequestMetricResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "get all users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "number of entity per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "n-th page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.ListUserResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "create a new user within organization of the admin, only superadmin may pick another one",
+                "parameters": [
+                    {
+                        "description": "user payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.UserPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "password shorter than 8 or longer than 72 characters",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "caller isn't an admin",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "organization not existed",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "username already taken",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "get the logged in user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "change password of the logged in user, ending every other session",
+                "parameters": [
+                    {
+                        "description": "password payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.PasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "password shorter than 8 or longer than 72 characters",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid old password",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "get specific user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/users.UserResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpres.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "access.ListMemberResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/access.MemberResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "access.MemberPayload": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "manager",
+                        "technician",
+                        "viewer",
+                        "auditor"
+                    ],
+                    "example": "technician"
+                }
+            }
+        },
+        "access.MemberResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-08-16T01:35:20Z"
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "technician"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "username": {
+                    "type": "string",
+                    "example": "budi"
+                }
+            }
+        },
+        "alerts.AlertResponse": {
+            "type": "object",
+            "properties": {
+                "acknowledged_at": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "opened_at": {
+                    "type": "string",
+                    "example": "2024-07-01T02:00:00Z"
+                },
+                "parameter": {
+                    "type": "string",
+                    "example": "dissolved_oxygen"
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reading_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 3.1
+                }
+            }
+        },
+        "alerts.AlertStatusPayload": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "acknowledged",
+                        "resolved"
+                    ],
+                    "example": "acknowledged"
+                }
+            }
+        },
+        "alerts.ListAlertResponse": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/alerts.AlertResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "alerts.ListRuleResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/alerts.RuleResponse"
+                    }
+                }
+            }
+        },
+        "alerts.RulePayload": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_value": {
+                    "type": "number"
+                },
+                "min_value": {
+                    "type": "number",
+                    "example": 4
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Low DO"
+                },
+                "parameter": {
+                    "type": "string",
+                    "enum": [
+                        "dissolved_oxygen",
+                        "ph",
+                        "temperature",
+                        "salinity",
+                        "ammonia",
+                        "nitrite",
+                        "turbidity"
+                    ],
+                    "example": "dissolved_oxygen"
+                },
+                "pond_id": {
+                    "description": "leave empty to apply the rule for every pond in the farm",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "alerts.RuleResponse": {
+            "type": "object",
+            "properties": {
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_value": {
+                    "type": "number"
+                },
+                "min_value": {
+                    "type": "number",
+                    "example": 4
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Low DO"
+                },
+                "parameter": {
+                    "type": "string",
+                    "example": "dissolved_oxygen"
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "apikeys.APIKeyPayload": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "never expires when empty",
+                    "type": "string",
+                    "example": "2025-08-18T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ERP integration"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "read:farms",
+                            "write:ponds",
+                            "ingest:sensors"
+                        ]
+                    },
+                    "example": [
+                        "read:farms",
+                        "write:ponds"
+                    ]
+                }
+            }
+        },
+        "apikeys.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-08-18T02:25:10Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-08-18T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-08-18T06:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ERP integration"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "dfk_Xq3b"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read:farms",
+                        "write:ponds"
+                    ]
+                }
+            }
+        },
+        "apikeys.APIKeySecretResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-08-18T02:25:10Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-08-18T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "type": "string",
+                    "example": "dfk_Xq3b..."
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-08-18T06:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ERP integration"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "dfk_Xq3b"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read:farms",
+                        "write:ponds"
+                    ]
+                }
+            }
+        },
+        "apikeys.ListAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apikeys.APIKeyResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "audit.AuditResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "actor_id": {
+                    "description": "empty for change made by scheduled job",
+                    "type": "integer",
+                    "example": 1
+                },
+                "api_key_id": {
+                    "type": "integer"
+                },
+                "changes": {
+                    "$ref": "#/definitions/audit.Changes"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-08-22T01:20:30Z"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "farm"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "0b8d5c6e-2f0a-4a55-9d8e-2f4c0b9a7e11"
+                }
+            }
+        },
+        "audit.Change": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "audit.Changes": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/audit.Change"
+            }
+        },
+        "audit.ListAuditResponse": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.AuditResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "checklists.CompleteTaskPayload": {
+            "type": "object",
+            "properties": {
+                "completed_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "DO low near inlet, aerator 2 turned on"
+                },
+                "reading": {
+                    "type": "number",
+                    "example": 4.2
+                },
+                "status": {
+                    "description": "default to done",
+                    "type": "string",
+                    "enum": [
+                        "done",
+                        "skipped"
+                    ],
+                    "example": "done"
+                }
+            }
+        },
+        "checklists.DailyReportResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-08-13"
+                },
+                "done": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "workers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/checklists.WorkerReportResponse"
+                    }
+                }
+            }
+        },
+        "checklists.ListTaskResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/checklists.TaskResponse"
+                    }
+                }
+            }
+        },
+        "checklists.ListTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/checklists.TemplateResponse"
+                    }
+                }
+            }
+        },
+        "checklists.ReportResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/checklists.DailyReportResponse"
+                    }
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "checklists.TaskResponse": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string",
+                    "example": "2024-08-12T21:55:00Z"
+                },
+                "completed_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-08-13"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2024-08-12T22:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Morning DO check"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "DO low near inlet, aerator 2 turned on"
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reading": {
+                    "type": "number",
+                    "example": 4.2
+                },
+                "reading_type": {
+                    "type": "string",
+                    "example": "dissolved_oxygen"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "done"
+                },
+                "template_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "checklists.TemplatePayload": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "default to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Measure DO near the aerator and at the far corner"
+                },
+                "due_time": {
+                    "description": "HH:MM in farm's timezone",
+                    "type": "string",
+                    "example": "06:00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Morning DO check"
+                },
+                "pond_id": {
+                    "description": "leave empty for farm-wide task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "reading_type": {
+                    "description": "parameter measured on completion",
+                    "type": "string",
+                    "example": "dissolved_oxygen"
+                }
+            }
+        },
+        "checklists.TemplateResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Measure DO near the aerator and at the far corner"
+                },
+                "due_time": {
+                    "type": "string",
+                    "example": "06:00"
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Morning DO check"
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reading_type": {
+                    "type": "string",
+                    "example": "dissolved_oxygen"
+                }
+            }
+        },
+        "checklists.WorkerReportResponse": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "late": {
+                    "description": "done after its due time",
+                    "type": "integer",
+                    "example": 1
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "worker": {
+                    "type": "string",
+                    "example": "budi"
+                }
+            }
+        },
+        "devices.DeviceKeyResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "type": "string",
+                    "example": "4f9c2a..."
+                },
+                "sensor_id": {
+                    "type": "string",
+                    "example": "DO-00A1B2"
+                }
+            }
+        },
+        "devices.DevicePayload": {
+            "type": "object",
+            "properties": {
+                "calibration_offset": {
+                    "description": "added into every raw value",
+                    "type": "number",
+                    "example": -0.15
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sensor_id": {
+                    "type": "string",
+                    "example": "DO-00A1B2"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "dissolved_oxygen",
+                        "ph",
+                        "temperature",
+                        "salinity",
+                        "ammonia",
+                        "nitrite",
+                        "turbidity",
+                        "water_level",
+                        "aerator_power"
+                    ],
+                    "example": "dissolved_oxygen"
+                }
+            }
+        },
+        "devices.DeviceResponse": {
+            "type": "object",
+            "properties": {
+                "calibration_offset": {
+                    "type": "number",
+                    "example": -0.15
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_seen_at": {
+                    "type": "string",
+                    "example": "2024-08-05T06:00:00Z"
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sensor_id": {
+                    "type": "string",
+                    "example": "DO-00A1B2"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "dissolved_oxygen"
+                }
+            }
+        },
+        "devices.IngestPayload": {
+            "type": "object",
+            "properties": {
+                "readings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/devices.IngestReadingPayload"
+                    }
+                }
+            }
+        },
+        "devices.IngestReadingPayload": {
+            "type": "object",
+            "properties": {
+                "measured_at": {
+                    "description": "default to time of ingestion",
+                    "type": "string",
+                    "example": "2024-08-05T06:00:00Z"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 5.35
+                }
+            }
+        },
+        "devices.IngestResponse": {
+            "type": "object",
+            "properties": {
+                "received": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "stored": {
+                    "description": "readings already stored before are skipped",
+                    "type": "integer",
+                    "example": 58
+                }
+            }
+        },
+        "devices.ListDeviceResponse": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/devices.DeviceResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "devices.ListSensorReadingResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "readings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/devices.SensorReadingResponse"
+                    }
+                }
+            }
+        },
+        "devices.SensorReadingResponse": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "measured_at": {
+                    "type": "string",
+                    "example": "2024-08-05T06:00:00Z"
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "raw_value": {
+                    "type": "number",
+                    "example": 5.35
+                },
+                "type": {
+                    "type": "string",
+                    "example": "dissolved_oxygen"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 5.2
+                }
+            }
+        },
+        "devices.SeriesPointResponse": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number",
+                    "example": 5.2
+                },
+                "bucket": {
+                    "type": "string",
+                    "example": "2024-08-05T06:00:00Z"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "max": {
+                    "type": "number",
+                    "example": 5.9
+                },
+                "min": {
+                    "type": "number",
+                    "example": 4.8
+                }
+            }
+        },
+        "devices.SeriesResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2024-08-05T00:00:00Z"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/devices.SeriesPointResponse"
+                    }
+                },
+                "resolution": {
+                    "type": "string",
+                    "example": "5m"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-08-06T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "dissolved_oxygen"
+                }
+            }
+        },
+        "devices.TopicIngestPayload": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "key of the device, taken from X-Device-Key header over HTTP",
+                    "type": "string",
+                    "example": "4f9c2a..."
+                },
+                "measured_at": {
+                    "type": "string",
+                    "example": "2024-08-05T06:00:00Z"
+                },
+                "readings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/devices.IngestReadingPayload"
+                    }
+                },
+                "sensor_id": {
+                    "description": "required when the pond has several devices of the same type",
+                    "type": "string",
+                    "example": "DO-00A1B2"
+                },
+                "value": {
+                    "description": "single reading shorthand, used when readings is empty",
+                    "type": "number",
+                    "example": 5.35
+                }
+            }
+        },
+        "equipments.EquipmentEnergyResponse": {
+            "type": "object",
+            "properties": {
+                "energy": {
+                    "description": "in kWh",
+                    "type": "number",
+                    "example": 540
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Paddlewheel A1"
+                },
+                "power_rating": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "run_hours": {
+                    "type": "number",
+                    "example": 360
+                },
+                "type": {
+                    "type": "string",
+                    "example": "aerator"
+                }
+            }
+        },
+        "equipments.EquipmentPayload": {
+            "type": "object",
+            "properties": {
+                "install_date": {
+                    "type": "string",
+                    "example": "2024-01-15T00:00:00Z"
+                },
+                "make": {
+                    "type": "string",
+                    "example": "Taiwan Paddlewheel PW-2HP"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Paddlewheel A1"
+                },
+                "pond_id": {
+                    "description": "leave empty for farm-wide equipment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "power_rating": {
+                    "description": "in kW",
+                    "type": "number",
+                    "example": 1.5
+                },
+                "status": {
+                    "description": "default to active",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "inactive",
+                        "broken",
+                        "retired"
+                    ],
+                    "example": "active"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "aerator",
+                        "pump",
+                        "feeder",
+                        "blower"
+                    ],
+                    "example": "aerator"
+                }
+            }
+        },
+        "equipments.EquipmentResponse": {
+            "type": "object",
+            "properties": {
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "install_date": {
+                    "type": "string",
+                    "example": "2024-01-15T00:00:00Z"
+                },
+                "make": {
+                    "type": "string",
+                    "example": "Taiwan Paddlewheel PW-2HP"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Paddlewheel A1"
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "power_rating": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "run_hours": {
+                    "description": "cumulative since installed",
+                    "type": "number",
+                    "example": 1250.5
+                },
+                "running": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "aerator"
+                }
+            }
+        },
+        "equipments.FarmEnergyResponse": {
+            "type": "object",
+            "properties": {
+                "energy": {
+                    "description": "in kWh",
+                    "type": "number",
+                    "example": 3240
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-08-01T00:00:00Z"
+                },
+                "ponds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/equipments.PondEnergyResponse"
+                    }
+                },
+                "run_hours": {
+                    "type": "number",
+                    "example": 2160
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-09-01T00:00:00Z"
+                }
+            }
+        },
+        "equipments.ListEquipmentResponse": {
+            "type": "object",
+            "properties": {
+                "equipments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/equipments.EquipmentResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "equipments.ListRuntimeResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "runtimes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/equipments.RuntimeResponse"
+                    }
+                }
+            }
+        },
+        "equipments.PondEnergyResponse": {
+            "type": "object",
+            "properties": {
+                "energy": {
+                    "description": "in kWh",
+                    "type": "number",
+                    "example": 1080
+                },
+                "equipments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/equipments.EquipmentEnergyResponse"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-08-01T00:00:00Z"
+                },
+                "pond_id": {
+                    "description": "null for farm-wide equipments",
+                    "type": "integer",
+                    "example": 1
+                },
+                "run_hours": {
+                    "type": "number",
+                    "example": 720
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-09-01T00:00:00Z"
+                }
+            }
+        },
+        "equipments.RuntimeResponse": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "number",
+                    "example": 12
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2024-08-05T18:00:00Z"
+                },
+                "stopped_at": {
+                    "description": "null while still running",
+                    "type": "string",
+                    "example": "2024-08-06T06:00:00Z"
+                }
+            }
+        },
+        "equipments.SwitchPayload": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "description": "default to now",
+                    "type": "string",
+                    "example": "2024-08-05T18:00:00Z"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "on",
+                        "off"
+                    ],
+                    "example": "on"
+                }
+            }
+        },
+        "farms.DeleteFarmResponse": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "description": "keyed by table, ex: {\"farms\": 1, \"ponds\": 4}, append-only logs are only removed on purge",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "farms.FarmPayload": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Pantai No. 1, Denpasar"
+                },
+                "area": {
+                    "description": "in m2, default to area of boundary",
+                    "type": "number",
+                    "example": 25000
+                },
+                "boundary": {
+                    "$ref": "#/definitions/geo.Polygon"
+                },
+                "contact": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": -8.65
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 115.22
+                },
+                "metadata": {
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Farm A"
+                },
+                "owner_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "Bali"
+                },
+                "timezone": {
+                    "description": "IANA timezone, default to Asia/Makassar",
+                    "type": "string",
+                    "example": "Asia/Makassar"
+                }
+            }
+        },
+        "farms.FarmResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Pantai No. 1, Denpasar"
+                },
+                "area": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "boundary": {
+                    "$ref": "#/definitions/geo.Polygon"
+                },
+                "contact": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                },
+                "distance": {
+                    "description": "in km, only on proximity query",
+                    "type": "number",
+                    "example": 1.5
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": -8.65
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 115.22
+                },
+                "metadata": {
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Farm A"
+                },
+                "owner_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "Bali"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Makassar"
+                }
+            }
+        },
+        "farms.ListFarmResponse": {
+            "type": "object",
+            "properties": {
+                "farms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/farms.FarmResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "feeding.FCRResponse": {
+            "type": "object",
+            "properties": {
+                "avg_weight": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "biomass_gain": {
+                    "type": "number",
+                    "example": 1123
+                },
+                "current_biomass": {
+                    "type": "number",
+                    "example": 1125
+                },
+                "fcr": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "harvested_biomass": {
+                    "type": "number",
+                    "example": 0
+                },
+                "headcount": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "initial_biomass": {
+                    "type": "number",
+                    "example": 2
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_feed": {
+                    "type": "number",
+                    "example": 1350
+                }
+            }
+        },
+        "feeding.FeedingPayload": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Brand A"
+                },
+                "fed_at": {
+                    "type": "string",
+                    "example": "2024-07-01T07:00:00Z"
+                },
+                "feed_type": {
+                    "type": "string",
+                    "example": "grower"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "feeding.FeedingResponse": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Brand A"
+                },
+                "fed_at": {
+                    "type": "string",
+                    "example": "2024-07-01T07:00:00Z"
+                },
+                "feed_type": {
+                    "type": "string",
+                    "example": "grower"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "feeding.ListFeedingResponse": {
+            "type": "object",
+            "properties": {
+                "feedings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/feeding.FeedingResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "geo.Feature": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "description": "null for entity without recorded boundary",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/geo.Polygon"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "properties": {},
+                "type": {
+                    "type": "string",
+                    "example": "Feature"
+                }
+            }
+        },
+        "geo.FeatureCollection": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/geo.Feature"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "FeatureCollection"
+                }
+            }
+        },
+        "geo.Polygon": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Polygon"
+                }
+            }
+        },
+        "growth.EstimateResponse": {
+            "type": "object",
+            "properties": {
+                "avg_length": {
+                    "type": "number",
+                    "example": 10.83
+                },
+                "avg_weight": {
+                    "type": "number",
+                    "example": 12.2
+                },
+                "batch_name": {
+                    "type": "string",
+                    "example": "2024-A"
+                },
+                "biomass": {
+                    "description": "in kg",
+                    "type": "number",
+                    "example": 1098
+                },
+                "daily_growth_rate": {
+                    "description": "in gram/day",
+                    "type": "number",
+                    "example": 0.39
+                },
+                "days_of_culture": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "headcount": {
+                    "description": "net of mortality and harvest",
+                    "type": "integer",
+                    "example": 90000
+                },
+                "sampled_at": {
+                    "type": "string",
+                    "example": "2024-08-01T00:00:00Z"
+                },
+                "specific_growth_rate": {
+                    "description": "in %/day",
+                    "type": "number",
+                    "example": 21.46
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "growth.ListSampleResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "samples": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/growth.SampleResponse"
+                    }
+                }
+            }
+        },
+        "growth.SamplePayload": {
+            "type": "object",
+            "properties": {
+                "lengths": {
+                    "description": "in cm, optional",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        10.5,
+                        11.2,
+                        10.8
+                    ]
+                },
+                "sample_size": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "sampled_at": {
+                    "type": "string",
+                    "example": "2024-08-01T00:00:00Z"
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_weight": {
+                    "description": "in gram",
+                    "type": "number",
+                    "example": 610
+                }
+            }
+        },
+        "growth.SampleResponse": {
+            "type": "object",
+            "properties": {
+                "avg_length": {
+                    "type": "number",
+                    "example": 10.83
+                },
+                "avg_weight": {
+                    "type": "number",
+                    "example": 12.2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lengths": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        10.5,
+                        11.2,
+                        10.8
+                    ]
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sample_size": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "sampled_at": {
+                    "type": "string",
+                    "example": "2024-08-01T00:00:00Z"
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_weight": {
+                    "type": "number",
+                    "example": 610
+                }
+            }
+        },
+        "harvest.FarmYieldResponse": {
+            "type": "object",
+            "properties": {
+                "avg_price": {
+                    "type": "number",
+                    "example": 65000
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "harvests": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "ponds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/harvest.PondYieldResponse"
+                    }
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 159282500
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 2450.5
+                }
+            }
+        },
+        "harvest.HarvestPayload": {
+            "type": "object",
+            "properties": {
+                "buyer": {
+                    "type": "string",
+                    "example": "PT Mina Sejahtera"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 42000
+                },
+                "harvested_on": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "price_per_kg": {
+                    "type": "number",
+                    "example": 65000
+                },
+                "size_grade": {
+                    "type": "string",
+                    "example": "50"
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "partial",
+                        "total"
+                    ],
+                    "example": "partial"
+                },
+                "weight": {
+                    "description": "in kg",
+                    "type": "number",
+                    "example": 850.5
+                }
+            }
+        },
+        "harvest.HarvestResponse": {
+            "type": "object",
+            "properties": {
+                "buyer": {
+                    "type": "string",
+                    "example": "PT Mina Sejahtera"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 42000
+                },
+                "harvested_on": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price_per_kg": {
+                    "type": "number",
+                    "example": 65000
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 55282500
+                },
+                "size_grade": {
+                    "type": "string",
+                    "example": "50"
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "type": {
+                    "type": "string",
+                    "example": "partial"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 850.5
+                }
+            }
+        },
+        "harvest.ListHarvestResponse": {
+            "type": "object",
+            "properties": {
+                "harvests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/harvest.HarvestResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                }
+            }
+        },
+        "harvest.ListSeasonYieldResponse": {
+            "type": "object",
+            "properties": {
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "season": {
+                    "type": "string",
+                    "example": "quarter"
+                },
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/harvest.SeasonYieldResponse"
+                    }
+                }
+            }
+        },
+        "harvest.PondYieldResponse": {
+            "type": "object",
+            "properties": {
+                "avg_price": {
+                    "type": "number",
+                    "example": 65000
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harvests": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 159282500
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 2450.5
+                }
+            }
+        },
+        "harvest.SeasonYieldResponse": {
+            "type": "object",
+            "properties": {
+                "avg_price": {
+                    "type": "number",
+                    "example": 65000
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harvests": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 159282500
+                },
+                "season": {
+                    "description": "start of the season",
+                    "type": "string",
+                    "example": "2024-07-01T00:00:00Z"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 2450.5
+                }
+            }
+        },
+        "httpres.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 404001
+                },
+                "msg": {
+                    "type": "string",
+                    "example": "unknown"
+                }
+            }
+        },
+        "httpres.ListPagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "maintenance.ListWorkOrderResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "work_orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/maintenance.WorkOrderResponse"
+                    }
+                }
+            }
+        },
+        "maintenance.WorkOrderPayload": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "blocking": {
+                    "description": "pond is moved into maintenance while it's unfinished",
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Spread 200kg of agricultural lime"
+                },
+                "due_at": {
+                    "description": "work order due in the future starts scheduled",
+                    "type": "string",
+                    "example": "2024-08-10T00:00:00Z"
+                },
+                "equipment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recurrence": {
+                    "description": "requires due_at",
+                    "type": "string",
+                    "example": "FREQ=MONTHLY;INTERVAL=3"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Lime pond bottom"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "drying",
+                        "liming",
+                        "liner_repair",
+                        "servicing",
+                        "inspection",
+                        "other"
+                    ],
+                    "example": "liming"
+                }
+            }
+        },
+        "maintenance.WorkOrderResponse": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "blocking": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2024-08-10T05:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Spread 200kg of agricultural lime"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2024-08-10T00:00:00Z"
+                },
+                "equipment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "done, 180kg used"
+                },
+                "overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=MONTHLY;INTERVAL=3"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2024-08-10T01:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Lime pond bottom"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "liming"
+                }
+            }
+        },
+        "maintenance.WorkOrderStatusPayload": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "done, 180kg used"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "in_progress",
+                        "on_hold",
+                        "done",
+                        "cancelled"
+                    ],
+                    "example": "done"
+                }
+            }
+        },
+        "mortality.BatchSurvivalResponse": {
+            "type": "object",
+            "properties": {
+                "batch_name": {
+                    "type": "string",
+                    "example": "2024-A"
+                },
+                "closed": {
+                    "description": "totally harvested",
+                    "type": "boolean",
+                    "example": false
+                },
+                "headcount": {
+                    "description": "live fish left in the pond, none once the batch is closed",
+                    "type": "integer",
+                    "example": 99850
+                },
+                "initial_count": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "survival_rate": {
+                    "description": "harvested fish are counted as survived",
+                    "type": "number",
+                    "example": 99.85
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mortality.SurvivalPointResponse"
+                    }
+                },
+                "total_harvested": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total_mortality": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "mortality.FarmSurvivalResponse": {
+            "type": "object",
+            "properties": {
+                "farm_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "headcount": {
+                    "type": "integer",
+                    "example": 99850
+                },
+                "initial_count": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "ponds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mortality.PondSurvivalResponse"
+                    }
+                },
+                "survival_rate": {
+                    "type": "number",
+                    "example": 99.85
+                },
+                "total_harvested": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total_mortality": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "mortality.ListMortalityResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "mortalities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mortality.MortalityResponse"
+                    }
+                }
+            }
+        },
+        "mortality.MortalityPayload": {
+            "type": "object",
+            "properties": {
+                "cause": {
+                    "type": "string",
+                    "example": "low DO"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "recorded_on": {
+                    "type": "string",
+                    "example": "2024-07-10T00:00:00Z"
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "mortality.MortalityResponse": {
+            "type": "object",
+            "properties": {
+                "cause": {
+                    "type": "string",
+                    "example": "low DO"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recorded_on": {
+                    "type": "string",
+                    "example": "2024-07-10T00:00:00Z"
+                },
+                "stocking_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "mortality.PondSurvivalResponse": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mortality.BatchSurvivalResponse"
+                    }
+                },
+                "headcount": {
+                    "type": "integer",
+                    "example": 99850
+                },
+                "initial_count": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "survival_rate": {
+                    "type": "number",
+                    "example": 99.85
+                },
+                "total_harvested": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total_mortality": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "mortality.SurvivalPointResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-07-10T00:00:00Z"
+                },
+                "headcount": {
+                    "type": "integer",
+                    "example": 99850
+                },
+                "mortality": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "survival_rate": {
+                    "type": "number",
+                    "example": 99.85
+                }
+            }
+        },
+        "organizations.ListOrganizationResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "organizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/organizations.OrganizationResponse"
+                    }
+                }
+            }
+        },
+        "organizations.OrganizationPayload": {
             "type": "object",
             "properties": {
                 "name": {
                     "type": "string",
-                    "example": "Farm A"
+                    "example": "Koperasi Tambak Jembrana"
                 }
             }
         },
-        "farms.FarmResponse": {
+        "organizations.OrganizationResponse": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-08-20T01:42:15Z"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
                 "name": {
                     "type": "string",
-                    "example": "Farm A"
+                    "example": "Koperasi Tambak Jembrana"
                 }
             }
         },
-        "farms.ListFarmResponse": {
+        "ponds.ListPondResponse": {
             "type": "object",
             "properties": {
-                "farms": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "ponds": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/farms.FarmResponse"
+                        "$ref": "#/definitions/ponds.PondResponse"
                     }
-                },
-                "meta": {
-                    "$ref": "#/definitions/httpres.ListPagination"
                 }
             }
         },
-        "httpres.ErrorResponse": {
+        "ponds.PondPayload": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 404001
+                "aeration_capacity": {
+                    "description": "in hp",
+                    "type": "number",
+                    "example": 4
                 },
-                "msg": {
+                "area": {
+                    "description": "in m2, default to area of boundary",
+                    "type": "number",
+                    "example": 1000
+                },
+                "boundary": {
+                    "$ref": "#/definitions/geo.Polygon"
+                },
+                "depth": {
+                    "description": "in m",
+                    "type": "number",
+                    "example": 1.2
+                },
+                "name": {
                     "type": "string",
-                    "example": "unknown"
+                    "example": "Pond 1"
+                },
+                "status": {
+                    "description": "default to current status",
+                    "type": "string",
+                    "enum": [
+                        "idle",
+                        "preparing",
+                        "stocked",
+                        "harvesting",
+                        "maintenance"
+                    ],
+                    "example": "idle"
+                },
+                "type": {
+                    "description": "default to earthen",
+                    "type": "string",
+                    "enum": [
+                        "earthen",
+                        "concrete",
+                        "tarpaulin",
+                        "ras"
+                    ],
+                    "example": "earthen"
+                },
+                "volume": {
+                    "description": "in m3, default to area * depth",
+                    "type": "number",
+                    "example": 1200
                 }
             }
         },
-        "httpres.ListPagination": {
+        "ponds.PondResponse": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer",
-                    "example": 100
+                "aeration_capacity": {
+                    "type": "number",
+                    "example": 4
                 },
-                "page": {
+                "area": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "boundary": {
+                    "$ref": "#/definitions/geo.Polygon"
+                },
+                "depth": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "farm_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "total_page": {
+                "farm_name": {
+                    "type": "string",
+                    "example": "Farm A"
+                },
+                "growth": {
+                    "$ref": "#/definitions/growth.EstimateResponse"
+                },
+                "id": {
                     "type": "integer",
-                    "example": 10
+                    "example": 1
+                },
+                "pond_name": {
+                    "type": "string",
+                    "example": "Pond A"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "idle"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "earthen"
+                },
+                "volume": {
+                    "type": "number",
+                    "example": 1200
                 }
             }
         },
-        "ponds.ListPondResponse": {
+        "stocking.ListStockingResponse": {
             "type": "object",
             "properties": {
                 "meta": {
                     "$ref": "#/definitions/httpres.ListPagination"
                 },
-                "ponds": {
+                "stockings": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ponds.PondResponse"
+                        "$ref": "#/definitions/stocking.StockingResponse"
                     }
                 }
             }
         },
-        "ponds.PondPayload": {
+        "stocking.StockingPayload": {
             "type": "object",
             "properties": {
-                "name": {
+                "avg_weight": {
+                    "type": "number",
+                    "example": 0.02
+                },
+                "batch_name": {
                     "type": "string",
-                    "example": "Pond 1"
+                    "example": "2024-A"
+                },
+                "cost": {
+                    "type": "number",
+                    "example": 3500000
+                },
+                "hatchery": {
+                    "type": "string",
+                    "example": "Hatchery A"
+                },
+                "initial_count": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "species": {
+                    "type": "string",
+                    "example": "Litopenaeus vannamei"
+                },
+                "stocked_at": {
+                    "type": "string",
+                    "example": "2024-07-01T00:00:00Z"
                 }
             }
         },
-        "ponds.PondResponse": {
+        "stocking.StockingResponse": {
             "type": "object",
             "properties": {
-                "farm_id": {
-                    "type": "integer",
-                    "example": 1
+                "avg_weight": {
+                    "type": "number",
+                    "example": 0.02
                 },
-                "farm_name": {
+                "batch_name": {
                     "type": "string",
-                    "example": "Farm A"
+                    "example": "2024-A"
+                },
+                "closed_at": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "cost": {
+                    "type": "number",
+                    "example": 3500000
+                },
+                "hatchery": {
+                    "type": "string",
+                    "example": "Hatchery A"
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "pond_name": {
+                "initial_count": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "species": {
                     "type": "string",
-                    "example": "Pond A"
+                    "example": "Litopenaeus vannamei"
+                },
+                "stocked_at": {
+                    "type": "string",
+                    "example": "2024-07-01T00:00:00Z"
                 }
             }
         },
@@ -699,6 +9864,253 @@ const docTemplate = `{
                     "example": 5
                 }
             }
+        },
+        "users.ListUserResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/users.UserResponse"
+                    }
+                }
+            }
+        },
+        "users.LoginPayload": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "s3cr3t-pa55"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "budi"
+                }
+            }
+        },
+        "users.LogoutPayload": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "description": "end every session of the user instead of the current one",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "users.PasswordPayload": {
+            "type": "object",
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "example": "n3w-s3cr3t-pa55"
+                },
+                "old_password": {
+                    "type": "string",
+                    "example": "s3cr3t-pa55"
+                }
+            }
+        },
+        "users.RefreshPayload": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "b64c2Vzc2lvbi1yZWZyZXNo..."
+                }
+            }
+        },
+        "users.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_in": {
+                    "description": "lifetime of access token in seconds",
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "b64c2Vzc2lvbi1yZWZyZXNo..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "users.UserPayload": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "description": "admin isn't bound by farm roles",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "organization_id": {
+                    "description": "only honoured for superadmin, default to organization of the creator",
+                    "type": "integer",
+                    "example": 1
+                },
+                "password": {
+                    "type": "string",
+                    "example": "s3cr3t-pa55"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "budi"
+                }
+            }
+        },
+        "users.UserResponse": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-08-15T02:10:40Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "example": "budi"
+                }
+            }
+        },
+        "waterquality.ListReadingResponse": {
+            "type": "object",
+            "properties": {
+                "meta": {
+                    "$ref": "#/definitions/httpres.ListPagination"
+                },
+                "readings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/waterquality.ReadingResponse"
+                    }
+                }
+            }
+        },
+        "waterquality.ReadingPayload": {
+            "type": "object",
+            "properties": {
+                "ammonia": {
+                    "type": "number",
+                    "example": 0.02
+                },
+                "dissolved_oxygen": {
+                    "type": "number",
+                    "example": 5.2
+                },
+                "measured_at": {
+                    "type": "string",
+                    "example": "2024-07-01T06:00:00Z"
+                },
+                "nitrite": {
+                    "type": "number",
+                    "example": 0.1
+                },
+                "ph": {
+                    "type": "number",
+                    "example": 7.4
+                },
+                "salinity": {
+                    "type": "number",
+                    "example": 15
+                },
+                "temperature": {
+                    "type": "number",
+                    "example": 28.5
+                },
+                "turbidity": {
+                    "type": "number",
+                    "example": 30
+                }
+            }
+        },
+        "waterquality.ReadingResponse": {
+            "type": "object",
+            "properties": {
+                "ammonia": {
+                    "type": "number",
+                    "example": 0.02
+                },
+                "dissolved_oxygen": {
+                    "type": "number",
+                    "example": 5.2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "measured_at": {
+                    "type": "string",
+                    "example": "2024-07-01T06:00:00Z"
+                },
+                "nitrite": {
+                    "type": "number",
+                    "example": 0.1
+                },
+                "ph": {
+                    "type": "number",
+                    "example": 7.4
+                },
+                "pond_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "salinity": {
+                    "type": "number",
+                    "example": 15
+                },
+                "temperature": {
+                    "type": "number",
+                    "example": 28.5
+                },
+                "turbidity": {
+                    "type": "number",
+                    "example": 30
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key prefixed with \"ApiKey \"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "access token prefixed with \"Bearer \"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
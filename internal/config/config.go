@@ -2,23 +2,43 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"log"
 	"os"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
 	postgresDB "github.com/nmluci/da-farm-be/internal/database/postgres"
+	"github.com/nmluci/da-farm-be/internal/mqtt"
 )
 
 var conf Config
 
+// DefaultPurgeRetention keep soft-deleted farms and ponds restorable for 30 days
+const DefaultPurgeRetention = 30 * 24 * time.Hour
+
+// EnvDevelopment relax settings required to run the service, ex: JWT secret
+const EnvDevelopment = "development"
+
 type Config struct {
 	ServiceName    string
 	ServiceAddress string
 	SwaggerHost    string
+	Environment    string
 
 	RunSince     time.Time
 	PostgresConf *postgresDB.PostgresConfig
+	MQTTConf     *mqtt.MQTTConfig
+	JWTConf      *auth.JWTConfig
+
+	// initial user created on a fresh deployment
+	AdminUsername string
+	AdminPassword string
+
+	// soft-deleted farms and ponds are purged once deleted longer than this
+	PurgeRetention time.Duration
 }
 
 func New() *Config {
@@ -30,6 +50,7 @@ func New() *Config {
 		ServiceName:    os.Getenv("SVC_NAME"),
 		ServiceAddress: os.Getenv("SVC_ADDRESS"),
 		SwaggerHost:    os.Getenv("SWAGGER_HOST"),
+		Environment:    os.Getenv("SVC_ENV"),
 		RunSince:       time.Now(),
 		PostgresConf: &postgresDB.PostgresConfig{
 			Address:  os.Getenv("POSTGRES_ADDRESS"),
@@ -37,6 +58,35 @@ func New() *Config {
 			Password: os.Getenv("POSTGRES_PASSWORD"),
 			DB:       os.Getenv("POSTGRES_DB"),
 		},
+		MQTTConf: &mqtt.MQTTConfig{
+			Broker:   os.Getenv("MQTT_BROKER"),
+			ClientID: os.Getenv("MQTT_CLIENT_ID"),
+			Username: os.Getenv("MQTT_USERNAME"),
+			Password: os.Getenv("MQTT_PASSWORD"),
+		},
+		JWTConf: &auth.JWTConfig{
+			Secret:     os.Getenv("JWT_SECRET"),
+			AccessTTL:  parseDuration(os.Getenv("JWT_ACCESS_TTL"), auth.DefaultAccessTTL),
+			RefreshTTL: parseDuration(os.Getenv("JWT_REFRESH_TTL"), auth.DefaultRefreshTTL),
+		},
+		AdminUsername:  os.Getenv("ADMIN_USERNAME"),
+		AdminPassword:  os.Getenv("ADMIN_PASSWORD"),
+		PurgeRetention: parseDuration(os.Getenv("PURGE_RETENTION"), DefaultPurgeRetention),
+	}
+
+	// a guessable secret let anyone forge tokens, thus only development may go without one
+	if conf.JWTConf.Secret == "" {
+		if conf.Environment != EnvDevelopment {
+			log.Fatalln("JWT_SECRET not set, refusing to start outside development")
+		}
+
+		log.Println("JWT_SECRET not set, using a random secret, issued tokens won't survive a restart")
+
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			log.Fatalf("failed to generate JWT secret, err: %s\n", err)
+		}
+		conf.JWTConf.Secret = hex.EncodeToString(buf)
 	}
 
 	return &conf
@@ -45,3 +95,13 @@ func New() *Config {
 func Get() *Config {
 	return &conf
 }
+
+// parseDuration return def when val is empty or malformed
+func parseDuration(val string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(val)
+	if err != nil || d <= 0 {
+		return def
+	}
+
+	return d
+}
@@ -0,0 +1,74 @@
+// Package auth carry identity of the authenticated caller across layers
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DefaultAccessTTL  = 15 * time.Minute
+	DefaultRefreshTTL = 7 * 24 * time.Hour
+)
+
+// actions a farm role may be permitted to perform
+const (
+	ActionRead         = "read"
+	ActionWrite        = "write" // record operational data, ex: stocking, feeding, pond state
+	ActionDeletePond   = "delete_pond"
+	ActionWriteFarm    = "write_farm"
+	ActionDeleteFarm   = "delete_farm"
+	ActionManageMember = "manage_member"
+	ActionReadAudit    = "read_audit"
+)
+
+// scopes an API key may be limited to
+const (
+	ScopeReadFarms     = "read:farms"
+	ScopeWritePonds    = "write:ponds"
+	ScopeIngestSensors = "ingest:sensors"
+)
+
+type JWTConfig struct {
+	Secret     string        // HMAC key used to sign access token
+	AccessTTL  time.Duration // lifetime of access token, default to 15 minutes
+	RefreshTTL time.Duration // lifetime of refresh token, default to 7 days
+}
+
+// Identity represent the authenticated caller of a request
+type Identity struct {
+	UserID     int64
+	Username   string
+	SessionID  int64
+	Admin      bool // admin isn't bound by farm roles, yet still bound by its organization
+	Superadmin bool // operates the platform, thus manages every organization. Never granted through API key
+
+	OrganizationID int64 // tenant the caller belongs to
+
+	// only set when authenticated by API key, which acts on behalf of its owner within its scopes
+	APIKeyID int64
+	Scopes   []string
+}
+
+type identityKey struct{}
+
+// WithIdentity return a copy of ctx carrying the identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext return identity carried by ctx, nil for unauthenticated caller
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
+
+// OrganizationFromContext return tenant of the caller carried by ctx, zero for internal caller (ex: scheduled job)
+// which isn't bound to any tenant
+func OrganizationFromContext(ctx context.Context) int64 {
+	if identity := FromContext(ctx); identity != nil {
+		return identity.OrganizationID
+	}
+
+	return 0
+}
@@ -15,6 +15,9 @@ var (
 	ErrUnknown                  = errors.New("internal server error")
 	ErrNotFound                 = errors.New("entity not found")
 	ErrMissingRequiredAttribute = errors.New("attribute is missing")
+	ErrInvalidStateTransition   = errors.New("invalid state transition")
+	ErrInvalidCred              = errors.New("invalid credential")
+	ErrNoAccess                 = errors.New("no access")
 )
 
 // Errcode: AAA-BB-C
@@ -30,6 +33,7 @@ const (
 	ErrCodeNotFound                 int = 404015
 	ErrCodeDuplicatedResources      int = 409016
 	ErrCodeBrokenUserReq            int = 422017
+	ErrCodeInvalidStateTransition   int = 409021
 	ErrCodeUndefined                int = 500011
 )
 
@@ -52,6 +56,9 @@ var errorMap = map[error]httpres.ErrorResponse{
 	ErrBrokenUserReq:            errorResponse(ErrStatusReqBody, ErrCodeBrokenUserReq, ErrBrokenUserReq),
 	ErrNotFound:                 errorResponse(ErrStatusNotFound, ErrCodeNotFound, ErrNotFound),
 	ErrMissingRequiredAttribute: errorResponse(ErrStatusClient, ErrCodeMissingRequiredAttribute, ErrMissingRequiredAttribute),
+	ErrInvalidStateTransition:   errorResponse(ErrStatusConflict, ErrCodeInvalidStateTransition, ErrInvalidStateTransition),
+	ErrInvalidCred:              errorResponse(ErrStatusNotLoggedIn, ErrCodeInvalidCred, ErrInvalidCred),
+	ErrNoAccess:                 errorResponse(ErrStatusNoAccess, ErrCodeNoAccess, ErrNoAccess),
 }
 
 func errorResponse(status int, code int, err error) httpres.ErrorResponse {
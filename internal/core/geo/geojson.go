@@ -0,0 +1,124 @@
+package geo
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+const (
+	TypePolygon           = "Polygon"
+	TypeFeature           = "Feature"
+	TypeFeatureCollection = "FeatureCollection"
+)
+
+// EarthRadius is the mean radius of the earth in meter
+const EarthRadius = 6371008.8
+
+// Polygon represent GeoJSON Polygon geometry (RFC 7946), position is ordered as [longitude, latitude]
+type Polygon struct {
+	Type        string        `json:"type" example:"Polygon"`
+	Coordinates [][][]float64 `json:"coordinates" swaggertype:"array,number"`
+}
+
+// Validate make sure polygon has closed linear rings with valid WGS84 position
+func (p *Polygon) Validate() error {
+	if p.Type != TypePolygon || len(p.Coordinates) == 0 {
+		return errs.ErrBadRequest
+	}
+
+	for _, ring := range p.Coordinates {
+		// linear ring needs at least 4 positions with the last one closing the ring
+		if len(ring) < 4 {
+			return errs.ErrBadRequest
+		}
+
+		for _, pos := range ring {
+			if len(pos) < 2 || pos[0] < -180 || pos[0] > 180 || pos[1] < -90 || pos[1] > 90 {
+				return errs.ErrBadRequest
+			}
+		}
+
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			return errs.ErrBadRequest
+		}
+	}
+
+	return nil
+}
+
+// Area return surface area of the polygon on a spherical earth in m2, holes are subtracted from the exterior ring
+func (p *Polygon) Area() float64 {
+	if len(p.Coordinates) == 0 {
+		return 0
+	}
+
+	area := math.Abs(ringArea(p.Coordinates[0]))
+	for _, hole := range p.Coordinates[1:] {
+		area -= math.Abs(ringArea(hole))
+	}
+
+	return math.Max(area, 0)
+}
+
+// ringArea calculate signed area of a ring as described in
+// "Some Algorithms for Polygons on a Sphere" (Chamberlain & Duquette, 2007)
+func ringArea(ring [][]float64) float64 {
+	var total float64
+
+	for i := 0; i < len(ring)-1; i++ {
+		lon1, lat1 := radians(ring[i][0]), radians(ring[i][1])
+		lon2, lat2 := radians(ring[i+1][0]), radians(ring[i+1][1])
+
+		total += (lon2 - lon1) * (2 + math.Sin(lat1) + math.Sin(lat2))
+	}
+
+	return total * EarthRadius * EarthRadius / 2
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// Value implements driver.Valuer
+func (p Polygon) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan implements sql.Scanner
+func (p *Polygon) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	}
+
+	return errors.New("unsupported type for Polygon")
+}
+
+// Feature represent GeoJSON Feature object
+type Feature struct {
+	Type       string   `json:"type" example:"Feature"`
+	ID         int64    `json:"id" example:"1"`
+	Geometry   *Polygon `json:"geometry"` // null for entity without recorded boundary
+	Properties any      `json:"properties"`
+}
+
+// FeatureCollection represent GeoJSON FeatureCollection object
+type FeatureCollection struct {
+	Type     string     `json:"type" example:"FeatureCollection"`
+	Features []*Feature `json:"features"`
+}
+
+// NewFeatureCollection return an empty FeatureCollection
+func NewFeatureCollection() *FeatureCollection {
+	return &FeatureCollection{
+		Type:     TypeFeatureCollection,
+		Features: []*Feature{},
+	}
+}
@@ -0,0 +1,37 @@
+package geo
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+// Point represent a WGS84 coordinate
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// ParsePoint parse coordinate formatted as "lat,lon"
+func ParsePoint(raw string) (res *Point, err error) {
+	rawLat, rawLon, ok := strings.Cut(raw, ",")
+	if !ok {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &Point{}
+	if res.Latitude, err = strconv.ParseFloat(strings.TrimSpace(rawLat), 64); err != nil {
+		return nil, errs.ErrBadRequest
+	}
+
+	if res.Longitude, err = strconv.ParseFloat(strings.TrimSpace(rawLon), 64); err != nil {
+		return nil, errs.ErrBadRequest
+	}
+
+	if res.Latitude < -90 || res.Latitude > 90 || res.Longitude < -180 || res.Longitude > 180 {
+		return nil, errs.ErrBadRequest
+	}
+
+	return
+}
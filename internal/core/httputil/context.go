@@ -0,0 +1,16 @@
+package httputil
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID return a copy of ctx carrying the request-id
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext return request-id carried by ctx, empty outside of a request (ex: scheduled job)
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -1,6 +1,8 @@
 package httputil
 
 import (
+	"encoding/json"
+
 	"github.com/labstack/echo/v4"
 	"github.com/nmluci/da-farm-be/internal/core/errs"
 	"github.com/nmluci/da-farm-be/internal/core/httpres"
@@ -14,6 +16,16 @@ func WriteSuccessResponse(ec echo.Context, status int, data any) error {
 	})
 }
 
+// WriteGeoJSONResponse serialized GeoJSON object as is, since GIS client expect unwrapped body
+func WriteGeoJSONResponse(ec echo.Context, status int, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return WriteErrorResponse(ec, err)
+	}
+
+	return ec.Blob(status, "application/geo+json", body)
+}
+
 // WriteErrorResponse serialized Go's error into standardized error code
 func WriteErrorResponse(ec echo.Context, err error) error {
 	res := errs.GetErrorResp(err)
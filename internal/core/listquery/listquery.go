@@ -0,0 +1,254 @@
+// Package listquery translate sort, filter and field selection parameters of list endpoints into squirrel clauses,
+// ex: ?sort=-created_at,name&filter[status]=stocked&filter[created_at][gte]=2024-08-01&fields=id,name
+package listquery
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+// Field describe what a field of a listed resource may be used for, nothing is allowed unless flagged
+type Field struct {
+	Column string   // column the field is sorted and filtered by
+	Select []string // columns fetched when the field is picked by fields, none means it can't be picked
+	Sort   bool     // may be ordered by sort
+	Filter bool     // may be matched by equality, comma separated values are matched by any
+	Range  bool     // may be matched by time range with gt, gte, lt or lte
+	Key    string   // key of the field within response, default to its name
+}
+
+// Spec whitelist fields of a listed resource, keyed by their name in the query string.
+// Spec is expected to have a selectable id, which is always fetched on field selection
+type Spec map[string]Field
+
+// rangeOps map range operator into its condition
+var rangeOps = map[string]func(column string, value time.Time) squirrel.Sqlizer{
+	"gt":  func(column string, value time.Time) squirrel.Sqlizer { return squirrel.Gt{column: value} },
+	"gte": func(column string, value time.Time) squirrel.Sqlizer { return squirrel.GtOrEq{column: value} },
+	"lt":  func(column string, value time.Time) squirrel.Sqlizer { return squirrel.Lt{column: value} },
+	"lte": func(column string, value time.Time) squirrel.Sqlizer { return squirrel.LtOrEq{column: value} },
+}
+
+// Params represent validated sort, filter and field selection of a list request.
+// Nil Params is usable and requests nothing
+type Params struct {
+	sort    []string
+	filter  squirrel.And
+	columns []string
+	omit    []string
+}
+
+// Parse read sort, filter[...] and fields out of query string, any field not whitelisted by spec
+// for the intended use is rejected with ErrBadRequest
+func Parse(values url.Values, spec Spec) (res *Params, err error) {
+	res = &Params{}
+
+	for _, name := range split(values.Get("sort")) {
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		field, ok := spec[name]
+		if !ok || !field.Sort {
+			return nil, errs.ErrBadRequest
+		}
+
+		order := field.Column + " ASC"
+		if desc {
+			order = field.Column + " DESC"
+		}
+
+		res.sort = append(res.sort, order)
+	}
+
+	// id is always fetched, thus every selected row remains identifiable
+	fields := split(values.Get("fields"))
+	if len(fields) > 0 {
+		fields = append([]string{"id"}, fields...)
+	}
+
+	for _, name := range fields {
+		field, ok := spec[name]
+		if !ok || len(field.Select) == 0 {
+			return nil, errs.ErrBadRequest
+		}
+
+		for _, column := range field.Select {
+			if !slices.Contains(res.columns, column) {
+				res.columns = append(res.columns, column)
+			}
+		}
+	}
+
+	// field whose columns aren't entirely fetched has nothing to show, thus its key is left out of response
+	for name, field := range spec {
+		if len(res.columns) == 0 || len(field.Select) == 0 {
+			continue
+		}
+
+		for _, column := range field.Select {
+			if !slices.Contains(res.columns, column) {
+				res.omit = append(res.omit, field.key(name))
+				break
+			}
+		}
+	}
+
+	// iterate in order, so the same query string always yields the same statement
+	params := []string{}
+	for param := range values {
+		if strings.HasPrefix(param, "filter[") {
+			params = append(params, param)
+		}
+	}
+	sort.Strings(params)
+
+	for _, param := range params {
+		cond, err := parseFilter(param, values[param], spec)
+		if err != nil {
+			return nil, err
+		}
+
+		res.filter = append(res.filter, cond)
+	}
+
+	return
+}
+
+// key return name of the field within response
+func (f Field) key(name string) string {
+	if f.Key != "" {
+		return f.Key
+	}
+
+	return name
+}
+
+// parseFilter translate filter[name]=a,b into equality and filter[name][op]=time into range condition
+func parseFilter(param string, values []string, spec Spec) (squirrel.Sqlizer, error) {
+	name, op, ok := strings.Cut(strings.TrimPrefix(param, "filter["), "]")
+	if !ok {
+		return nil, errs.ErrBadRequest
+	}
+
+	field, ok := spec[name]
+	if !ok {
+		return nil, errs.ErrBadRequest
+	}
+
+	if op == "" {
+		if !field.Filter {
+			return nil, errs.ErrBadRequest
+		}
+
+		matches := []string{}
+		for _, value := range values {
+			matches = append(matches, split(value)...)
+		}
+
+		switch len(matches) {
+		case 0:
+			return nil, errs.ErrBadRequest
+		case 1:
+			return squirrel.Eq{field.Column: matches[0]}, nil
+		default:
+			return squirrel.Eq{field.Column: matches}, nil
+		}
+	}
+
+	op, opened := strings.CutPrefix(op, "[")
+	op, closed := strings.CutSuffix(op, "]")
+	if !opened || !closed {
+		return nil, errs.ErrBadRequest
+	}
+
+	cond, ok := rangeOps[op]
+	if !ok || !field.Range {
+		return nil, errs.ErrBadRequest
+	}
+
+	value, err := parseTime(values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return cond(field.Column, value), nil
+}
+
+// parseTime accept RFC3339 timestamp or date formatted as YYYY-MM-DD, meaning its midnight in UTC
+func parseTime(value string) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+
+	ts, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, errs.ErrBadRequest
+	}
+
+	return ts, nil
+}
+
+// split comma separated list, ignoring blank item
+func split(list string) (res []string) {
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+
+	return
+}
+
+// Where return conditions of every filter, to be combined with the repository's own
+func (p *Params) Where() squirrel.And {
+	if p == nil {
+		return nil
+	}
+
+	return p.filter
+}
+
+// Sort return ordering clauses in requested precedence, empty means the repository's default ordering applies
+func (p *Params) Sort() []string {
+	if p == nil {
+		return nil
+	}
+
+	return p.sort
+}
+
+// Columns return columns of the selected fields to be fetched, empty means every column of the repository's own
+func (p *Params) Columns() []string {
+	if p == nil {
+		return nil
+	}
+
+	return p.columns
+}
+
+// Project marshal v into JSON object without keys of the unselected fields,
+// v is marshalled as is when nothing is selected
+func (p *Params) Project(v any) ([]byte, error) {
+	body, err := json.Marshal(v)
+	if p == nil || len(p.omit) == 0 || err != nil {
+		return body, err
+	}
+
+	object := map[string]json.RawMessage{}
+	if err = json.Unmarshal(body, &object); err != nil {
+		return nil, err
+	}
+
+	for _, key := range p.omit {
+		delete(object, key)
+	}
+
+	return json.Marshal(object)
+}
@@ -0,0 +1,116 @@
+package listquery
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+var spec = Spec{
+	"id":         {Column: "p.id", Select: []string{"p.id"}, Sort: true},
+	"farm_name":  {Select: []string{"f.name farm_name"}},
+	"name":       {Column: "p.name", Select: []string{"p.name"}, Sort: true, Filter: true},
+	"status":     {Column: "p.status", Select: []string{"p.status"}, Filter: true},
+	"latitude":   {Select: []string{"p.latitude", "p.longitude"}},
+	"longitude":  {Select: []string{"p.latitude", "p.longitude"}},
+	"created_at": {Column: "p.created_at", Sort: true, Range: true},
+}
+
+func TestShouldParseListQuery(t *testing.T) {
+	values, _ := url.ParseQuery("sort=-created_at,name&filter[status]=idle,stocked&filter[name]=Pond A&filter[created_at][gte]=2024-08-01&filter[created_at][lt]=2024-08-02T07:00:00Z&fields=id,name")
+
+	params, err := Parse(values, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := []string{"p.created_at DESC", "p.name ASC"}; !reflect.DeepEqual(params.Sort(), expected) {
+		t.Errorf("unexpected sort: %v", params.Sort())
+	}
+
+	stmt, args, _ := squirrel.Select("*").From("ponds p").Where(params.Where()).ToSql()
+	if expected := "SELECT * FROM ponds p WHERE (p.created_at >= ? AND p.created_at < ? AND p.name = ? AND p.status IN (?,?))"; stmt != expected {
+		t.Errorf("unexpected statement: %s", stmt)
+	}
+
+	expectedArgs := []any{
+		time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 8, 2, 7, 0, 0, 0, time.UTC),
+		"Pond A", "idle", "stocked",
+	}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
+
+func TestShouldNOTParseListQueryOutsideWhitelist(t *testing.T) {
+	cases := []string{
+		"sort=status",
+		"sort=-password",
+		"fields=id,created_at",
+		"filter[password]=secret",
+		"filter[id]=1",
+		"filter[status]=",
+		"filter[status][gte]=2024-08-01",
+		"filter[created_at]=2024-08-01",
+		"filter[created_at][between]=2024-08-01",
+		"filter[created_at][gte]=yesterday",
+		"filter[created_at][gte",
+		"filter[status",
+	}
+
+	for _, query := range cases {
+		values, _ := url.ParseQuery(query)
+
+		if _, err := Parse(values, spec); !errors.Is(err, errs.ErrBadRequest) {
+			t.Errorf("expected bad request on %q, got: %v", query, err)
+		}
+	}
+}
+
+func TestShouldSelectColumnsOfFieldsAlongWithID(t *testing.T) {
+	values, _ := url.ParseQuery("fields=name,latitude,farm_name,longitude")
+
+	params, err := Parse(values, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := []string{"p.id", "p.name", "p.latitude", "p.longitude", "f.name farm_name"}; !reflect.DeepEqual(params.Columns(), expected) {
+		t.Errorf("unexpected columns: %v", params.Columns())
+	}
+}
+
+func TestShouldProjectOnlyKeysOfSelectedFields(t *testing.T) {
+	values, _ := url.ParseQuery("fields=latitude")
+	object := map[string]any{"id": 1, "farm_name": "Farm A", "name": "Pond A", "latitude": -8.65, "longitude": 115.22, "growth": nil}
+
+	params, err := Parse(values, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// longitude is fetched along with latitude, key not described by spec is kept as is
+	body, err := params.Project(object)
+	if expected := `{"growth":null,"id":1,"latitude":-8.65,"longitude":115.22}`; err != nil || string(body) != expected {
+		t.Errorf("unexpected projection: %s, err: %v", body, err)
+	}
+
+	var nilParams *Params
+	if body, _ := nilParams.Project(object); string(body) != `{"farm_name":"Farm A","growth":null,"id":1,"latitude":-8.65,"longitude":115.22,"name":"Pond A"}` {
+		t.Errorf("expected nil params to keep every key, got: %s", body)
+	}
+}
+
+func TestShouldNOTRequestAnythingOnNilParams(t *testing.T) {
+	var params *Params
+
+	if params.Where() != nil || params.Sort() != nil || params.Columns() != nil {
+		t.Error("expected nil params to request nothing")
+	}
+}
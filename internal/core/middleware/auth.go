@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+// Authenticator resolve the identity owning a credential
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*auth.Identity, error)
+}
+
+// Authenticate reject request without a valid bearer token or API key, routes listed in publicPaths are let through
+func Authenticate(bearer Authenticator, apiKey Authenticator, publicPaths ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if slices.Contains(publicPaths, c.Path()) {
+				return next(c)
+			}
+
+			var authenticator Authenticator
+
+			scheme, credential, _ := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+			switch {
+			case strings.EqualFold(scheme, "Bearer"):
+				authenticator = bearer
+			case strings.EqualFold(scheme, "ApiKey"):
+				authenticator = apiKey
+			}
+
+			if authenticator == nil || credential == "" {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer, ApiKey")
+				return httputil.WriteErrorResponse(c, errs.ErrInvalidCred)
+			}
+
+			ctx := c.Request().Context()
+
+			identity, err := authenticator.Authenticate(ctx, credential)
+			if err != nil {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer, ApiKey")
+				return httputil.WriteErrorResponse(c, err)
+			}
+
+			zerolog.Ctx(ctx).UpdateContext(func(cl zerolog.Context) zerolog.Context {
+				cl = cl.Int64("user-id", identity.UserID)
+				if identity.APIKeyID != 0 {
+					cl = cl.Int64("api-key-id", identity.APIKeyID)
+				}
+
+				return cl
+			})
+
+			c.SetRequest(c.Request().WithContext(auth.WithIdentity(ctx, identity)))
+			return next(c)
+		}
+	}
+}
+
+// ScopeRule grant API key holding Scope access to routes under Prefix, limited to Methods when any
+type ScopeRule struct {
+	Scope   string
+	Prefix  string
+	Methods []string
+}
+
+func (rule *ScopeRule) match(method, path string) bool {
+	if path != rule.Prefix && !strings.HasPrefix(path, rule.Prefix+"/") && !strings.HasPrefix(path, rule.Prefix+".") {
+		return false
+	}
+
+	return len(rule.Methods) == 0 || slices.Contains(rule.Methods, method)
+}
+
+// ScopeGuard reject request authenticated by API key unless one of its scopes grants the route, user session
+// and unauthenticated request are let through
+func ScopeGuard(rules ...ScopeRule) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			identity := auth.FromContext(c.Request().Context())
+			if identity == nil || identity.APIKeyID == 0 {
+				return next(c)
+			}
+
+			for _, rule := range rules {
+				if slices.Contains(identity.Scopes, rule.Scope) && rule.match(c.Request().Method, c.Path()) {
+					return next(c)
+				}
+			}
+
+			return httputil.WriteErrorResponse(c, errs.ErrNoAccess)
+		}
+	}
+}
+
+// Authorizer decide whether the caller may perform an action on a farm
+type Authorizer interface {
+	Authorize(ctx context.Context, farmID int64, action string) error
+}
+
+// AuthorizeFarm reject request on the farm addressed by :farmID whose caller may not read it, or may not write
+// into it for non-safe methods. Route without :farmID is let through
+func AuthorizeFarm(authorizer Authorizer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			action := auth.ActionWrite
+
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				action = auth.ActionRead
+			}
+
+			if err := authorize(c, authorizer, action); err != nil {
+				return httputil.WriteErrorResponse(c, err)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireAction reject request whose caller may not perform the action on the farm addressed by :farmID
+func RequireAction(authorizer Authorizer, action string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := authorize(c, authorizer, action); err != nil {
+				return httputil.WriteErrorResponse(c, err)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func authorize(c echo.Context, authorizer Authorizer, action string) error {
+	param := c.Param("farmID")
+	if param == "" {
+		return nil
+	}
+
+	farmID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return errs.ErrBadRequest
+	}
+
+	return authorizer.Authorize(c.Request().Context(), farmID, action)
+}
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+type stubAuthenticator struct{}
+
+func (stubAuthenticator) Authenticate(_ context.Context, token string) (*auth.Identity, error) {
+	if token != "valid" {
+		return nil, errs.ErrInvalidCred
+	}
+
+	return &auth.Identity{UserID: 1}, nil
+}
+
+func TestShouldAuthenticateBearerToken(t *testing.T) {
+	ec := echo.New()
+	grp := ec.Group("/api/v1", Authenticate(stubAuthenticator{}, stubAuthenticator{}, "/api/v1/misc/ping"))
+
+	handler := func(c echo.Context) error {
+		if identity := auth.FromContext(c.Request().Context()); identity == nil {
+			return c.NoContent(http.StatusTeapot)
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+	grp.GET("/misc/ping", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	grp.GET("/farms", handler)
+
+	cases := []struct {
+		path, header string
+		status       int
+	}{
+		{"/api/v1/misc/ping", "", http.StatusOK},
+		{"/api/v1/farms", "", http.StatusUnauthorized},
+		{"/api/v1/farms", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"/api/v1/farms", "Bearer invalid", http.StatusUnauthorized},
+		{"/api/v1/farms", "Bearer valid", http.StatusOK},
+		{"/api/v1/farms", "ApiKey valid", http.StatusOK},
+		{"/api/v1/farms", "ApiKey", http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.header != "" {
+			req.Header.Set(echo.HeaderAuthorization, tc.header)
+		}
+
+		rec := httptest.NewRecorder()
+		ec.ServeHTTP(rec, req)
+
+		if rec.Code != tc.status {
+			t.Errorf("expected %d for %s %q, got: %d", tc.status, tc.path, tc.header, rec.Code)
+		}
+	}
+}
+
+type stubAuthorizer struct{}
+
+// Authorize permit reading farm 1 and writing into it, anything else is denied
+func (stubAuthorizer) Authorize(_ context.Context, farmID int64, action string) error {
+	if farmID != 1 || (action != auth.ActionRead && action != auth.ActionWrite) {
+		return errs.ErrNoAccess
+	}
+
+	return nil
+}
+
+func TestShouldAuthorizeFarmAction(t *testing.T) {
+	ec := echo.New()
+	grp := ec.Group("/api/v1", AuthorizeFarm(stubAuthorizer{}))
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	grp.GET("/farms", ok)
+	grp.GET("/farms/:farmID", ok)
+	grp.POST("/farms/:farmID/ponds", ok)
+	grp.DELETE("/farms/:farmID", ok, RequireAction(stubAuthorizer{}, auth.ActionDeleteFarm))
+
+	cases := []struct {
+		method, path string
+		status       int
+	}{
+		{http.MethodGet, "/api/v1/farms", http.StatusOK},
+		{http.MethodGet, "/api/v1/farms/1", http.StatusOK},
+		{http.MethodGet, "/api/v1/farms/2", http.StatusForbidden},
+		{http.MethodGet, "/api/v1/farms/abc", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/farms/1/ponds", http.StatusOK},
+		{http.MethodDelete, "/api/v1/farms/1", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		ec.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+
+		if rec.Code != tc.status {
+			t.Errorf("expected %d for %s %s, got: %d", tc.status, tc.method, tc.path, rec.Code)
+		}
+	}
+}
+
+func TestShouldGuardAPIKeyScope(t *testing.T) {
+	ec := echo.New()
+	grp := ec.Group("/api/v1", func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			identity := &auth.Identity{UserID: 1}
+			if c.Request().Header.Get("X-Test-Key") != "" {
+				identity.APIKeyID, identity.Scopes = 1, []string{auth.ScopeReadFarms}
+			}
+
+			c.SetRequest(c.Request().WithContext(auth.WithIdentity(c.Request().Context(), identity)))
+			return next(c)
+		}
+	}, ScopeGuard(
+		ScopeRule{Scope: auth.ScopeReadFarms, Prefix: "/api/v1/farms", Methods: []string{http.MethodGet}},
+		ScopeRule{Scope: auth.ScopeWritePonds, Prefix: "/api/v1/farms/:farmID/ponds"},
+	))
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	grp.GET("/farms/:farmID", ok)
+	grp.GET("/farms/:farmID/ponds.geojson", ok)
+	grp.POST("/farms/:farmID/ponds", ok)
+	grp.GET("/users/me", ok)
+
+	cases := []struct {
+		method, path string
+		key          bool
+		status       int
+	}{
+		{http.MethodGet, "/api/v1/farms/1", true, http.StatusOK},
+		{http.MethodGet, "/api/v1/farms/1/ponds.geojson", true, http.StatusOK},
+		{http.MethodPost, "/api/v1/farms/1/ponds", true, http.StatusForbidden},
+		{http.MethodPost, "/api/v1/farms/1/ponds", false, http.StatusOK},
+		{http.MethodGet, "/api/v1/users/me", true, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.key {
+			req.Header.Set("X-Test-Key", "1")
+		}
+
+		rec := httptest.NewRecorder()
+		ec.ServeHTTP(rec, req)
+
+		if rec.Code != tc.status {
+			t.Errorf("expected %d for %s %s (api key: %v), got: %d", tc.status, tc.method, tc.path, tc.key, rec.Code)
+		}
+	}
+}
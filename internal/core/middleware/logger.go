@@ -9,20 +9,24 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
 	"github.com/nmluci/da-farm-be/internal/domain/telemetry"
 	"github.com/rs/zerolog"
 )
 
-// HandlerLogger tag every request with request-id for traceability
+// HandlerLogger tag every request with request-id for traceability, the request-id is carried by request context as well
 func HandlerLogger(logger *zerolog.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
 			l := logger.With().Logger()
 			l.UpdateContext(func(cl zerolog.Context) zerolog.Context {
-				return cl.Str("request-id", c.Response().Header().Get(echo.HeaderXRequestID))
+				return cl.Str("request-id", requestID)
 			})
 
-			c.SetRequest(c.Request().WithContext(l.WithContext(c.Request().Context())))
+			ctx := httputil.WithRequestID(l.WithContext(c.Request().Context()), requestID)
+			c.SetRequest(c.Request().WithContext(ctx))
 			return next(c)
 		}
 
@@ -0,0 +1,53 @@
+// Package search build keyword search conditions on top of postgres full-text search and pg_trgm
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Keyword represent a free-form search input, matched against a tsvector column by prefix
+// and against a text column by trigram similarity to tolerate typos
+type Keyword struct {
+	raw   string
+	query string
+}
+
+// Parse turn keyword into prefix tsquery, ex: "Farm Ba" into "farm:* & ba:*".
+// Keyword without any letter or digit yields nil, meaning there's nothing to search
+func Parse(keyword string) *Keyword {
+	terms := strings.FieldsFunc(strings.ToLower(keyword), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(terms) == 0 {
+		return nil
+	}
+
+	for i := range terms {
+		terms[i] += ":*"
+	}
+
+	return &Keyword{
+		raw:   strings.TrimSpace(keyword),
+		query: strings.Join(terms, " & "),
+	}
+}
+
+// Query return the tsquery built from keyword
+func (k *Keyword) Query() string {
+	return k.query
+}
+
+// Match return condition matching rows whose vector has every term as prefix, or whose text is similar to the keyword
+func (k *Keyword) Match(vector, text string) squirrel.Sqlizer {
+	return squirrel.Expr(fmt.Sprintf("(%s @@ to_tsquery('simple', ?) OR %s %% ?)", vector, text), k.query, k.raw)
+}
+
+// Rank return ordering clause putting the most relevant row first, to be used with OrderByClause
+func (k *Keyword) Rank(vector, text string) squirrel.Sqlizer {
+	return squirrel.Expr(fmt.Sprintf("ts_rank(%s, to_tsquery('simple', ?)) + similarity(%s, ?) DESC", vector, text), k.query, k.raw)
+}
@@ -0,0 +1,26 @@
+package search
+
+import "testing"
+
+func TestShouldParseKeywordIntoPrefixQuery(t *testing.T) {
+	cases := map[string]string{
+		"Farm":           "farm:*",
+		"  farm  BA ":    "farm:* & ba:*",
+		"tambak-udang's": "tambak:* & udang:* & s:*",
+		"kolam 2":        "kolam:* & 2:*",
+	}
+
+	for keyword, expected := range cases {
+		if got := Parse(keyword); got == nil || got.Query() != expected {
+			t.Errorf("unexpected query of %q: %+v", keyword, got)
+		}
+	}
+}
+
+func TestShouldNOTParseKeywordWithoutTerm(t *testing.T) {
+	for _, keyword := range []string{"", "   ", "&|!:*"} {
+		if got := Parse(keyword); got != nil {
+			t.Errorf("expected nothing to search for %q, got: %+v", keyword, got)
+		}
+	}
+}
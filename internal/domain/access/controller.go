@@ -0,0 +1,36 @@
+package access
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/middleware"
+)
+
+type AccessController struct {
+	svc AccessService
+}
+
+func NewController(svc AccessService) *AccessController {
+	return &AccessController{
+		svc: svc,
+	}
+}
+
+const (
+	memberBasepath = "/farms/:farmID/members"
+	userIDPath     = "/:userID"
+)
+
+func (ac *AccessController) Route(grp *echo.Group) {
+	subrouter := grp.Group(memberBasepath)
+	manage := middleware.RequireAction(ac.svc, auth.ActionManageMember)
+
+	subrouter.GET("", HandleGetAllMember(ac.svc.GetAll))
+	subrouter.OPTIONS("", HandleGetAllMember(ac.svc.GetAll))
+	subrouter.PUT(userIDPath, HandlePutMember(ac.svc.Put), manage)
+	subrouter.OPTIONS(userIDPath, HandlePutMember(ac.svc.Put))
+	subrouter.DELETE(userIDPath, HandleDeleteMember(ac.svc.Delete), manage)
+	subrouter.OPTIONS(userIDPath, HandleDeleteMember(ac.svc.Delete))
+
+	return
+}
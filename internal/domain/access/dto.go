@@ -0,0 +1,39 @@
+package access
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// MemberRequestQuery represent query parameters fetch from request
+type MemberRequestQuery struct {
+	FarmID int64  `param:"farmID" example:"1"`
+	UserID int64  `param:"userID" example:"2"`
+	Role   string `query:"role" example:"technician"`
+	Limit  uint64 `query:"limit" example:"100"`
+	Page   uint64 `query:"page" example:"2"`
+}
+
+// MemberPayload represent payload fetch from request body
+type MemberPayload struct {
+	FarmID int64  `param:"farmID" json:"-" example:"1"`
+	UserID int64  `param:"userID" json:"-" example:"2"`
+	Role   string `json:"role" example:"technician" enums:"owner,manager,technician,viewer,auditor"`
+}
+
+// MemberResponse represent domain response for farm Member entity
+type MemberResponse struct {
+	FarmID    int64     `json:"farm_id" example:"1"`
+	UserID    int64     `json:"user_id" example:"2"`
+	Username  string    `json:"username" example:"budi"`
+	Name      string    `json:"name" example:"Budi Santoso"`
+	Role      string    `json:"role" example:"technician"`
+	CreatedAt time.Time `json:"created_at" example:"2024-08-16T01:35:20Z"`
+}
+
+// ListMemberResponse represent domain response for bulk farm Member entities
+type ListMemberResponse struct {
+	Members []*MemberResponse      `json:"members"`
+	Meta    httpres.ListPagination `json:"meta"`
+}
@@ -0,0 +1,122 @@
+package access
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllMemberHandler func(context.Context, *MemberRequestQuery) (*ListMemberResponse, error)
+
+// Get All Member godoc
+//
+//	@Summary	get users having a role on the farm
+//	@Tags		Access
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		role	query		string	false	"only return member with the role"
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListMemberResponse
+//	@Failure	403		{object}	httpres.ErrorResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/members [get]
+func HandleGetAllMember(handler GetAllMemberHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &MemberRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type PutMemberHandler func(context.Context, *MemberPayload) error
+
+// Put Member godoc
+//
+//	@Summary	grant a role on the farm to a user, replacing the previous one. Only owner may manage members
+//	@Tags		Access
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int				true	"Farm ID"
+//	@Param		userID	path		int				true	"User ID"
+//	@Param		payload	body		MemberPayload	true	"member payload"
+//	@Success	200		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"unknown role, or demoting the last owner"
+//	@Failure	403		{object}	httpres.ErrorResponse
+//	@Failure	404		{object}	httpres.ErrorResponse	"farm or user not existed"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/members/{userID} [put]
+func HandlePutMember(handler PutMemberHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &MemberPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteMemberHandler func(context.Context, *MemberRequestQuery) error
+
+// DeleteMember godoc
+//
+//	@Summary	revoke role of a user on the farm. Only owner may manage members
+//	@Tags		Access
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Param		userID	path		int	true	"User ID"
+//	@Success	200		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"removing the last owner"
+//	@Failure	403		{object}	httpres.ErrorResponse
+//	@Failure	404		{object}	httpres.ErrorResponse	"user isn't a member"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/members/{userID} [delete]
+func HandleDeleteMember(handler DeleteMemberHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &MemberRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,36 @@
+package access
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+)
+
+const (
+	RoleOwner      = "owner"
+	RoleManager    = "manager"
+	RoleTechnician = "technician"
+	RoleViewer     = "viewer"
+	RoleAuditor    = "auditor"
+)
+
+// permissions list actions each role may perform on its farm
+var permissions = map[string][]string{
+	RoleOwner: {
+		auth.ActionRead, auth.ActionWrite, auth.ActionDeletePond, auth.ActionWriteFarm,
+		auth.ActionDeleteFarm, auth.ActionManageMember, auth.ActionReadAudit,
+	},
+	RoleManager:    {auth.ActionRead, auth.ActionWrite, auth.ActionDeletePond, auth.ActionWriteFarm, auth.ActionReadAudit},
+	RoleTechnician: {auth.ActionRead, auth.ActionWrite},
+	RoleViewer:     {auth.ActionRead},
+	RoleAuditor:    {auth.ActionRead, auth.ActionReadAudit},
+}
+
+type MemberType struct {
+	FarmID    int64     `db:"farm_id"`
+	UserID    int64     `db:"user_id"`
+	Username  string    `db:"username"`
+	Name      string    `db:"name"`
+	Role      string    `db:"role"`
+	CreatedAt time.Time `db:"created_at"`
+}
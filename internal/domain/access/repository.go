@@ -0,0 +1,278 @@
+package access
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// MemberRepository contain contract that defined all necessary public function available to be interact with
+type MemberRepository interface {
+	GetAll(context.Context, *memberQuery) ([]*MemberType, error)
+	Count(context.Context, *memberQuery) (uint64, error)
+	GetOne(context.Context, *memberQuery) (*MemberType, error)
+	Upsert(context.Context, *MemberType) error
+	Delete(context.Context, *memberQuery) error
+	GetFarmOrganization(context.Context, int64) (int64, error)
+}
+
+type memberRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of memberRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) MemberRepository {
+	return &memberRepository{db: db}
+}
+
+type memberQuery struct {
+	FarmID, UserID int64
+	Role           string
+	Limit, Page    uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var memberColumns = []string{"m.farm_id", "m.user_id", "u.username", "u.name", "m.role", "m.created_at"}
+
+func (params *memberQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"m.farm_id": params.FarmID},
+		squirrel.Eq{"u.deleted_at": nil},
+	}
+
+	if params.UserID != 0 {
+		cond = append(cond, squirrel.Eq{"m.user_id": params.UserID})
+	}
+
+	if params.Role != "" {
+		cond = append(cond, squirrel.Eq{"m.role": params.Role})
+	}
+
+	return cond
+}
+
+func (repo *memberRepository) GetAll(ctx context.Context, params *memberQuery) (res []*MemberType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(memberColumns...).From("farm_members m").
+		Join("users u on m.user_id = u.id").
+		Where(params.filter()).
+		OrderBy("m.created_at", "m.user_id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*MemberType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &MemberType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *memberRepository) Count(ctx context.Context, params *memberQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("farm_members m").
+		Join("users u on m.user_id = u.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *memberRepository) GetOne(ctx context.Context, params *memberQuery) (res *MemberType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(memberColumns...).From("farm_members m").
+		Join("users u on m.user_id = u.id").
+		Where(params.filter()).ToSql()
+
+	res = &MemberType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure both the farm and the user exists within the same organization
+func (repo *memberRepository) validate(ctx context.Context, tx *sqlx.Tx, payload *MemberType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var count int64
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("farms f").
+		Join("users u on u.id = ?", payload.UserID).
+		Where(squirrel.And{
+			squirrel.Eq{"f.id": payload.FarmID},
+			squirrel.Eq{"f.deleted_at": nil},
+			squirrel.Eq{"u.deleted_at": nil},
+			squirrel.Expr("u.organization_id = f.organization_id"),
+		}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate farm and user data existence")
+		return
+	}
+
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+// guardOwner make sure the farm keeps another owner when an owner is demoted or removed
+func (repo *memberRepository) guardOwner(ctx context.Context, tx *sqlx.Tx, farmID, userID int64) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var self, others int64
+
+	stmt, args, _ := pgSquirrel.Select().
+		Column(squirrel.Expr("count(*) filter (where user_id = ?)", userID)).
+		Column(squirrel.Expr("count(*) filter (where user_id <> ?)", userID)).
+		From("farm_members").
+		Where(squirrel.And{
+			squirrel.Eq{"farm_id": farmID},
+			squirrel.Eq{"role": RoleOwner},
+		}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&self, &others); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate remaining owner")
+		return
+	}
+
+	// farm without owner can't be managed anymore
+	if self != 0 && others == 0 {
+		return errs.ErrBadRequest
+	}
+
+	return
+}
+
+// Upsert grant the role to the user, replacing any role the user has on the farm
+func (repo *memberRepository) Upsert(ctx context.Context, payload *MemberType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	if payload.Role != RoleOwner {
+		if err = repo.guardOwner(ctx, tx, payload.FarmID, payload.UserID); err != nil {
+			return
+		}
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("farm_members").
+		Columns("farm_id", "user_id", "role").
+		Values(payload.FarmID, payload.UserID, payload.Role).
+		Suffix("ON CONFLICT (farm_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = NOW()").ToSql()
+
+	if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *memberRepository) Delete(ctx context.Context, params *memberQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.guardOwner(ctx, tx, params.FarmID, params.UserID); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Delete("farm_members").Where(squirrel.And{
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"user_id": params.UserID},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// GetFarmOrganization return tenant owning an active farm, zero when the farm doesn't exist
+func (repo *memberRepository) GetFarmOrganization(ctx context.Context, farmID int64) (res int64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("organization_id").From("farms").Where(squirrel.And{
+		squirrel.Eq{"id": farmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
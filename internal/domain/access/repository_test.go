@@ -0,0 +1,70 @@
+package access
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldNOTDemoteLastOwner(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	memberRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms f JOIN users u on u.id = $1 WHERE (f.id = $2 AND f.deleted_at IS NULL AND u.deleted_at IS NULL AND u.organization_id = f.organization_id)")).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) filter (where user_id = $1), count(*) filter (where user_id <> $2) FROM farm_members WHERE (farm_id = $3 AND role = $4)")).
+		WithArgs(2, 2, 1, RoleOwner).
+		WillReturnRows(sqlmock.NewRows([]string{"self", "others"}).AddRow(1, 0))
+	mock.ExpectRollback()
+
+	err = memberRepo.Upsert(context.Background(), &MemberType{FarmID: 1, UserID: 2, Role: RoleManager})
+	if err != errs.ErrBadRequest {
+		t.Errorf("expected ErrBadRequest, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldGrantRoleToMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	memberRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms f JOIN users u on u.id = $1")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM farm_members WHERE (farm_id = $3 AND role = $4)")).
+		WillReturnRows(sqlmock.NewRows([]string{"self", "others"}).AddRow(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO farm_members (farm_id,user_id,role) VALUES ($1,$2,$3) ON CONFLICT (farm_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = NOW()")).
+		WithArgs(1, 2, RoleTechnician).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = memberRepo.Upsert(context.Background(), &MemberType{FarmID: 1, UserID: 2, Role: RoleTechnician})
+	if err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
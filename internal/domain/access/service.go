@@ -0,0 +1,163 @@
+package access
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// AccessService contains public API available to be interacted with
+type AccessService interface {
+	GetAll(context.Context, *MemberRequestQuery) (*ListMemberResponse, error)
+	Put(context.Context, *MemberPayload) error
+	Delete(context.Context, *MemberRequestQuery) error
+	Authorize(context.Context, int64, string) error
+}
+
+type accessService struct {
+	repo MemberRepository
+}
+
+// NewService return an instance of AccessService containing available usecases
+func NewService(repo MemberRepository) AccessService {
+	return &accessService{repo: repo}
+}
+
+func (svc *accessService) GetAll(ctx context.Context, params *MemberRequestQuery) (res *ListMemberResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &memberQuery{
+		FarmID: params.FarmID,
+		Role:   params.Role,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListMemberResponse{
+		Members: []*MemberResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	members, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, member := range members {
+		res.Members = append(res.Members, toMemberResponse(member))
+	}
+
+	return
+}
+
+// Put grant the role on the farm to the user, replacing the previous one
+func (svc *accessService) Put(ctx context.Context, payload *MemberPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if _, ok := permissions[payload.Role]; !ok {
+		return errs.ErrBadRequest
+	}
+
+	err = svc.repo.Upsert(ctx, &MemberType{
+		FarmID: payload.FarmID,
+		UserID: payload.UserID,
+		Role:   payload.Role,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// Delete revoke every role of the user on the farm
+func (svc *accessService) Delete(ctx context.Context, params *MemberRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	err = svc.repo.Delete(ctx, &memberQuery{FarmID: params.FarmID, UserID: params.UserID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// Authorize return ErrNoAccess unless the caller's role on the farm permits the action
+func (svc *accessService) Authorize(ctx context.Context, farmID int64, action string) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return errs.ErrInvalidCred
+	}
+
+	// admin bypass farm roles, yet never reach into farm of another organization
+	if identity.Admin {
+		organizationID, err := svc.repo.GetFarmOrganization(ctx, farmID)
+		if err != nil {
+			logger.Error().Err(err).Send()
+			return err
+		}
+
+		if organizationID != 0 && organizationID != identity.OrganizationID {
+			logger.Warn().Int64("farm-id", farmID).Str("action", action).Msg("access denied")
+			return errs.ErrNoAccess
+		}
+
+		return nil
+	}
+
+	member, err := svc.repo.GetOne(ctx, &memberQuery{FarmID: farmID, UserID: identity.UserID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if member == nil || !slices.Contains(permissions[member.Role], action) {
+		logger.Warn().Int64("farm-id", farmID).Str("action", action).Msg("access denied")
+		return errs.ErrNoAccess
+	}
+
+	return
+}
+
+func toMemberResponse(member *MemberType) *MemberResponse {
+	return &MemberResponse{
+		FarmID:    member.FarmID,
+		UserID:    member.UserID,
+		Username:  member.Username,
+		Name:      member.Name,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+}
@@ -0,0 +1,87 @@
+package access
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+type stubMemberRepository struct {
+	MemberRepository
+	members map[int64]*MemberType // keyed by farm ID
+	tenants map[int64]int64       // organization keyed by farm ID
+}
+
+func (repo *stubMemberRepository) GetOne(_ context.Context, params *memberQuery) (*MemberType, error) {
+	member, ok := repo.members[params.FarmID]
+	if !ok || member.UserID != params.UserID {
+		return nil, nil
+	}
+
+	return member, nil
+}
+
+func (repo *stubMemberRepository) GetFarmOrganization(_ context.Context, farmID int64) (int64, error) {
+	return repo.tenants[farmID], nil
+}
+
+func TestShouldAuthorizeByRoleOnTheFarm(t *testing.T) {
+	repo := &stubMemberRepository{members: map[int64]*MemberType{
+		1: {FarmID: 1, UserID: 2, Role: RoleTechnician},
+		2: {FarmID: 2, UserID: 2, Role: RoleViewer},
+	}}
+	svc := NewService(repo)
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 2})
+
+	cases := []struct {
+		farmID int64
+		action string
+		err    error
+	}{
+		{1, auth.ActionRead, nil},
+		{1, auth.ActionWrite, nil},
+		{1, auth.ActionWriteFarm, errs.ErrNoAccess},
+		{2, auth.ActionRead, nil},
+		{2, auth.ActionWrite, errs.ErrNoAccess},
+		{2, auth.ActionDeleteFarm, errs.ErrNoAccess},
+		{3, auth.ActionRead, errs.ErrNoAccess}, // not a member
+	}
+
+	for _, tc := range cases {
+		if err := svc.Authorize(ctx, tc.farmID, tc.action); err != tc.err {
+			t.Errorf("expected %v on farm %d for %s, got: %v", tc.err, tc.farmID, tc.action, err)
+		}
+	}
+}
+
+func TestShouldAuthorizeAdminOnEveryFarm(t *testing.T) {
+	svc := NewService(&stubMemberRepository{})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, Admin: true})
+
+	if err := svc.Authorize(ctx, 1, auth.ActionDeleteFarm); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
+
+func TestShouldNOTPutUnknownRole(t *testing.T) {
+	svc := NewService(&stubMemberRepository{})
+
+	if err := svc.Put(context.Background(), &MemberPayload{FarmID: 1, UserID: 2, Role: "contractor"}); err != errs.ErrBadRequest {
+		t.Errorf("expected ErrBadRequest, got: %v", err)
+	}
+}
+
+func TestShouldNOTAuthorizeAdminOnFarmOfAnotherOrganization(t *testing.T) {
+	svc := NewService(&stubMemberRepository{tenants: map[int64]int64{1: 1, 2: 2}})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, Admin: true, OrganizationID: 1})
+
+	if err := svc.Authorize(ctx, 1, auth.ActionDeleteFarm); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+
+	if err := svc.Authorize(ctx, 2, auth.ActionRead); err != errs.ErrNoAccess {
+		t.Errorf("expected ErrNoAccess, got: %v", err)
+	}
+}
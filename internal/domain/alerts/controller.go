@@ -0,0 +1,48 @@
+package alerts
+
+import "github.com/labstack/echo/v4"
+
+type AlertController struct {
+	svc AlertService
+}
+
+func NewController(svc AlertService) *AlertController {
+	return &AlertController{
+		svc: svc,
+	}
+}
+
+const (
+	ruleBasepath  = "/farms/:farmID/alert-rules"
+	ruleIDPath    = "/:ruleID"
+	alertBasepath = "/farms/:farmID/alerts"
+	alertIDPath   = "/:alertID"
+)
+
+func (ac *AlertController) Route(grp *echo.Group) {
+	ruleRouter := grp.Group(ruleBasepath)
+
+	ruleRouter.GET("", HandleGetAllRule(ac.svc.GetAllRule))
+	ruleRouter.OPTIONS("", HandleGetAllRule(ac.svc.GetAllRule))
+	ruleRouter.GET(ruleIDPath, HandleGetOneRule(ac.svc.GetOneRule))
+	ruleRouter.OPTIONS(ruleIDPath, HandleGetOneRule(ac.svc.GetOneRule))
+	ruleRouter.POST("", HandleCreateRule(ac.svc.CreateRule))
+	ruleRouter.OPTIONS("", HandleCreateRule(ac.svc.CreateRule))
+	ruleRouter.PUT(ruleIDPath, HandleUpdateRule(ac.svc.UpdateRule))
+	ruleRouter.OPTIONS(ruleIDPath, HandleUpdateRule(ac.svc.UpdateRule))
+	ruleRouter.DELETE(ruleIDPath, HandleDeleteRule(ac.svc.DeleteRule))
+	ruleRouter.OPTIONS(ruleIDPath, HandleDeleteRule(ac.svc.DeleteRule))
+
+	alertRouter := grp.Group(alertBasepath)
+
+	alertRouter.GET("", HandleGetAllAlert(ac.svc.GetAllAlert))
+	alertRouter.OPTIONS("", HandleGetAllAlert(ac.svc.GetAllAlert))
+	alertRouter.GET(alertIDPath, HandleGetOneAlert(ac.svc.GetOneAlert))
+	alertRouter.OPTIONS(alertIDPath, HandleGetOneAlert(ac.svc.GetOneAlert))
+	alertRouter.PUT(alertIDPath, HandleUpdateAlertStatus(ac.svc.UpdateAlertStatus))
+	alertRouter.OPTIONS(alertIDPath, HandleUpdateAlertStatus(ac.svc.UpdateAlertStatus))
+	alertRouter.DELETE(alertIDPath, HandleDeleteAlert(ac.svc.DeleteAlert))
+	alertRouter.OPTIONS(alertIDPath, HandleDeleteAlert(ac.svc.DeleteAlert))
+
+	return
+}
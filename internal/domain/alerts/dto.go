@@ -0,0 +1,84 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// RuleRequestQuery represent query parameters fetch from request
+type RuleRequestQuery struct {
+	ID     int64  `param:"ruleID" example:"1"`
+	FarmID int64  `param:"farmID" example:"1"`
+	PondID int64  `query:"pond_id" example:"1"`
+	Limit  uint64 `query:"limit" example:"100"`
+	Page   uint64 `query:"page" example:"2"`
+}
+
+// RulePayload represent payload fetch from request body
+type RulePayload struct {
+	ID        int64    `param:"ruleID" json:"-" example:"1"`
+	FarmID    int64    `param:"farmID" json:"-" example:"1"`
+	PondID    *int64   `json:"pond_id" example:"1"` // leave empty to apply the rule for every pond in the farm
+	Name      string   `json:"name" example:"Low DO"`
+	Parameter string   `json:"parameter" example:"dissolved_oxygen" enums:"dissolved_oxygen,ph,temperature,salinity,ammonia,nitrite,turbidity"`
+	MinValue  *float64 `json:"min_value" example:"4"`
+	MaxValue  *float64 `json:"max_value"`
+	IsActive  *bool    `json:"is_active" example:"true"`
+}
+
+// RuleResponse represent domain response for Alert Rule entity
+type RuleResponse struct {
+	ID        int64    `json:"id" example:"1"`
+	FarmID    int64    `json:"farm_id" example:"1"`
+	PondID    *int64   `json:"pond_id" example:"1"`
+	Name      string   `json:"name" example:"Low DO"`
+	Parameter string   `json:"parameter" example:"dissolved_oxygen"`
+	MinValue  *float64 `json:"min_value" example:"4"`
+	MaxValue  *float64 `json:"max_value"`
+	IsActive  bool     `json:"is_active" example:"true"`
+}
+
+// ListRuleResponse represent domain response for bulk Alert Rule entities
+type ListRuleResponse struct {
+	Rules []*RuleResponse        `json:"rules"`
+	Meta  httpres.ListPagination `json:"meta"`
+}
+
+// AlertRequestQuery represent query parameters fetch from request
+type AlertRequestQuery struct {
+	ID     int64  `param:"alertID" example:"1"`
+	FarmID int64  `param:"farmID" example:"1"`
+	PondID int64  `query:"pond_id" example:"1"`
+	Status string `query:"status" example:"open"`
+	Limit  uint64 `query:"limit" example:"100"`
+	Page   uint64 `query:"page" example:"2"`
+}
+
+// AlertStatusPayload represent payload fetch from request body to move an alert into another status
+type AlertStatusPayload struct {
+	ID     int64  `param:"alertID" json:"-" example:"1"`
+	FarmID int64  `param:"farmID" json:"-" example:"1"`
+	Status string `json:"status" example:"acknowledged" enums:"acknowledged,resolved"`
+}
+
+// AlertResponse represent domain response for Alert entity
+type AlertResponse struct {
+	ID             int64      `json:"id" example:"1"`
+	RuleID         int64      `json:"rule_id" example:"1"`
+	FarmID         int64      `json:"farm_id" example:"1"`
+	PondID         int64      `json:"pond_id" example:"1"`
+	ReadingID      int64      `json:"reading_id" example:"1"`
+	Parameter      string     `json:"parameter" example:"dissolved_oxygen"`
+	Value          float64    `json:"value" example:"3.1"`
+	Status         string     `json:"status" example:"open"`
+	OpenedAt       time.Time  `json:"opened_at" example:"2024-07-01T02:00:00Z"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+}
+
+// ListAlertResponse represent domain response for bulk Alert entities
+type ListAlertResponse struct {
+	Alerts []*AlertResponse       `json:"alerts"`
+	Meta   httpres.ListPagination `json:"meta"`
+}
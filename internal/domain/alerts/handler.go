@@ -0,0 +1,333 @@
+package alerts
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllRuleHandler func(context.Context, *RuleRequestQuery) (*ListRuleResponse, error)
+
+// Get All Alert Rule godoc
+//
+//	@Summary	get all alert rules of a farm
+//	@Tags		Alert
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pond_id	query		int		false	"only return rules bound to the pond"
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListRuleResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alert-rules [get]
+func HandleGetAllRule(handler GetAllRuleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &RuleRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneRuleHandler func(context.Context, *RuleRequestQuery) (*RuleResponse, error)
+
+// Get One Alert Rule godoc
+//
+//	@Summary	get specific alert rule by ID
+//	@Tags		Alert
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Param		ruleID	path		int	true	"Rule ID"
+//	@Success	200		{object}	RuleResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alert-rules/{ruleID} [get]
+func HandleGetOneRule(handler GetOneRuleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &RuleRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateRuleHandler func(context.Context, *RulePayload) error
+
+// CreateRule godoc
+//
+//	@Summary	create a new alert rule
+//	@Tags		Alert
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int			true	"Farm ID"
+//	@Param		payload	body		RulePayload	true	"rule payload"
+//	@Success	201		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid parameter or threshold"
+//	@Failure	404		{object}	httpres.ErrorResponse	"farm or pond not existed"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alert-rules [post]
+func HandleCreateRule(handler CreateRuleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &RulePayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateRuleHandler func(context.Context, *RulePayload) error
+
+// Update Alert Rule godoc
+//
+//	@Summary	update alert rule data
+//	@Tags		Alert
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int			true	"Farm ID"
+//	@Param		ruleID	path		int			true	"Rule ID"
+//	@Param		payload	body		RulePayload	true	"rule payload"
+//	@Success	200		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid parameter or threshold"
+//	@Failure	404		{object}	httpres.ErrorResponse	"rule not existed"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alert-rules/{ruleID} [put]
+func HandleUpdateRule(handler UpdateRuleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &RulePayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteRuleHandler func(context.Context, *RuleRequestQuery) error
+
+// DeleteRule godoc
+//
+//	@Summary	delete specific alert rule by ID
+//	@Tags		Alert
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Param		ruleID	path		int	true	"Rule ID"
+//	@Success	200		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"rule not existed"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alert-rules/{ruleID} [delete]
+func HandleDeleteRule(handler DeleteRuleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &RuleRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetAllAlertHandler func(context.Context, *AlertRequestQuery) (*ListAlertResponse, error)
+
+// Get All Alert godoc
+//
+//	@Summary	get all alerts of a farm
+//	@Tags		Alert
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pond_id	query		int		false	"only return alerts of the pond"
+//	@Param		status	query		string	false	"alert status"	Enums(open, acknowledged, resolved)
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListAlertResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alerts [get]
+func HandleGetAllAlert(handler GetAllAlertHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &AlertRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneAlertHandler func(context.Context, *AlertRequestQuery) (*AlertResponse, error)
+
+// Get One Alert godoc
+//
+//	@Summary	get specific alert by ID
+//	@Tags		Alert
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Param		alertID	path		int	true	"Alert ID"
+//	@Success	200		{object}	AlertResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alerts/{alertID} [get]
+func HandleGetOneAlert(handler GetOneAlertHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &AlertRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type UpdateAlertStatusHandler func(context.Context, *AlertStatusPayload) error
+
+// Update Alert Status godoc
+//
+//	@Summary	acknowledge or resolve an alert
+//	@Tags		Alert
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int					true	"Farm ID"
+//	@Param		alertID	path		int					true	"Alert ID"
+//	@Param		payload	body		AlertStatusPayload	true	"status payload"
+//	@Success	200		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"alert not existed"
+//	@Failure	409		{object}	httpres.ErrorResponse	"alert can't be moved into requested status"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alerts/{alertID} [put]
+func HandleUpdateAlertStatus(handler UpdateAlertStatusHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &AlertStatusPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteAlertHandler func(context.Context, *AlertRequestQuery) error
+
+// DeleteAlert godoc
+//
+//	@Summary	delete specific alert by ID
+//	@Tags		Alert
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Param		alertID	path		int	true	"Alert ID"
+//	@Success	200		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"alert not existed"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/alerts/{alertID} [delete]
+func HandleDeleteAlert(handler DeleteAlertHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &AlertRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,56 @@
+package alerts
+
+import (
+	"database/sql"
+	"time"
+)
+
+type RuleType struct {
+	ID        int64         `db:"id"`
+	FarmID    int64         `db:"farm_id"`
+	PondID    sql.NullInt64 `db:"pond_id"`
+	Name      string        `db:"name"`
+	Parameter string        `db:"parameter"`
+	MinValue  *float64      `db:"min_value"`
+	MaxValue  *float64      `db:"max_value"`
+	IsActive  bool          `db:"is_active"`
+}
+
+type AlertType struct {
+	ID             int64        `db:"id"`
+	RuleID         int64        `db:"rule_id"`
+	FarmID         int64        `db:"farm_id"`
+	PondID         int64        `db:"pond_id"`
+	ReadingID      int64        `db:"reading_id"`
+	Parameter      string       `db:"parameter"`
+	Value          float64      `db:"value"`
+	Status         string       `db:"status"`
+	OpenedAt       time.Time    `db:"opened_at"`
+	AcknowledgedAt sql.NullTime `db:"acknowledged_at"`
+	ResolvedAt     sql.NullTime `db:"resolved_at"`
+}
+
+// supported water quality parameters to be watched by a rule
+const (
+	ParamDissolvedOxygen = "dissolved_oxygen"
+	ParamPH              = "ph"
+	ParamTemperature     = "temperature"
+	ParamSalinity        = "salinity"
+	ParamAmmonia         = "ammonia"
+	ParamNitrite         = "nitrite"
+	ParamTurbidity       = "turbidity"
+)
+
+// alert lifecycle status
+const (
+	StatusOpen         = "open"
+	StatusAcknowledged = "acknowledged"
+	StatusResolved     = "resolved"
+)
+
+// alertTransitions map every status into statuses it may move into
+var alertTransitions = map[string][]string{
+	StatusOpen:         {StatusAcknowledged, StatusResolved},
+	StatusAcknowledged: {StatusResolved},
+	StatusResolved:     {},
+}
@@ -0,0 +1,501 @@
+package alerts
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// AlertRepository contain contract that defined all necessary public function available to be interact with
+type AlertRepository interface {
+	GetAllRule(context.Context, *ruleQuery) ([]*RuleType, error)
+	CountRule(context.Context, *ruleQuery) (uint64, error)
+	GetOneRule(context.Context, *ruleQuery) (*RuleType, error)
+	GetActiveRule(context.Context, *ruleQuery) ([]*RuleType, error)
+	StoreRule(context.Context, *RuleType) error
+	UpdateRule(context.Context, *RuleType) error
+	DeleteRule(context.Context, *ruleQuery) error
+
+	GetAllAlert(context.Context, *alertQuery) ([]*AlertType, error)
+	CountAlert(context.Context, *alertQuery) (uint64, error)
+	GetOneAlert(context.Context, *alertQuery) (*AlertType, error)
+	StoreAlert(context.Context, *AlertType) error
+	UpdateAlertStatus(context.Context, *AlertType) error
+	DeleteAlert(context.Context, *alertQuery) error
+}
+
+type alertRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of alertRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) AlertRepository {
+	return &alertRepository{db: db}
+}
+
+type ruleQuery struct {
+	ID, FarmID, PondID int64
+	Limit, Page        uint64
+}
+
+type alertQuery struct {
+	ID, FarmID, PondID int64
+	Status             string
+	Limit, Page        uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var ruleColumns = []string{"id", "farm_id", "pond_id", "name", "parameter", "min_value", "max_value", "is_active"}
+
+var alertColumns = []string{
+	"id", "rule_id", "farm_id", "pond_id", "reading_id", "parameter", "value", "status",
+	"opened_at", "acknowledged_at", "resolved_at",
+}
+
+func (params *ruleQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"pond_id": params.PondID})
+	}
+
+	return cond
+}
+
+func (params *alertQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"pond_id": params.PondID})
+	}
+
+	if params.Status != "" {
+		cond = append(cond, squirrel.Eq{"status": params.Status})
+	}
+
+	return cond
+}
+
+func (repo *alertRepository) GetAllRule(ctx context.Context, params *ruleQuery) (res []*RuleType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(ruleColumns...).From("alert_rules").
+		Where(params.filter()).
+		OrderBy("id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	return repo.selectRule(ctx, logger, stmt, args)
+}
+
+func (repo *alertRepository) CountRule(ctx context.Context, params *ruleQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("alert_rules").Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *alertRepository) GetOneRule(ctx context.Context, params *ruleQuery) (res *RuleType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(ruleColumns...).From("alert_rules").
+		Where(squirrel.And{
+			squirrel.Eq{"id": params.ID},
+			squirrel.Eq{"farm_id": params.FarmID},
+			squirrel.Eq{"deleted_at": nil},
+		}).ToSql()
+
+	res = &RuleType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// GetActiveRule return every active rule applicable to a pond, including the farm-wide ones
+func (repo *alertRepository) GetActiveRule(ctx context.Context, params *ruleQuery) (res []*RuleType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(ruleColumns...).From("alert_rules").
+		Where(squirrel.And{
+			squirrel.Eq{"farm_id": params.FarmID},
+			squirrel.Or{
+				squirrel.Eq{"pond_id": params.PondID},
+				squirrel.Eq{"pond_id": nil},
+			},
+			squirrel.Eq{"is_active": true},
+			squirrel.Eq{"deleted_at": nil},
+		}).ToSql()
+
+	return repo.selectRule(ctx, logger, stmt, args)
+}
+
+func (repo *alertRepository) selectRule(ctx context.Context, logger *zerolog.Logger, stmt string, args []any) (res []*RuleType, err error) {
+	res = []*RuleType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &RuleType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+// validateRuleScope make sure both farm and (optional) pond of a rule are existed
+func (repo *alertRepository) validateRuleScope(ctx context.Context, tx *sqlx.Tx, payload *RuleType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var count int64
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
+		squirrel.Eq{"id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate farm data existence")
+		return
+	}
+
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	if !payload.PondID.Valid {
+		return nil
+	}
+
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+		squirrel.Eq{"id": payload.PondID.Int64},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate pond data existence")
+		return
+	}
+
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+func (repo *alertRepository) StoreRule(ctx context.Context, payload *RuleType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validateRuleScope(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("alert_rules").
+		Columns("farm_id", "pond_id", "name", "parameter", "min_value", "max_value", "is_active").
+		Values(payload.FarmID, payload.PondID, payload.Name, payload.Parameter, payload.MinValue, payload.MaxValue, payload.IsActive).ToSql()
+
+	_, err = tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *alertRepository) UpdateRule(ctx context.Context, payload *RuleType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validateRuleScope(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("alert_rules").SetMap(map[string]interface{}{
+		"pond_id":    payload.PondID,
+		"name":       payload.Name,
+		"parameter":  payload.Parameter,
+		"min_value":  payload.MinValue,
+		"max_value":  payload.MaxValue,
+		"is_active":  payload.IsActive,
+		"updated_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *alertRepository) DeleteRule(ctx context.Context, params *ruleQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("alert_rules").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("rule doesn't exists")
+		return
+	}
+
+	return
+}
+
+func (repo *alertRepository) GetAllAlert(ctx context.Context, params *alertQuery) (res []*AlertType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(alertColumns...).From("alerts").
+		Where(params.filter()).
+		OrderBy("opened_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*AlertType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &AlertType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *alertRepository) CountAlert(ctx context.Context, params *alertQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("alerts").Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *alertRepository) GetOneAlert(ctx context.Context, params *alertQuery) (res *AlertType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(alertColumns...).From("alerts").
+		Where(squirrel.And{
+			squirrel.Eq{"id": params.ID},
+			squirrel.Eq{"farm_id": params.FarmID},
+			squirrel.Eq{"deleted_at": nil},
+		}).ToSql()
+
+	res = &AlertType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// StoreAlert open a new alert, unless the same rule already has an unresolved alert on the pond
+func (repo *alertRepository) StoreAlert(ctx context.Context, payload *AlertType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var stmt string
+	var args []any
+	var count int64
+
+	// check for unresolved alert of the same rule
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("alerts").Where(squirrel.And{
+		squirrel.Eq{"rule_id": payload.RuleID},
+		squirrel.Eq{"pond_id": payload.PondID},
+		squirrel.NotEq{"status": StatusResolved},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
+		return
+	}
+
+	if count != 0 {
+		return errs.ErrDuplicatedResources
+	}
+
+	stmt, args, _ = pgSquirrel.Insert("alerts").
+		Columns("rule_id", "farm_id", "pond_id", "reading_id", "parameter", "value", "status", "opened_at").
+		Values(payload.RuleID, payload.FarmID, payload.PondID, payload.ReadingID, payload.Parameter, payload.Value, payload.Status, payload.OpenedAt).ToSql()
+
+	_, err = tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *alertRepository) UpdateAlertStatus(ctx context.Context, payload *AlertType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("alerts").SetMap(map[string]interface{}{
+		"status":          payload.Status,
+		"acknowledged_at": payload.AcknowledgedAt,
+		"resolved_at":     payload.ResolvedAt,
+		"updated_at":      squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+func (repo *alertRepository) DeleteAlert(ctx context.Context, params *alertQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("alerts").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("alert doesn't exists")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,176 @@
+package alerts
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestShouldGetActiveRuleOfPond(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	alertRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "farm_id", "pond_id", "name", "parameter", "min_value", "max_value", "is_active"}).
+		AddRow(1, 1, nil, "Low DO", "dissolved_oxygen", 4, nil, true).
+		AddRow(2, 1, 2, "pH range", "ph", 6.5, 8.5, true)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, farm_id, pond_id, name, parameter, min_value, max_value, is_active FROM alert_rules WHERE (farm_id = $1 AND (pond_id = $2 OR pond_id IS NULL) AND is_active = $3 AND deleted_at IS NULL)")).
+		WithArgs(1, 2, true).
+		WillReturnRows(rows)
+
+	res, err := alertRepo.GetActiveRule(context.Background(), &ruleQuery{FarmID: 1, PondID: 2})
+	if err != nil || len(res) != 2 {
+		t.Errorf("expected 2 rules, got %d, err: %s", len(res), err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	alertRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	minDO := 4.0
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO alert_rules (farm_id,pond_id,name,parameter,min_value,max_value,is_active) VALUES ($1,$2,$3,$4,$5,$6,$7)")).
+		WithArgs(1, 2, "Low DO", "dissolved_oxygen", &minDO, nil, true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	alertRepo.StoreRule(context.Background(), &RuleType{
+		FarmID:    1,
+		PondID:    sql.NullInt64{Int64: 2, Valid: true},
+		Name:      "Low DO",
+		Parameter: ParamDissolvedOxygen,
+		MinValue:  &minDO,
+		IsActive:  true,
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreRuleDuePondNotExisted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	alertRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectRollback()
+
+	alertRepo.StoreRule(context.Background(), &RuleType{FarmID: 1, PondID: sql.NullInt64{Int64: 2, Valid: true}, Name: "Low DO", Parameter: ParamDissolvedOxygen})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreAlert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	alertRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	openedAt := time.Date(2024, 7, 1, 2, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM alerts WHERE (rule_id = $1 AND pond_id = $2 AND status <> $3 AND deleted_at IS NULL)")).WithArgs(1, 2, StatusResolved).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO alerts (rule_id,farm_id,pond_id,reading_id,parameter,value,status,opened_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)")).
+		WithArgs(1, 1, 2, 10, "dissolved_oxygen", 3.1, StatusOpen, openedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	alertRepo.StoreAlert(context.Background(), &AlertType{
+		RuleID:    1,
+		FarmID:    1,
+		PondID:    2,
+		ReadingID: 10,
+		Parameter: ParamDissolvedOxygen,
+		Value:     3.1,
+		Status:    StatusOpen,
+		OpenedAt:  openedAt,
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreAlertDueUnresolvedAlertExisted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	alertRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM alerts WHERE (rule_id = $1 AND pond_id = $2 AND status <> $3 AND deleted_at IS NULL)")).WithArgs(1, 2, StatusResolved).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectRollback()
+
+	alertRepo.StoreAlert(context.Background(), &AlertType{RuleID: 1, FarmID: 1, PondID: 2, Status: StatusOpen})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldUpdateAlertStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	alertRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	ackAt := sql.NullTime{Time: time.Date(2024, 7, 1, 2, 30, 0, 0, time.UTC), Valid: true}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE alerts SET acknowledged_at = $1, resolved_at = $2, status = $3, updated_at = NOW() WHERE (id = $4 AND farm_id = $5 AND deleted_at IS NULL)")).
+		WithArgs(ackAt, sql.NullTime{}, StatusAcknowledged, 1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	alertRepo.UpdateAlertStatus(context.Background(), &AlertType{ID: 1, FarmID: 1, Status: StatusAcknowledged, AcknowledgedAt: ackAt})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -0,0 +1,437 @@
+package alerts
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/domain/waterquality"
+	"github.com/rs/zerolog"
+)
+
+// AlertService contains public API available to be interacted with
+type AlertService interface {
+	GetAllRule(context.Context, *RuleRequestQuery) (*ListRuleResponse, error)
+	GetOneRule(context.Context, *RuleRequestQuery) (*RuleResponse, error)
+	CreateRule(context.Context, *RulePayload) error
+	UpdateRule(context.Context, *RulePayload) error
+	DeleteRule(context.Context, *RuleRequestQuery) error
+
+	GetAllAlert(context.Context, *AlertRequestQuery) (*ListAlertResponse, error)
+	GetOneAlert(context.Context, *AlertRequestQuery) (*AlertResponse, error)
+	UpdateAlertStatus(context.Context, *AlertStatusPayload) error
+	DeleteAlert(context.Context, *AlertRequestQuery) error
+
+	EvaluateReading(context.Context, int64, *waterquality.ReadingResponse) error
+}
+
+type alertService struct {
+	repo AlertRepository
+}
+
+// NewService return an instance of AlertService containing available usecases
+func NewService(repo AlertRepository) AlertService {
+	return &alertService{repo: repo}
+}
+
+func (svc *alertService) GetAllRule(ctx context.Context, params *RuleRequestQuery) (res *ListRuleResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &ruleQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListRuleResponse{
+		Rules: []*RuleResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.CountRule(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	rules, err := svc.repo.GetAllRule(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, rule := range rules {
+		res.Rules = append(res.Rules, toRuleResponse(rule))
+	}
+
+	return
+}
+
+func (svc *alertService) GetOneRule(ctx context.Context, params *RuleRequestQuery) (res *RuleResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	rule, err := svc.repo.GetOneRule(ctx, &ruleQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if rule == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toRuleResponse(rule), nil
+}
+
+func (svc *alertService) CreateRule(ctx context.Context, payload *RulePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toRuleType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.StoreRule(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *alertService) UpdateRule(ctx context.Context, payload *RulePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toRuleType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.UpdateRule(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *alertService) DeleteRule(ctx context.Context, params *RuleRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	err = svc.repo.DeleteRule(ctx, &ruleQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *alertService) GetAllAlert(ctx context.Context, params *AlertRequestQuery) (res *ListAlertResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &alertQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		Status: params.Status,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListAlertResponse{
+		Alerts: []*AlertResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.CountAlert(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	alerts, err := svc.repo.GetAllAlert(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, alert := range alerts {
+		res.Alerts = append(res.Alerts, toAlertResponse(alert))
+	}
+
+	return
+}
+
+func (svc *alertService) GetOneAlert(ctx context.Context, params *AlertRequestQuery) (res *AlertResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	alert, err := svc.repo.GetOneAlert(ctx, &alertQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if alert == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toAlertResponse(alert), nil
+}
+
+// UpdateAlertStatus move an alert forward into acknowledged or resolved status
+func (svc *alertService) UpdateAlertStatus(ctx context.Context, payload *AlertStatusPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	alert, err := svc.repo.GetOneAlert(ctx, &alertQuery{ID: payload.ID, FarmID: payload.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if alert == nil {
+		return errs.ErrNotFound
+	}
+
+	if _, ok := alertTransitions[payload.Status]; !ok {
+		return errs.ErrBadRequest
+	}
+
+	if !slices.Contains(alertTransitions[alert.Status], payload.Status) {
+		return errs.ErrInvalidStateTransition
+	}
+
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+
+	alert.Status = payload.Status
+	switch payload.Status {
+	case StatusAcknowledged:
+		alert.AcknowledgedAt = now
+	case StatusResolved:
+		alert.ResolvedAt = now
+	}
+
+	err = svc.repo.UpdateAlertStatus(ctx, alert)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *alertService) DeleteAlert(ctx context.Context, params *AlertRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	err = svc.repo.DeleteAlert(ctx, &alertQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// EvaluateReading check a newly stored reading against every active rule of its pond
+// and open an alert for each breached rule. Pond-level rule take precedence over
+// farm-wide rule watching the same parameter.
+func (svc *alertService) EvaluateReading(ctx context.Context, farmID int64, reading *waterquality.ReadingResponse) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	rules, err := svc.repo.GetActiveRule(ctx, &ruleQuery{FarmID: farmID, PondID: reading.PondID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	overridden := map[string]bool{}
+	for _, rule := range rules {
+		if rule.PondID.Valid {
+			overridden[rule.Parameter] = true
+		}
+	}
+
+	for _, rule := range rules {
+		if !rule.PondID.Valid && overridden[rule.Parameter] {
+			continue
+		}
+
+		value := readingValue(reading, rule.Parameter)
+		if value == nil || !isBreached(rule, *value) {
+			continue
+		}
+
+		err = svc.repo.StoreAlert(ctx, &AlertType{
+			RuleID:    rule.ID,
+			FarmID:    farmID,
+			PondID:    reading.PondID,
+			ReadingID: reading.ID,
+			Parameter: rule.Parameter,
+			Value:     *value,
+			Status:    StatusOpen,
+			OpenedAt:  reading.MeasuredAt,
+		})
+		// an unresolved alert for the rule is still around, no need to open another one
+		if err == errs.ErrDuplicatedResources {
+			err = nil
+			continue
+		} else if err != nil {
+			logger.Error().Err(err).Send()
+			return
+		}
+
+		logger.Warn().Int64("pond-id", reading.PondID).Int64("rule-id", rule.ID).Float64("value", *value).Msg("water quality alert opened")
+	}
+
+	return
+}
+
+func isBreached(rule *RuleType, value float64) bool {
+	if rule.MinValue != nil && value < *rule.MinValue {
+		return true
+	}
+
+	if rule.MaxValue != nil && value > *rule.MaxValue {
+		return true
+	}
+
+	return false
+}
+
+func readingValue(reading *waterquality.ReadingResponse, parameter string) *float64 {
+	switch parameter {
+	case ParamDissolvedOxygen:
+		return reading.DissolvedOxygen
+	case ParamPH:
+		return reading.PH
+	case ParamTemperature:
+		return reading.Temperature
+	case ParamSalinity:
+		return reading.Salinity
+	case ParamAmmonia:
+		return reading.Ammonia
+	case ParamNitrite:
+		return reading.Nitrite
+	case ParamTurbidity:
+		return reading.Turbidity
+	}
+
+	return nil
+}
+
+func toRuleType(payload *RulePayload) (res *RuleType, err error) {
+	if payload.Name == "" || payload.Parameter == "" || (payload.MinValue == nil && payload.MaxValue == nil) {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	switch payload.Parameter {
+	case ParamDissolvedOxygen, ParamPH, ParamTemperature, ParamSalinity, ParamAmmonia, ParamNitrite, ParamTurbidity:
+	default:
+		return nil, errs.ErrBadRequest
+	}
+
+	if payload.MinValue != nil && payload.MaxValue != nil && *payload.MinValue > *payload.MaxValue {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &RuleType{
+		ID:        payload.ID,
+		FarmID:    payload.FarmID,
+		Name:      payload.Name,
+		Parameter: payload.Parameter,
+		MinValue:  payload.MinValue,
+		MaxValue:  payload.MaxValue,
+		IsActive:  true,
+	}
+
+	if payload.PondID != nil {
+		res.PondID = sql.NullInt64{Int64: *payload.PondID, Valid: true}
+	}
+
+	if payload.IsActive != nil {
+		res.IsActive = *payload.IsActive
+	}
+
+	return
+}
+
+func toRuleResponse(rule *RuleType) *RuleResponse {
+	res := &RuleResponse{
+		ID:        rule.ID,
+		FarmID:    rule.FarmID,
+		Name:      rule.Name,
+		Parameter: rule.Parameter,
+		MinValue:  rule.MinValue,
+		MaxValue:  rule.MaxValue,
+		IsActive:  rule.IsActive,
+	}
+
+	if rule.PondID.Valid {
+		res.PondID = &rule.PondID.Int64
+	}
+
+	return res
+}
+
+func toAlertResponse(alert *AlertType) *AlertResponse {
+	res := &AlertResponse{
+		ID:        alert.ID,
+		RuleID:    alert.RuleID,
+		FarmID:    alert.FarmID,
+		PondID:    alert.PondID,
+		ReadingID: alert.ReadingID,
+		Parameter: alert.Parameter,
+		Value:     alert.Value,
+		Status:    alert.Status,
+		OpenedAt:  alert.OpenedAt,
+	}
+
+	if alert.AcknowledgedAt.Valid {
+		res.AcknowledgedAt = &alert.AcknowledgedAt.Time
+	}
+
+	if alert.ResolvedAt.Valid {
+		res.ResolvedAt = &alert.ResolvedAt.Time
+	}
+
+	return res
+}
@@ -0,0 +1,68 @@
+package alerts
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/domain/waterquality"
+)
+
+// stubAlertRepository only implement the part of AlertRepository used by the evaluation engine
+type stubAlertRepository struct {
+	AlertRepository
+	rules  []*RuleType
+	stored []*AlertType
+}
+
+func (repo *stubAlertRepository) GetActiveRule(context.Context, *ruleQuery) ([]*RuleType, error) {
+	return repo.rules, nil
+}
+
+func (repo *stubAlertRepository) StoreAlert(_ context.Context, payload *AlertType) error {
+	repo.stored = append(repo.stored, payload)
+	return nil
+}
+
+func TestShouldOpenAlertOnBreachedRule(t *testing.T) {
+	minDO, minPH, maxPH := 4.0, 6.5, 8.5
+	repo := &stubAlertRepository{rules: []*RuleType{
+		{ID: 1, FarmID: 1, Parameter: ParamDissolvedOxygen, MinValue: &minDO, IsActive: true},
+		{ID: 2, FarmID: 1, Parameter: ParamPH, MinValue: &minPH, MaxValue: &maxPH, IsActive: true},
+	}}
+
+	do, ph := 3.2, 7.0
+	svc := NewService(repo)
+	err := svc.EvaluateReading(context.Background(), 1, &waterquality.ReadingResponse{
+		ID: 10, PondID: 2, DissolvedOxygen: &do, PH: &ph, MeasuredAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(repo.stored) != 1 || repo.stored[0].RuleID != 1 || repo.stored[0].Value != do {
+		t.Errorf("expected only low DO alert to be opened, got %+v", repo.stored)
+	}
+}
+
+func TestShouldPreferPondRuleOverFarmRule(t *testing.T) {
+	farmMin, pondMin := 4.0, 3.0
+	repo := &stubAlertRepository{rules: []*RuleType{
+		{ID: 1, FarmID: 1, Parameter: ParamDissolvedOxygen, MinValue: &farmMin, IsActive: true},
+		{ID: 2, FarmID: 1, PondID: sql.NullInt64{Int64: 2, Valid: true}, Parameter: ParamDissolvedOxygen, MinValue: &pondMin, IsActive: true},
+	}}
+
+	do := 3.5
+	svc := NewService(repo)
+	err := svc.EvaluateReading(context.Background(), 1, &waterquality.ReadingResponse{
+		ID: 10, PondID: 2, DissolvedOxygen: &do, MeasuredAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(repo.stored) != 0 {
+		t.Errorf("expected pond rule to override farm rule, got %+v", repo.stored)
+	}
+}
@@ -0,0 +1,36 @@
+package apikeys
+
+import "github.com/labstack/echo/v4"
+
+type APIKeyController struct {
+	svc APIKeyService
+}
+
+func NewController(svc APIKeyService) *APIKeyController {
+	return &APIKeyController{
+		svc: svc,
+	}
+}
+
+const (
+	apiKeyBasepath = "/api-keys"
+	apiKeyIDPath   = "/:keyID"
+	rotatePath     = "/:keyID/rotate"
+)
+
+func (kc *APIKeyController) Route(grp *echo.Group) {
+	subrouter := grp.Group(apiKeyBasepath)
+
+	subrouter.GET("", HandleGetAllAPIKey(kc.svc.GetAll))
+	subrouter.OPTIONS("", HandleGetAllAPIKey(kc.svc.GetAll))
+	subrouter.GET(apiKeyIDPath, HandleGetOneAPIKey(kc.svc.GetOne))
+	subrouter.OPTIONS(apiKeyIDPath, HandleGetOneAPIKey(kc.svc.GetOne))
+	subrouter.POST("", HandleCreateAPIKey(kc.svc.Create))
+	subrouter.OPTIONS("", HandleCreateAPIKey(kc.svc.Create))
+	subrouter.POST(rotatePath, HandleRotateAPIKey(kc.svc.Rotate))
+	subrouter.OPTIONS(rotatePath, HandleRotateAPIKey(kc.svc.Rotate))
+	subrouter.DELETE(apiKeyIDPath, HandleRevokeAPIKey(kc.svc.Revoke))
+	subrouter.OPTIONS(apiKeyIDPath, HandleRevokeAPIKey(kc.svc.Revoke))
+
+	return
+}
@@ -0,0 +1,46 @@
+package apikeys
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// APIKeyRequestQuery represent query parameters fetch from request
+type APIKeyRequestQuery struct {
+	ID      int64  `param:"keyID" example:"1"`
+	Revoked bool   `query:"revoked" example:"false"` // include revoked and expired keys
+	Limit   uint64 `query:"limit" example:"100"`
+	Page    uint64 `query:"page" example:"2"`
+}
+
+// APIKeyPayload represent payload fetch from request body
+type APIKeyPayload struct {
+	Name      string     `json:"name" example:"ERP integration"`
+	Scopes    []string   `json:"scopes" example:"read:farms,write:ponds" enums:"read:farms,write:ponds,ingest:sensors"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2025-08-18T00:00:00Z"` // never expires when empty
+}
+
+// APIKeyResponse represent domain response for API Key entity, the key itself is never shown again
+type APIKeyResponse struct {
+	ID         int64      `json:"id" example:"1"`
+	Name       string     `json:"name" example:"ERP integration"`
+	Prefix     string     `json:"prefix" example:"dfk_Xq3b"`
+	Scopes     []string   `json:"scopes" example:"read:farms,write:ponds"`
+	ExpiresAt  *time.Time `json:"expires_at" example:"2025-08-18T00:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at" example:"2024-08-18T06:00:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" example:"2024-08-18T02:25:10Z"`
+}
+
+// APIKeySecretResponse represent freshly generated key, only shown once
+type APIKeySecretResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"dfk_Xq3b..."`
+}
+
+// ListAPIKeyResponse represent domain response for bulk API Key entities
+type ListAPIKeyResponse struct {
+	APIKeys []*APIKeyResponse      `json:"api_keys"`
+	Meta    httpres.ListPagination `json:"meta"`
+}
@@ -0,0 +1,178 @@
+package apikeys
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllAPIKeyHandler func(context.Context, *APIKeyRequestQuery) (*ListAPIKeyResponse, error)
+
+// Get All API Key godoc
+//
+//	@Summary	get API keys owned by the logged in user
+//	@Tags		API Key
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		revoked	query		bool	false	"include revoked and expired keys"
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListAPIKeyResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/api-keys [get]
+func HandleGetAllAPIKey(handler GetAllAPIKeyHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &APIKeyRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneAPIKeyHandler func(context.Context, *APIKeyRequestQuery) (*APIKeyResponse, error)
+
+// Get One API Key godoc
+//
+//	@Summary	get specific API key by ID, the key itself is never shown again
+//	@Tags		API Key
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		keyID	path		int	true	"API Key ID"
+//	@Success	200		{object}	APIKeyResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/api-keys/{keyID} [get]
+func HandleGetOneAPIKey(handler GetOneAPIKeyHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &APIKeyRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateAPIKeyHandler func(context.Context, *APIKeyPayload) (*APIKeySecretResponse, error)
+
+// CreateAPIKey godoc
+//
+//	@Summary	issue a new API key acting on behalf of the logged in user within its scopes, the key is only shown once
+//	@Tags		API Key
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		payload	body		APIKeyPayload	true	"API key payload"
+//	@Success	201		{object}	APIKeySecretResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"missing name or scopes, unknown scope or past expiry"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/api-keys [post]
+func HandleCreateAPIKey(handler CreateAPIKeyHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &APIKeyPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, data)
+	}
+}
+
+type RotateAPIKeyHandler func(context.Context, *APIKeyRequestQuery) (*APIKeySecretResponse, error)
+
+// Rotate API Key godoc
+//
+//	@Summary	replace the secret of an active API key, the previous secret stops working immediately
+//	@Tags		API Key
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		keyID	path		int	true	"API Key ID"
+//	@Success	200		{object}	APIKeySecretResponse
+//	@Failure	404		{object}	httpres.ErrorResponse	"key not existed, revoked or expired"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/api-keys/{keyID}/rotate [post]
+func HandleRotateAPIKey(handler RotateAPIKeyHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &APIKeyRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type RevokeAPIKeyHandler func(context.Context, *APIKeyRequestQuery) error
+
+// RevokeAPIKey godoc
+//
+//	@Summary	permanently revoke specific API key by ID
+//	@Tags		API Key
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		keyID	path		int	true	"API Key ID"
+//	@Success	200		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"key not existed or already revoked"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/api-keys/{keyID} [delete]
+func HandleRevokeAPIKey(handler RevokeAPIKeyHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &APIKeyRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,68 @@
+package apikeys
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+)
+
+// KeyPrefix is prepended into every generated key, so a leaked key is easy to recognize
+const KeyPrefix = "dfk_"
+
+// lastUsedInterval throttle recording of key usage, so every request doesn't end up as a write
+const lastUsedInterval = time.Minute
+
+// scopes list every scope a key may be granted
+var scopes = []string{auth.ScopeReadFarms, auth.ScopeWritePonds, auth.ScopeIngestSensors}
+
+type APIKeyType struct {
+	ID             int64        `db:"id"`
+	UserID         int64        `db:"user_id"`
+	Username       string       `db:"username"`
+	IsAdmin        bool         `db:"is_admin"`
+	OrganizationID int64        `db:"organization_id"`
+	Name           string       `db:"name"`
+	Prefix         string       `db:"prefix"`
+	KeyHash        string       `db:"key_hash"`
+	Scopes         Scopes       `db:"scopes"`
+	ExpiresAt      sql.NullTime `db:"expires_at"`
+	LastUsedAt     sql.NullTime `db:"last_used_at"`
+	RevokedAt      sql.NullTime `db:"revoked_at"`
+	CreatedAt      time.Time    `db:"created_at"`
+}
+
+// Scopes represent granted scopes stored as comma separated string
+type Scopes []string
+
+// Value implements driver.Valuer
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, ","), nil
+}
+
+// Scan implements sql.Scanner
+func (s *Scopes) Scan(src any) error {
+	var v string
+
+	switch src := src.(type) {
+	case nil:
+		*s = Scopes{}
+		return nil
+	case []byte:
+		v = string(src)
+	case string:
+		v = src
+	default:
+		return errors.New("unsupported type for Scopes")
+	}
+
+	*s = Scopes{}
+	if v != "" {
+		*s = strings.Split(v, ",")
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// APIKeyRepository contain contract that defined all necessary public function available to be interact with
+type APIKeyRepository interface {
+	GetAll(context.Context, *apiKeyQuery) ([]*APIKeyType, error)
+	Count(context.Context, *apiKeyQuery) (uint64, error)
+	GetOne(context.Context, *apiKeyQuery) (*APIKeyType, error)
+	Store(context.Context, *APIKeyType) error
+	UpdateKey(context.Context, *APIKeyType) error
+	Revoke(context.Context, *apiKeyQuery) error
+	Touch(context.Context, int64) error
+}
+
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of apiKeyRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// apiKeyQuery select keys of a user, or the key owning KeyHash regardless of its owner
+type apiKeyQuery struct {
+	ID, UserID  int64
+	KeyHash     string
+	Active      bool // exclude revoked and expired keys
+	Limit, Page uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var apiKeyColumns = []string{
+	"k.id", "k.user_id", "u.username", "u.is_admin", "u.organization_id", "k.name", "k.prefix", "k.key_hash", "k.scopes",
+	"k.expires_at", "k.last_used_at", "k.revoked_at", "k.created_at",
+}
+
+func (params *apiKeyQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"u.deleted_at": nil},
+	}
+
+	if params.KeyHash != "" {
+		cond = append(cond, squirrel.Eq{"k.key_hash": params.KeyHash})
+	} else {
+		cond = append(cond, squirrel.Eq{"k.user_id": params.UserID})
+	}
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"k.id": params.ID})
+	}
+
+	if params.Active {
+		cond = append(cond,
+			squirrel.Eq{"k.revoked_at": nil},
+			squirrel.Expr("(k.expires_at IS NULL OR k.expires_at > NOW())"),
+		)
+	}
+
+	return cond
+}
+
+func (repo *apiKeyRepository) GetAll(ctx context.Context, params *apiKeyQuery) (res []*APIKeyType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(apiKeyColumns...).From("api_keys k").
+		Join("users u on k.user_id = u.id").
+		Where(params.filter()).
+		OrderBy("k.id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*APIKeyType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &APIKeyType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *apiKeyRepository) Count(ctx context.Context, params *apiKeyQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("api_keys k").
+		Join("users u on k.user_id = u.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *apiKeyRepository) GetOne(ctx context.Context, params *apiKeyQuery) (res *APIKeyType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(apiKeyColumns...).From("api_keys k").
+		Join("users u on k.user_id = u.id").
+		Where(params.filter()).ToSql()
+
+	res = &APIKeyType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// Store save a new key, the generated ID will be assigned back into payload
+func (repo *apiKeyRepository) Store(ctx context.Context, payload *APIKeyType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Insert("api_keys").
+		Columns("user_id", "name", "prefix", "key_hash", "scopes", "expires_at").
+		Values(payload.UserID, payload.Name, payload.Prefix, payload.KeyHash, payload.Scopes, payload.ExpiresAt).
+		Suffix("RETURNING id, created_at").ToSql()
+
+	if err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID, &payload.CreatedAt); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	return
+}
+
+// UpdateKey replace the secret of an active key, invalidating the previous one
+func (repo *apiKeyRepository) UpdateKey(ctx context.Context, payload *APIKeyType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("api_keys").SetMap(map[string]interface{}{
+		"prefix":     payload.Prefix,
+		"key_hash":   payload.KeyHash,
+		"updated_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"user_id": payload.UserID},
+		squirrel.Eq{"revoked_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+// Revoke permanently disable a key
+func (repo *apiKeyRepository) Revoke(ctx context.Context, params *apiKeyQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("api_keys").SetMap(map[string]interface{}{
+		"revoked_at": squirrel.Expr("NOW()"),
+		"updated_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"user_id": params.UserID},
+		squirrel.Eq{"revoked_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+// Touch record usage of a key, at most once per lastUsedInterval
+func (repo *apiKeyRepository) Touch(ctx context.Context, id int64) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("api_keys").
+		Set("last_used_at", squirrel.Expr("NOW()")).
+		Where(squirrel.And{
+			squirrel.Eq{"id": id},
+			squirrel.Expr(fmt.Sprintf("(last_used_at IS NULL OR last_used_at < NOW() - interval '%d seconds')", int(lastUsedInterval.Seconds()))),
+		}).ToSql()
+
+	if _, err = repo.db.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	return
+}
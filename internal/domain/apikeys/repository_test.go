@@ -0,0 +1,65 @@
+package apikeys
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldFetchActiveAPIKeyByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	keyRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT k.id, k.user_id, u.username, u.is_admin, u.organization_id, k.name, k.prefix, k.key_hash, k.scopes, k.expires_at, k.last_used_at, k.revoked_at, k.created_at FROM api_keys k JOIN users u on k.user_id = u.id WHERE (u.deleted_at IS NULL AND k.key_hash = $1 AND k.revoked_at IS NULL AND (k.expires_at IS NULL OR k.expires_at > NOW()))")).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "username", "is_admin", "organization_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "revoked_at", "created_at"}).
+			AddRow(3, 1, "budi", false, 1, "gateway", "dfk_abcdefgh", "hash", "ingest:sensors,read:farms", nil, nil, nil, time.Now()))
+
+	key, err := keyRepo.GetOne(context.Background(), &apiKeyQuery{KeyHash: "hash", Active: true})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(key.Scopes) != 2 || key.Scopes[0] != "ingest:sensors" || key.Scopes[1] != "read:farms" {
+		t.Errorf("expected scopes to be split, got: %v", key.Scopes)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTRevokeAlreadyRevokedAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	keyRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE api_keys SET revoked_at = NOW(), updated_at = NOW() WHERE (id = $1 AND user_id = $2 AND revoked_at IS NULL)")).
+		WithArgs(3, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = keyRepo.Revoke(context.Background(), &apiKeyQuery{ID: 3, UserID: 1})
+	if err != errs.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -0,0 +1,301 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// APIKeyService contains public API available to be interacted with
+type APIKeyService interface {
+	GetAll(context.Context, *APIKeyRequestQuery) (*ListAPIKeyResponse, error)
+	GetOne(context.Context, *APIKeyRequestQuery) (*APIKeyResponse, error)
+	Create(context.Context, *APIKeyPayload) (*APIKeySecretResponse, error)
+	Rotate(context.Context, *APIKeyRequestQuery) (*APIKeySecretResponse, error)
+	Revoke(context.Context, *APIKeyRequestQuery) error
+	Authenticate(context.Context, string) (*auth.Identity, error)
+}
+
+type apiKeyService struct {
+	repo APIKeyRepository
+}
+
+// NewService return an instance of APIKeyService containing available usecases
+func NewService(repo APIKeyRepository) APIKeyService {
+	return &apiKeyService{repo: repo}
+}
+
+// GetAll return keys owned by the logged in user, revoked and expired keys are only included on request
+func (svc *apiKeyService) GetAll(ctx context.Context, params *APIKeyRequestQuery) (res *ListAPIKeyResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	repoParams := &apiKeyQuery{
+		UserID: identity.UserID,
+		Active: !params.Revoked,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListAPIKeyResponse{
+		APIKeys: []*APIKeyResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	keys, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, key := range keys {
+		res.APIKeys = append(res.APIKeys, toAPIKeyResponse(key))
+	}
+
+	return
+}
+
+func (svc *apiKeyService) GetOne(ctx context.Context, params *APIKeyRequestQuery) (res *APIKeyResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	key, err := svc.repo.GetOne(ctx, &apiKeyQuery{ID: params.ID, UserID: identity.UserID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if key == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toAPIKeyResponse(key), nil
+}
+
+// Create issue a new key on behalf of the logged in user, the key is only returned once
+func (svc *apiKeyService) Create(ctx context.Context, payload *APIKeyPayload) (res *APIKeySecretResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	if payload.Name == "" || len(payload.Scopes) == 0 {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	for _, scope := range payload.Scopes {
+		if !slices.Contains(scopes, scope) {
+			return nil, errs.ErrBadRequest
+		}
+	}
+
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		return nil, errs.ErrBadRequest
+	}
+
+	secret, err := generateKey()
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	granted := slices.Clone(payload.Scopes)
+	slices.Sort(granted)
+
+	key := &APIKeyType{
+		UserID:  identity.UserID,
+		Name:    payload.Name,
+		Prefix:  keyPrefix(secret),
+		KeyHash: hashKey(secret),
+		Scopes:  slices.Compact(granted),
+	}
+
+	if payload.ExpiresAt != nil {
+		key.ExpiresAt = sql.NullTime{Time: *payload.ExpiresAt, Valid: true}
+	}
+
+	err = svc.repo.Store(ctx, key)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return &APIKeySecretResponse{APIKeyResponse: *toAPIKeyResponse(key), Key: secret}, nil
+}
+
+// Rotate replace the secret of an active key while keeping its scopes and expiry, the previous secret stops working immediately
+func (svc *apiKeyService) Rotate(ctx context.Context, params *APIKeyRequestQuery) (res *APIKeySecretResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	key, err := svc.repo.GetOne(ctx, &apiKeyQuery{ID: params.ID, UserID: identity.UserID, Active: true})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if key == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	secret, err := generateKey()
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	key.Prefix = keyPrefix(secret)
+	key.KeyHash = hashKey(secret)
+
+	err = svc.repo.UpdateKey(ctx, key)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return &APIKeySecretResponse{APIKeyResponse: *toAPIKeyResponse(key), Key: secret}, nil
+}
+
+// Revoke permanently disable a key of the logged in user
+func (svc *apiKeyService) Revoke(ctx context.Context, params *APIKeyRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return errs.ErrInvalidCred
+	}
+
+	err = svc.repo.Revoke(ctx, &apiKeyQuery{ID: params.ID, UserID: identity.UserID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// Authenticate verify a key, and return identity of its owner limited to the key scopes
+func (svc *apiKeyService) Authenticate(ctx context.Context, secret string) (res *auth.Identity, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !strings.HasPrefix(secret, KeyPrefix) {
+		return nil, errs.ErrInvalidCred
+	}
+
+	key, err := svc.repo.GetOne(ctx, &apiKeyQuery{KeyHash: hashKey(secret), Active: true})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if key == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	// failing to record usage shouldn't reject an otherwise valid key
+	if err := svc.repo.Touch(ctx, key.ID); err != nil {
+		logger.Warn().Err(err).Int64("api-key-id", key.ID).Msg("failed to record key usage")
+	}
+
+	return &auth.Identity{
+		UserID:         key.UserID,
+		Username:       key.Username,
+		Admin:          key.IsAdmin,
+		OrganizationID: key.OrganizationID,
+		APIKeyID:       key.ID,
+		Scopes:         key.Scopes,
+	}, nil
+}
+
+func toAPIKeyResponse(key *APIKeyType) *APIKeyResponse {
+	res := &APIKeyResponse{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+	}
+
+	if key.ExpiresAt.Valid {
+		res.ExpiresAt = &key.ExpiresAt.Time
+	}
+
+	if key.LastUsedAt.Valid {
+		res.LastUsedAt = &key.LastUsedAt.Time
+	}
+
+	if key.RevokedAt.Valid {
+		res.RevokedAt = &key.RevokedAt.Time
+	}
+
+	return res
+}
+
+// generateKey return a random url-safe 256 bit key prefixed by KeyPrefix
+func generateKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return KeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// keyPrefix return the leading part of a key, stored in plain so the owner can tell keys apart
+func keyPrefix(secret string) string {
+	return secret[:len(KeyPrefix)+8]
+}
+
+// hashKey return hex-encoded sha256 of the key, key has enough entropy thus no salt needed
+func hashKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,155 @@
+package apikeys
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+type stubAPIKeyRepository struct {
+	APIKeyRepository
+	key     *APIKeyType
+	stored  *APIKeyType
+	touched int64
+}
+
+func (repo *stubAPIKeyRepository) GetOne(_ context.Context, params *apiKeyQuery) (*APIKeyType, error) {
+	if repo.key == nil || (params.KeyHash != "" && params.KeyHash != repo.key.KeyHash) {
+		return nil, nil
+	}
+
+	if params.Active && (repo.key.RevokedAt.Valid || (repo.key.ExpiresAt.Valid && repo.key.ExpiresAt.Time.Before(time.Now()))) {
+		return nil, nil
+	}
+
+	return repo.key, nil
+}
+
+func (repo *stubAPIKeyRepository) Store(_ context.Context, payload *APIKeyType) error {
+	payload.ID = 3
+	repo.stored = payload
+	return nil
+}
+
+func (repo *stubAPIKeyRepository) UpdateKey(context.Context, *APIKeyType) error {
+	return nil
+}
+
+func (repo *stubAPIKeyRepository) Touch(_ context.Context, id int64) error {
+	repo.touched = id
+	return nil
+}
+
+func newIdentityContext() context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, Username: "budi"})
+}
+
+func TestShouldCreateHashedAPIKey(t *testing.T) {
+	repo := &stubAPIKeyRepository{}
+	svc := NewService(repo)
+
+	res, err := svc.Create(newIdentityContext(), &APIKeyPayload{
+		Name:   "ERP integration",
+		Scopes: []string{auth.ScopeWritePonds, auth.ScopeReadFarms, auth.ScopeReadFarms},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if !strings.HasPrefix(res.Key, KeyPrefix) || !strings.HasPrefix(res.Key, res.Prefix) {
+		t.Errorf("expected key %q to start with prefix %q", res.Key, res.Prefix)
+	}
+
+	if repo.stored.KeyHash == res.Key || repo.stored.KeyHash != hashKey(res.Key) {
+		t.Errorf("expected only hash of the key to be stored, got: %q", repo.stored.KeyHash)
+	}
+
+	if strings.Join(repo.stored.Scopes, ",") != "read:farms,write:ponds" {
+		t.Errorf("expected deduplicated scopes, got: %v", repo.stored.Scopes)
+	}
+}
+
+func TestShouldNOTCreateAPIKeyWithInvalidPayload(t *testing.T) {
+	svc := NewService(&stubAPIKeyRepository{})
+	past := time.Now().Add(-time.Hour)
+
+	for name, tc := range map[string]struct {
+		payload *APIKeyPayload
+		err     error
+	}{
+		"missing scopes": {&APIKeyPayload{Name: "gateway"}, errs.ErrMissingRequiredAttribute},
+		"unknown scope":  {&APIKeyPayload{Name: "gateway", Scopes: []string{"delete:farms"}}, errs.ErrBadRequest},
+		"past expiry":    {&APIKeyPayload{Name: "gateway", Scopes: []string{auth.ScopeIngestSensors}, ExpiresAt: &past}, errs.ErrBadRequest},
+	} {
+		if _, err := svc.Create(newIdentityContext(), tc.payload); err != tc.err {
+			t.Errorf("%s: expected %v, got: %v", name, tc.err, err)
+		}
+	}
+}
+
+func TestShouldAuthenticateAPIKeyWithinScopes(t *testing.T) {
+	repo := &stubAPIKeyRepository{}
+	svc := NewService(repo)
+
+	created, err := svc.Create(newIdentityContext(), &APIKeyPayload{Name: "gateway", Scopes: []string{auth.ScopeIngestSensors}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	repo.key = repo.stored
+	repo.key.Username = "budi"
+
+	identity, err := svc.Authenticate(context.Background(), created.Key)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if identity.UserID != 1 || identity.APIKeyID != 3 || len(identity.Scopes) != 1 || identity.Scopes[0] != auth.ScopeIngestSensors {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+
+	if repo.touched != 3 {
+		t.Errorf("expected key usage to be recorded, got: %d", repo.touched)
+	}
+}
+
+func TestShouldNOTAuthenticateRevokedOrExpiredAPIKey(t *testing.T) {
+	secret := KeyPrefix + "secret"
+
+	for name, key := range map[string]*APIKeyType{
+		"revoked": {ID: 3, KeyHash: hashKey(secret)},
+		"expired": {ID: 3, KeyHash: hashKey(secret)},
+	} {
+		if name == "revoked" {
+			key.RevokedAt.Time, key.RevokedAt.Valid = time.Now(), true
+		} else {
+			key.ExpiresAt.Time, key.ExpiresAt.Valid = time.Now().Add(-time.Minute), true
+		}
+
+		svc := NewService(&stubAPIKeyRepository{key: key})
+		if _, err := svc.Authenticate(context.Background(), secret); err != errs.ErrInvalidCred {
+			t.Errorf("%s: expected ErrInvalidCred, got: %v", name, err)
+		}
+	}
+}
+
+func TestShouldRotateAPIKeySecret(t *testing.T) {
+	repo := &stubAPIKeyRepository{key: &APIKeyType{ID: 3, UserID: 1, KeyHash: hashKey(KeyPrefix + "old")}}
+	svc := NewService(repo)
+
+	res, err := svc.Rotate(newIdentityContext(), &APIKeyRequestQuery{ID: 3})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if _, err := svc.Authenticate(context.Background(), KeyPrefix+"old"); err != errs.ErrInvalidCred {
+		t.Errorf("expected previous secret to be rejected, got: %v", err)
+	}
+
+	if _, err := svc.Authenticate(context.Background(), res.Key); err != nil {
+		t.Errorf("expected new secret to be accepted, got: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package audit
+
+import "github.com/labstack/echo/v4"
+
+type AuditController struct {
+	svc AuditService
+}
+
+func NewController(svc AuditService) *AuditController {
+	return &AuditController{
+		svc: svc,
+	}
+}
+
+const (
+	auditBasepath = "/audit"
+)
+
+func (ac *AuditController) Route(grp *echo.Group) {
+	subrouter := grp.Group(auditBasepath)
+
+	subrouter.GET("", HandleGetAllAudit(ac.svc.GetAll))
+	subrouter.OPTIONS("", HandleGetAllAudit(ac.svc.GetAll))
+
+	return
+}
@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// AuditRequestQuery represent query parameters fetch from request, entries are always limited into a single farm
+type AuditRequestQuery struct {
+	Entity   string `query:"entity" example:"farm" enums:"farm,pond"`
+	EntityID int64  `query:"id" example:"1"`
+	FarmID   int64  `query:"farm_id" example:"1"` // derived from entity and id when empty
+	Action   string `query:"action" example:"update" enums:"create,update,delete,restore"`
+	ActorID  int64  `query:"actor_id" example:"1"`
+	Limit    uint64 `query:"limit" example:"100"`
+	Page     uint64 `query:"page" example:"2"`
+}
+
+// RecordPayload represent a mutation to be recorded, Before is nil on creation while After is nil on deletion
+type RecordPayload struct {
+	FarmID   int64
+	Entity   string
+	EntityID int64
+	Action   string
+	Before   any
+	After    any
+}
+
+// AuditResponse represent domain response for Audit Log entity
+type AuditResponse struct {
+	ID        int64     `json:"id" example:"1"`
+	FarmID    int64     `json:"farm_id" example:"1"`
+	Entity    string    `json:"entity" example:"farm"`
+	EntityID  int64     `json:"entity_id" example:"1"`
+	Action    string    `json:"action" example:"update"`
+	ActorID   *int64    `json:"actor_id" example:"1"` // empty for change made by scheduled job
+	Actor     string    `json:"actor" example:"budi"`
+	APIKeyID  *int64    `json:"api_key_id"`
+	RequestID string    `json:"request_id" example:"0b8d5c6e-2f0a-4a55-9d8e-2f4c0b9a7e11"`
+	Changes   Changes   `json:"changes"`
+	CreatedAt time.Time `json:"created_at" example:"2024-08-22T01:20:30Z"`
+}
+
+// ListAuditResponse represent domain response for bulk Audit Log entities
+type ListAuditResponse struct {
+	Logs []*AuditResponse       `json:"logs"`
+	Meta httpres.ListPagination `json:"meta"`
+}
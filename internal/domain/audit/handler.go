@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllAuditHandler func(context.Context, *AuditRequestQuery) (*ListAuditResponse, error)
+
+// Get All Audit godoc
+//
+//	@Summary	get history of changes made on a farm or its ponds, newest first
+//	@Tags		Audit
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		entity		query		string	false	"only return entries of the entity type"	Enums(farm, pond)
+//	@Param		id			query		int		false	"only return entries of the entity, requires entity"
+//	@Param		farm_id		query		int		false	"farm of the entries, required when entity and id are empty"
+//	@Param		action		query		string	false	"only return entries of the action"	Enums(create, update, delete, restore)
+//	@Param		actor_id	query		int		false	"only return entries made by the user"
+//	@Param		limit		query		string	false	"number of entity per page"
+//	@Param		page		query		string	false	"n-th page"
+//	@Success	200			{object}	ListAuditResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"unknown entity or missing farm"
+//	@Failure	403			{object}	httpres.ErrorResponse	"not permitted reading audit of the farm"
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/audit [get]
+func HandleGetAllAudit(handler GetAllAuditHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &AuditRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// audited entities
+const (
+	EntityFarm = "farm"
+	EntityPond = "pond"
+)
+
+// audited actions
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+)
+
+var entities = []string{EntityFarm, EntityPond}
+
+type LogType struct {
+	ID             int64         `db:"id"`
+	OrganizationID int64         `db:"organization_id"`
+	FarmID         int64         `db:"farm_id"`
+	Entity         string        `db:"entity"`
+	EntityID       int64         `db:"entity_id"`
+	Action         string        `db:"action"`
+	ActorID        sql.NullInt64 `db:"actor_id"`
+	Actor          string        `db:"actor"`
+	APIKeyID       sql.NullInt64 `db:"api_key_id"`
+	RequestID      string        `db:"request_id"`
+	Changes        Changes       `db:"changes"`
+	CreatedAt      time.Time     `db:"created_at"`
+}
+
+// Change represent value of a field before and after a mutation, nil on the side where the entity doesn't exist
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Changes represent changed fields keyed by field name, stored as jsonb object
+type Changes map[string]Change
+
+// Value implements driver.Valuer
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(c)
+}
+
+// Scan implements sql.Scanner
+func (c *Changes) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = Changes{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	}
+
+	return errors.New("unsupported type for Changes")
+}
+
+// Diff compare JSON representation of before and after field by field, either may be nil for creation or deletion
+func Diff(before, after any) (res Changes, err error) {
+	prev, err := toFields(before)
+	if err != nil {
+		return
+	}
+
+	next, err := toFields(after)
+	if err != nil {
+		return
+	}
+
+	res = Changes{}
+
+	for field, value := range prev {
+		if !reflect.DeepEqual(value, next[field]) {
+			res[field] = Change{Before: value, After: next[field]}
+		}
+	}
+
+	for field, value := range next {
+		if _, ok := prev[field]; !ok && value != nil {
+			res[field] = Change{Before: nil, After: value}
+		}
+	}
+
+	return
+}
+
+// toFields return JSON object of v as a map, so values of either side are comparable
+func toFields(v any) (res map[string]any, err error) {
+	res = map[string]any{}
+
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(body, &res)
+	return
+}
@@ -0,0 +1,163 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// AuditRepository contain contract that defined all necessary public function available to be interact with.
+// Audit log is append-only, thus there's no way to alter a stored entry
+type AuditRepository interface {
+	GetAll(context.Context, *auditQuery) ([]*LogType, error)
+	Count(context.Context, *auditQuery) (uint64, error)
+	GetFarmID(context.Context, *auditQuery) (int64, error)
+	Store(context.Context, *LogType) error
+}
+
+type auditRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of auditRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+type auditQuery struct {
+	OrganizationID int64 // only entries of the tenant, when non-zero
+	FarmID         int64
+	Entity, Action string
+	EntityID       int64
+	ActorID        int64
+	Limit, Page    uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var logColumns = []string{
+	"id", "organization_id", "farm_id", "entity", "entity_id", "action", "actor_id", "actor", "api_key_id", "request_id", "changes", "created_at",
+}
+
+func (params *auditQuery) filter() squirrel.And {
+	cond := squirrel.And{}
+
+	if params.OrganizationID != 0 {
+		cond = append(cond, squirrel.Eq{"organization_id": params.OrganizationID})
+	}
+
+	if params.FarmID != 0 {
+		cond = append(cond, squirrel.Eq{"farm_id": params.FarmID})
+	}
+
+	if params.Entity != "" {
+		cond = append(cond, squirrel.Eq{"entity": params.Entity})
+	}
+
+	if params.EntityID != 0 {
+		cond = append(cond, squirrel.Eq{"entity_id": params.EntityID})
+	}
+
+	if params.Action != "" {
+		cond = append(cond, squirrel.Eq{"action": params.Action})
+	}
+
+	if params.ActorID != 0 {
+		cond = append(cond, squirrel.Eq{"actor_id": params.ActorID})
+	}
+
+	return cond
+}
+
+func (repo *auditRepository) GetAll(ctx context.Context, params *auditQuery) (res []*LogType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	// latest change goes first
+	stmt, args, _ := pgSquirrel.Select(logColumns...).From("audit_logs").
+		Where(params.filter()).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*LogType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &LogType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *auditRepository) Count(ctx context.Context, params *auditQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("audit_logs").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+// GetFarmID return farm of the latest entry matching params, zero when there's none
+func (repo *auditRepository) GetFarmID(ctx context.Context, params *auditQuery) (res int64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("farm_id").From("audit_logs").
+		Where(params.filter()).
+		OrderBy("id DESC").
+		Limit(1).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+// Store append an entry, tenant of the entry follows the farm regardless of the caller
+func (repo *auditRepository) Store(ctx context.Context, payload *LogType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Insert("audit_logs").
+		Columns("organization_id", "farm_id", "entity", "entity_id", "action", "actor_id", "actor", "api_key_id", "request_id", "changes").
+		Values(
+			squirrel.Expr("(select organization_id from farms where id = ?)", payload.FarmID),
+			payload.FarmID, payload.Entity, payload.EntityID, payload.Action, payload.ActorID, payload.Actor, payload.APIKeyID, payload.RequestID, payload.Changes,
+		).
+		Suffix("RETURNING id, organization_id, created_at").ToSql()
+
+	if err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID, &payload.OrganizationID, &payload.CreatedAt); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	return
+}
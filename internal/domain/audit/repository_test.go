@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestShouldStoreAuditUnderTenantOfTheFarm(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	auditRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO audit_logs (organization_id,farm_id,entity,entity_id,action,actor_id,actor,api_key_id,request_id,changes) VALUES ((select organization_id from farms where id = $1),$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING id, organization_id, created_at")).
+		WithArgs(2, 2, EntityFarm, 2, ActionDelete, 1, "budi", nil, "req-1", []byte(`{"name":{"before":"Farm A","after":null}}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "created_at"}).AddRow(1, 3, time.Now()))
+
+	payload := &LogType{
+		FarmID:    2,
+		Entity:    EntityFarm,
+		EntityID:  2,
+		Action:    ActionDelete,
+		ActorID:   sql.NullInt64{Int64: 1, Valid: true},
+		Actor:     "budi",
+		RequestID: "req-1",
+		Changes:   Changes{"name": {Before: "Farm A"}},
+	}
+	if err = auditRepo.Store(context.Background(), payload); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if payload.ID != 1 || payload.OrganizationID != 3 {
+		t.Errorf("unexpected stored entry: %+v", payload)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldGetAuditOfEntityNewestFirst(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	auditRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	rows := sqlmock.NewRows(logColumns)
+
+	// expected queries
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, organization_id, farm_id, entity, entity_id, action, actor_id, actor, api_key_id, request_id, changes, created_at FROM audit_logs WHERE (organization_id = $1 AND farm_id = $2 AND entity = $3 AND entity_id = $4) ORDER BY created_at DESC, id DESC LIMIT 100 OFFSET 0")).
+		WithArgs(1, 1, EntityPond, 3).
+		WillReturnRows(rows)
+
+	auditRepo.GetAll(context.Background(), &auditQuery{OrganizationID: 1, FarmID: 1, Entity: EntityPond, EntityID: 3, Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
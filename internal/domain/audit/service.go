@@ -0,0 +1,184 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/nmluci/da-farm-be/internal/domain/access"
+	"github.com/rs/zerolog"
+)
+
+// AuditService contains public API available to be interacted with
+type AuditService interface {
+	GetAll(context.Context, *AuditRequestQuery) (*ListAuditResponse, error)
+	Record(context.Context, *RecordPayload) error
+}
+
+type auditService struct {
+	repo      AuditRepository
+	accessSvc access.AccessService
+}
+
+// NewService return an instance of AuditService containing available usecases
+func NewService(repo AuditRepository, accessSvc access.AccessService) AuditService {
+	return &auditService{repo: repo, accessSvc: accessSvc}
+}
+
+// GetAll return history of a farm or its entities, caller needs to be permitted reading audit of the farm
+func (svc *auditService) GetAll(ctx context.Context, params *AuditRequestQuery) (res *ListAuditResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if params.Entity != "" && !slices.Contains(entities, params.Entity) {
+		return nil, errs.ErrBadRequest
+	}
+
+	repoParams := &auditQuery{
+		OrganizationID: auth.OrganizationFromContext(ctx),
+		FarmID:         params.FarmID,
+		Entity:         params.Entity,
+		EntityID:       params.EntityID,
+		Action:         params.Action,
+		ActorID:        params.ActorID,
+		Limit:          params.Limit,
+		Page:           params.Page,
+	}
+
+	// entity without explicit farm is looked up from its own history
+	if repoParams.FarmID == 0 && params.Entity == EntityFarm {
+		repoParams.FarmID = params.EntityID
+	} else if repoParams.FarmID == 0 && params.Entity != "" && params.EntityID != 0 {
+		repoParams.FarmID, err = svc.repo.GetFarmID(ctx, &auditQuery{
+			OrganizationID: repoParams.OrganizationID,
+			Entity:         params.Entity,
+			EntityID:       params.EntityID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Send()
+			return
+		}
+
+		if repoParams.FarmID == 0 {
+			return nil, errs.ErrNotFound
+		}
+	}
+
+	if repoParams.FarmID == 0 {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if err = svc.accessSvc.Authorize(ctx, repoParams.FarmID, auth.ActionReadAudit); err != nil {
+		return
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListAuditResponse{
+		Logs: []*AuditResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	logs, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, log := range logs {
+		res.Logs = append(res.Logs, toAuditResponse(log))
+	}
+
+	return
+}
+
+// Record append the mutation into audit trail along with the caller and request-id carried by ctx.
+// Update which doesn't change anything is skipped
+func (svc *auditService) Record(ctx context.Context, payload *RecordPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	changes, err := Diff(payload.Before, payload.After)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if payload.Action == ActionUpdate && len(changes) == 0 {
+		return
+	}
+
+	data := &LogType{
+		FarmID:    payload.FarmID,
+		Entity:    payload.Entity,
+		EntityID:  payload.EntityID,
+		Action:    payload.Action,
+		RequestID: httputil.RequestIDFromContext(ctx),
+		Changes:   changes,
+	}
+
+	if identity := auth.FromContext(ctx); identity != nil {
+		data.ActorID = sql.NullInt64{Int64: identity.UserID, Valid: true}
+		data.Actor = identity.Username
+		data.APIKeyID = sql.NullInt64{Int64: identity.APIKeyID, Valid: identity.APIKeyID != 0}
+	}
+
+	err = svc.repo.Store(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func toAuditResponse(log *LogType) *AuditResponse {
+	res := &AuditResponse{
+		ID:        log.ID,
+		FarmID:    log.FarmID,
+		Entity:    log.Entity,
+		EntityID:  log.EntityID,
+		Action:    log.Action,
+		Actor:     log.Actor,
+		RequestID: log.RequestID,
+		Changes:   log.Changes,
+		CreatedAt: log.CreatedAt,
+	}
+
+	if log.ActorID.Valid {
+		res.ActorID = &log.ActorID.Int64
+	}
+
+	if log.APIKeyID.Valid {
+		res.APIKeyID = &log.APIKeyID.Int64
+	}
+
+	if res.Changes == nil {
+		res.Changes = Changes{}
+	}
+
+	return res
+}
@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/nmluci/da-farm-be/internal/domain/access"
+)
+
+type stubAuditRepository struct {
+	AuditRepository
+	farmID int64
+	stored []*LogType
+}
+
+func (repo *stubAuditRepository) GetFarmID(context.Context, *auditQuery) (int64, error) {
+	return repo.farmID, nil
+}
+
+func (repo *stubAuditRepository) Count(context.Context, *auditQuery) (uint64, error) {
+	return uint64(len(repo.stored)), nil
+}
+
+func (repo *stubAuditRepository) GetAll(context.Context, *auditQuery) ([]*LogType, error) {
+	return repo.stored, nil
+}
+
+func (repo *stubAuditRepository) Store(_ context.Context, payload *LogType) error {
+	repo.stored = append(repo.stored, payload)
+	return nil
+}
+
+type stubAccessService struct {
+	access.AccessService
+	farmID int64
+	err    error
+}
+
+func (svc *stubAccessService) Authorize(_ context.Context, farmID int64, _ string) error {
+	svc.farmID = farmID
+	return svc.err
+}
+
+type farm struct {
+	Name string  `json:"name"`
+	Area float64 `json:"area"`
+}
+
+func TestShouldRecordOnlyChangedFields(t *testing.T) {
+	repo := &stubAuditRepository{}
+	svc := NewService(repo, &stubAccessService{})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, Username: "budi", APIKeyID: 4})
+	ctx = httputil.WithRequestID(ctx, "req-1")
+
+	err := svc.Record(ctx, &RecordPayload{FarmID: 1, Entity: EntityFarm, EntityID: 1, Action: ActionUpdate, Before: &farm{Name: "Farm A", Area: 10}, After: &farm{Name: "Farm B", Area: 10}})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(repo.stored) != 1 {
+		t.Fatalf("expected an entry, got: %d", len(repo.stored))
+	}
+
+	log := repo.stored[0]
+	if log.Actor != "budi" || log.ActorID.Int64 != 1 || !log.APIKeyID.Valid || log.RequestID != "req-1" {
+		t.Errorf("unexpected caller of entry: %+v", log)
+	}
+
+	if len(log.Changes) != 1 || log.Changes["name"].Before != "Farm A" || log.Changes["name"].After != "Farm B" {
+		t.Errorf("unexpected changes: %+v", log.Changes)
+	}
+}
+
+func TestShouldNOTRecordUpdateWithoutChanges(t *testing.T) {
+	repo := &stubAuditRepository{}
+	svc := NewService(repo, &stubAccessService{})
+
+	err := svc.Record(context.Background(), &RecordPayload{FarmID: 1, Entity: EntityFarm, EntityID: 1, Action: ActionUpdate, Before: &farm{Name: "Farm A"}, After: &farm{Name: "Farm A"}})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(repo.stored) != 0 {
+		t.Errorf("expected no entry, got: %+v", repo.stored)
+	}
+}
+
+func TestShouldAuthorizeAuditOfPondAgainstItsFarm(t *testing.T) {
+	accessSvc := &stubAccessService{err: errs.ErrNoAccess}
+	svc := NewService(&stubAuditRepository{farmID: 2}, accessSvc)
+
+	if _, err := svc.GetAll(context.Background(), &AuditRequestQuery{Entity: EntityPond, EntityID: 3}); err != errs.ErrNoAccess {
+		t.Errorf("expected no access, got: %v", err)
+	}
+
+	if accessSvc.farmID != 2 {
+		t.Errorf("expected farm of the pond to be authorized, got: %d", accessSvc.farmID)
+	}
+}
+
+func TestShouldNOTGetAuditWithoutFarm(t *testing.T) {
+	svc := NewService(&stubAuditRepository{}, &stubAccessService{})
+
+	if _, err := svc.GetAll(context.Background(), &AuditRequestQuery{Action: ActionDelete}); err != errs.ErrMissingRequiredAttribute {
+		t.Errorf("expected missing attribute, got: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package checklists
+
+import "github.com/labstack/echo/v4"
+
+type ChecklistController struct {
+	svc ChecklistService
+}
+
+func NewController(svc ChecklistService) *ChecklistController {
+	return &ChecklistController{
+		svc: svc,
+	}
+}
+
+const (
+	checklistBasepath = "/farms/:farmID"
+	templatePath      = "/checklist-templates"
+	templateIDPath    = "/checklist-templates/:templateID"
+	taskPath          = "/checklist-tasks"
+	taskCompletePath  = "/checklist-tasks/:taskID/complete"
+	reportPath        = "/checklist-report"
+)
+
+func (cc *ChecklistController) Route(grp *echo.Group) {
+	subrouter := grp.Group(checklistBasepath)
+
+	subrouter.GET(templatePath, HandleGetAllTemplate(cc.svc.GetAllTemplate))
+	subrouter.OPTIONS(templatePath, HandleGetAllTemplate(cc.svc.GetAllTemplate))
+	subrouter.GET(templateIDPath, HandleGetOneTemplate(cc.svc.GetOneTemplate))
+	subrouter.OPTIONS(templateIDPath, HandleGetOneTemplate(cc.svc.GetOneTemplate))
+	subrouter.POST(templatePath, HandleCreateTemplate(cc.svc.CreateTemplate))
+	subrouter.OPTIONS(templatePath, HandleCreateTemplate(cc.svc.CreateTemplate))
+	subrouter.PUT(templateIDPath, HandleUpdateTemplate(cc.svc.UpdateTemplate))
+	subrouter.OPTIONS(templateIDPath, HandleUpdateTemplate(cc.svc.UpdateTemplate))
+	subrouter.DELETE(templateIDPath, HandleDeleteTemplate(cc.svc.DeleteTemplate))
+	subrouter.OPTIONS(templateIDPath, HandleDeleteTemplate(cc.svc.DeleteTemplate))
+	subrouter.GET(taskPath, HandleGetAllTask(cc.svc.GetAllTask))
+	subrouter.OPTIONS(taskPath, HandleGetAllTask(cc.svc.GetAllTask))
+	subrouter.PUT(taskCompletePath, HandleCompleteTask(cc.svc.CompleteTask))
+	subrouter.OPTIONS(taskCompletePath, HandleCompleteTask(cc.svc.CompleteTask))
+	subrouter.GET(reportPath, HandleGetReport(cc.svc.GetReport))
+	subrouter.OPTIONS(reportPath, HandleGetReport(cc.svc.GetReport))
+
+	return
+}
@@ -0,0 +1,121 @@
+package checklists
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// TemplateRequestQuery represent query parameters fetch from request
+type TemplateRequestQuery struct {
+	ID     int64  `param:"templateID" example:"1"`
+	FarmID int64  `param:"farmID" example:"1"`
+	PondID int64  `query:"pond_id" example:"1"`
+	Limit  uint64 `query:"limit" example:"100"`
+	Page   uint64 `query:"page" example:"2"`
+}
+
+// TemplatePayload represent payload fetch from request body
+type TemplatePayload struct {
+	ID          int64  `param:"templateID" json:"-" example:"1"`
+	FarmID      int64  `param:"farmID" json:"-" example:"1"`
+	PondID      int64  `json:"pond_id" example:"1"` // leave empty for farm-wide task
+	Name        string `json:"name" example:"Morning DO check"`
+	Description string `json:"description" example:"Measure DO near the aerator and at the far corner"`
+	DueTime     string `json:"due_time" example:"06:00"`                // HH:MM in farm's timezone
+	ReadingType string `json:"reading_type" example:"dissolved_oxygen"` // parameter measured on completion
+	Active      *bool  `json:"active" example:"true"`                   // default to true
+}
+
+// TemplateResponse represent domain response for Checklist Template entity
+type TemplateResponse struct {
+	ID          int64  `json:"id" example:"1"`
+	FarmID      int64  `json:"farm_id" example:"1"`
+	PondID      *int64 `json:"pond_id" example:"1"`
+	Name        string `json:"name" example:"Morning DO check"`
+	Description string `json:"description" example:"Measure DO near the aerator and at the far corner"`
+	DueTime     string `json:"due_time" example:"06:00"`
+	ReadingType string `json:"reading_type" example:"dissolved_oxygen"`
+	Active      bool   `json:"active" example:"true"`
+}
+
+// ListTemplateResponse represent domain response for bulk Checklist Template entities
+type ListTemplateResponse struct {
+	Templates []*TemplateResponse    `json:"templates"`
+	Meta      httpres.ListPagination `json:"meta"`
+}
+
+// TaskRequestQuery represent query parameters fetch from request
+type TaskRequestQuery struct {
+	ID          int64  `param:"taskID" example:"1"`
+	FarmID      int64  `param:"farmID" example:"1"`
+	Date        string `query:"date" example:"2024-08-13"` // default to today in farm's timezone
+	PondID      int64  `query:"pond_id" example:"1"`
+	Status      string `query:"status" example:"pending"`
+	CompletedBy string `query:"completed_by" example:"budi"`
+	Limit       uint64 `query:"limit" example:"100"`
+	Page        uint64 `query:"page" example:"2"`
+}
+
+// CompleteTaskPayload represent completion of a task by a worker
+type CompleteTaskPayload struct {
+	ID          int64    `param:"taskID" json:"-" example:"1"`
+	FarmID      int64    `param:"farmID" json:"-" example:"1"`
+	Status      string   `json:"status" example:"done" enums:"done,skipped"` // default to done
+	CompletedBy string   `json:"completed_by" example:"budi"`
+	Note        string   `json:"note" example:"DO low near inlet, aerator 2 turned on"`
+	Reading     *float64 `json:"reading" example:"4.2"`
+}
+
+// TaskResponse represent domain response for Checklist Task entity
+type TaskResponse struct {
+	ID          int64      `json:"id" example:"1"`
+	TemplateID  int64      `json:"template_id" example:"1"`
+	PondID      *int64     `json:"pond_id" example:"1"`
+	Name        string     `json:"name" example:"Morning DO check"`
+	ReadingType string     `json:"reading_type" example:"dissolved_oxygen"`
+	Date        string     `json:"date" example:"2024-08-13"`
+	DueAt       time.Time  `json:"due_at" example:"2024-08-12T22:00:00Z"`
+	Status      string     `json:"status" example:"done"`
+	CompletedBy string     `json:"completed_by" example:"budi"`
+	CompletedAt *time.Time `json:"completed_at" example:"2024-08-12T21:55:00Z"`
+	Note        string     `json:"note" example:"DO low near inlet, aerator 2 turned on"`
+	Reading     *float64   `json:"reading" example:"4.2"`
+}
+
+// ListTaskResponse represent domain response for bulk Checklist Task entities
+type ListTaskResponse struct {
+	Tasks []*TaskResponse        `json:"tasks"`
+	Meta  httpres.ListPagination `json:"meta"`
+}
+
+// ReportRequestQuery represent query parameters fetch from request
+type ReportRequestQuery struct {
+	FarmID   int64  `param:"farmID" example:"1"`
+	DateFrom string `query:"date_from" example:"2024-08-01"` // default to today in farm's timezone
+	DateTo   string `query:"date_to" example:"2024-08-13"`   // inclusive, default to date_from
+}
+
+// WorkerReportResponse represent tasks handled by a worker within a day
+type WorkerReportResponse struct {
+	Worker  string `json:"worker" example:"budi"`
+	Done    int64  `json:"done" example:"5"`
+	Late    int64  `json:"late" example:"1"` // done after its due time
+	Skipped int64  `json:"skipped" example:"0"`
+}
+
+// DailyReportResponse represent task completion of a farm within a day
+type DailyReportResponse struct {
+	Date    string                  `json:"date" example:"2024-08-13"`
+	Total   int64                   `json:"total" example:"8"`
+	Done    int64                   `json:"done" example:"6"`
+	Skipped int64                   `json:"skipped" example:"1"`
+	Pending int64                   `json:"pending" example:"1"`
+	Workers []*WorkerReportResponse `json:"workers"`
+}
+
+// ReportResponse represent domain response for task completion report
+type ReportResponse struct {
+	FarmID int64                  `json:"farm_id" example:"1"`
+	Days   []*DailyReportResponse `json:"days"`
+}
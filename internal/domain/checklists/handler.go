@@ -0,0 +1,304 @@
+package checklists
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllTemplateHandler func(context.Context, *TemplateRequestQuery) (*ListTemplateResponse, error)
+
+// Get All Template godoc
+//
+//	@Summary	get daily checklist templates of a farm ordered by due time
+//	@Tags		Checklist
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pond_id	query		int		false	"only return template of the pond"
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListTemplateResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/checklist-templates [get]
+func HandleGetAllTemplate(handler GetAllTemplateHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &TemplateRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneTemplateHandler func(context.Context, *TemplateRequestQuery) (*TemplateResponse, error)
+
+// Get One Template godoc
+//
+//	@Summary	get specific checklist template by ID
+//	@Tags		Checklist
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		templateID	path		int	true	"Template ID"
+//	@Success	200				{object}	TemplateResponse
+//	@Failure	404				{object}	httpres.ErrorResponse
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/checklist-templates/{templateID} [get]
+func HandleGetOneTemplate(handler GetOneTemplateHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &TemplateRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateTemplateHandler func(context.Context, *TemplatePayload) error
+
+// CreateTemplate godoc
+//
+//	@Summary	create a daily checklist template
+//	@Tags		Checklist
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int				true	"Farm ID"
+//	@Param		payload	body		TemplatePayload	true	"template payload"
+//	@Success	201		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid due time"
+//	@Failure	404		{object}	httpres.ErrorResponse	"farm or pond not existed"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/checklist-templates [post]
+func HandleCreateTemplate(handler CreateTemplateHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &TemplatePayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateTemplateHandler func(context.Context, *TemplatePayload) error
+
+// Update Template godoc
+//
+//	@Summary	update checklist template, already generated tasks are left as is
+//	@Tags		Checklist
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int				true	"Farm ID"
+//	@Param		templateID	path		int				true	"Template ID"
+//	@Param		payload		body		TemplatePayload	true	"template payload"
+//	@Success	200				{object}	string
+//	@Failure	400				{object}	httpres.ErrorResponse	"invalid due time"
+//	@Failure	404				{object}	httpres.ErrorResponse	"template or pond not existed"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/checklist-templates/{templateID} [put]
+func HandleUpdateTemplate(handler UpdateTemplateHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &TemplatePayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteTemplateHandler func(context.Context, *TemplateRequestQuery) error
+
+// DeleteTemplate godoc
+//
+//	@Summary	delete specific checklist template by ID
+//	@Tags		Checklist
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		templateID	path		int	true	"Template ID"
+//	@Success	200				{object}	string
+//	@Failure	404				{object}	httpres.ErrorResponse	"template not existed"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/checklist-templates/{templateID} [delete]
+func HandleDeleteTemplate(handler DeleteTemplateHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &TemplateRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetAllTaskHandler func(context.Context, *TaskRequestQuery) (*ListTaskResponse, error)
+
+// Get All Task godoc
+//
+//	@Summary	get checklist tasks of a day ordered by due time
+//	@Tags		Checklist
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID			path		int		true	"Farm ID"
+//	@Param		date			query		string	false	"day in farm's timezone formatted as YYYY-MM-DD, default to today"
+//	@Param		pond_id			query		int		false	"only return task of the pond"
+//	@Param		status			query		string	false	"only return task with the status"
+//	@Param		completed_by	query		string	false	"only return task completed by the worker"
+//	@Param		limit			query		string	false	"number of entity per page"
+//	@Param		page			query		string	false	"n-th page"
+//	@Success	200				{object}	ListTaskResponse
+//	@Failure	400				{object}	httpres.ErrorResponse	"invalid date"
+//	@Failure	404				{object}	httpres.ErrorResponse
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/checklist-tasks [get]
+func HandleGetAllTask(handler GetAllTaskHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &TaskRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CompleteTaskHandler func(context.Context, *CompleteTaskPayload) error
+
+// Complete Task godoc
+//
+//	@Summary	mark a pending task done or skipped, task with reading type requires the reading
+//	@Tags		Checklist
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int					true	"Farm ID"
+//	@Param		taskID	path		int					true	"Task ID"
+//	@Param		payload	body		CompleteTaskPayload	true	"completion payload"
+//	@Success	200		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"missing worker or reading"
+//	@Failure	404		{object}	httpres.ErrorResponse	"task not existed"
+//	@Failure	409		{object}	httpres.ErrorResponse	"task already handled"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/checklist-tasks/{taskID}/complete [put]
+func HandleCompleteTask(handler CompleteTaskHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &CompleteTaskPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetReportHandler func(context.Context, *ReportRequestQuery) (*ReportResponse, error)
+
+// Get Report godoc
+//
+//	@Summary	get checklist completion per worker per day
+//	@Tags		Checklist
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		date_from	query		string	false	"first day formatted as YYYY-MM-DD, default to today"
+//	@Param		date_to		query		string	false	"last day (inclusive) formatted as YYYY-MM-DD, default to date_from, at most 31 days"
+//	@Success	200			{object}	ReportResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"invalid date range"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/checklist-report [get]
+func HandleGetReport(handler GetReportHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &ReportRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
@@ -0,0 +1,60 @@
+package checklists
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	StatusPending = "pending"
+	StatusDone    = "done"
+	StatusSkipped = "skipped"
+)
+
+// DateLayout is the format of a day within farm's timezone
+const DateLayout = "2006-01-02"
+
+// DueTimeLayout is the format of template's due time within farm's timezone
+const DueTimeLayout = "15:04"
+
+// MaxReportDays limit number of days within a single completion report
+const MaxReportDays = 31
+
+type TemplateType struct {
+	ID          int64         `db:"id"`
+	FarmID      int64         `db:"farm_id"`
+	PondID      sql.NullInt64 `db:"pond_id"`
+	Name        string        `db:"name"`
+	Description string        `db:"description"`
+	DueTime     string        `db:"due_time"`
+	ReadingType string        `db:"reading_type"`
+	Active      bool          `db:"active"`
+}
+
+// TaskType represent a template instantiated for a single day
+type TaskType struct {
+	ID          int64           `db:"id"`
+	TemplateID  int64           `db:"template_id"`
+	FarmID      int64           `db:"farm_id"`
+	PondID      sql.NullInt64   `db:"pond_id"`
+	Name        string          `db:"name"`
+	ReadingType string          `db:"reading_type"`
+	TaskDate    time.Time       `db:"task_date"`
+	DueAt       time.Time       `db:"due_at"`
+	Status      string          `db:"status"`
+	CompletedBy string          `db:"completed_by"`
+	CompletedAt sql.NullTime    `db:"completed_at"`
+	Note        string          `db:"note"`
+	Reading     sql.NullFloat64 `db:"reading"`
+}
+
+// WorkerReportType represent number of tasks handled by a worker within a day, unhandled tasks are grouped under
+// empty worker
+type WorkerReportType struct {
+	TaskDate time.Time `db:"task_date"`
+	Worker   string    `db:"completed_by"`
+	Done     int64     `db:"done"`
+	Late     int64     `db:"late"` // done after its due time
+	Skipped  int64     `db:"skipped"`
+	Pending  int64     `db:"pending"`
+}
@@ -0,0 +1,504 @@
+package checklists
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// ChecklistRepository contain contract that defined all necessary public function available to be interact with
+type ChecklistRepository interface {
+	GetAllTemplate(context.Context, *templateQuery) ([]*TemplateType, error)
+	CountTemplate(context.Context, *templateQuery) (uint64, error)
+	GetOneTemplate(context.Context, *templateQuery) (*TemplateType, error)
+	StoreTemplate(context.Context, *TemplateType) error
+	UpdateTemplate(context.Context, *TemplateType) error
+	DeleteTemplate(context.Context, *templateQuery) error
+	Generate(context.Context, *generateQuery) (int64, error)
+	GetAllTask(context.Context, *taskQuery) ([]*TaskType, error)
+	CountTask(context.Context, *taskQuery) (uint64, error)
+	GetOneTask(context.Context, *taskQuery) (*TaskType, error)
+	CompleteTask(context.Context, *TaskType) error
+	GetReport(context.Context, *reportQuery) ([]*WorkerReportType, error)
+}
+
+type checklistRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of checklistRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) ChecklistRepository {
+	return &checklistRepository{db: db}
+}
+
+type templateQuery struct {
+	ID, FarmID, PondID int64
+	Limit, Page        uint64
+}
+
+// generateQuery select templates to be instantiated, zero FarmID means every farm and zero Date means today
+// within each farm's timezone
+type generateQuery struct {
+	FarmID int64
+	Date   time.Time
+}
+
+type taskQuery struct {
+	ID, FarmID, PondID  int64
+	Date                time.Time
+	Status, CompletedBy string
+	Limit, Page         uint64
+}
+
+type reportQuery struct {
+	FarmID           int64
+	DateFrom, DateTo time.Time
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var templateColumns = []string{"t.id", "t.farm_id", "t.pond_id", "t.name", "t.description", "t.due_time", "t.reading_type", "t.active"}
+
+var taskColumns = []string{
+	"k.id", "k.template_id", "k.farm_id", "k.pond_id", "k.name", "k.reading_type", "k.task_date", "k.due_at", "k.status",
+	"k.completed_by", "k.completed_at", "k.note", "k.reading",
+}
+
+func (params *templateQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"t.farm_id": params.FarmID},
+		squirrel.Eq{"t.deleted_at": nil},
+	}
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"t.id": params.ID})
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"t.pond_id": params.PondID})
+	}
+
+	return cond
+}
+
+func (params *taskQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"k.farm_id": params.FarmID},
+	}
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"k.id": params.ID})
+	}
+
+	if !params.Date.IsZero() {
+		cond = append(cond, squirrel.Eq{"k.task_date": params.Date.Format(DateLayout)})
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"k.pond_id": params.PondID})
+	}
+
+	if params.Status != "" {
+		cond = append(cond, squirrel.Eq{"k.status": params.Status})
+	}
+
+	if params.CompletedBy != "" {
+		cond = append(cond, squirrel.Eq{"k.completed_by": params.CompletedBy})
+	}
+
+	return cond
+}
+
+func (repo *checklistRepository) GetAllTemplate(ctx context.Context, params *templateQuery) (res []*TemplateType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(templateColumns...).From("checklist_templates t").
+		Where(params.filter()).
+		OrderBy("t.due_time", "t.id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*TemplateType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &TemplateType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *checklistRepository) CountTemplate(ctx context.Context, params *templateQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("checklist_templates t").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *checklistRepository) GetOneTemplate(ctx context.Context, params *templateQuery) (res *TemplateType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(templateColumns...).From("checklist_templates t").
+		Where(params.filter()).ToSql()
+
+	res = &TemplateType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure the farm exists and the pond, if any, belongs to it
+func (repo *checklistRepository) validate(ctx context.Context, tx *sqlx.Tx, payload *TemplateType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var count int64
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
+		squirrel.Eq{"id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if payload.PondID.Valid {
+		stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+			squirrel.Eq{"id": payload.PondID.Int64},
+			squirrel.Eq{"farm_id": payload.FarmID},
+			squirrel.Eq{"deleted_at": nil},
+		}).ToSql()
+	}
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate farm data existence")
+		return
+	}
+
+	// if selected farm or pond doesn't exists, bail out from here
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+// StoreTemplate save a new template, the generated ID will be assigned back into payload
+func (repo *checklistRepository) StoreTemplate(ctx context.Context, payload *TemplateType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("checklist_templates").
+		Columns("farm_id", "pond_id", "name", "description", "due_time", "reading_type", "active").
+		Values(payload.FarmID, payload.PondID, payload.Name, payload.Description, payload.DueTime, payload.ReadingType, payload.Active).
+		Suffix("RETURNING id").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// UpdateTemplate change a template, tasks already generated from it are left as is
+func (repo *checklistRepository) UpdateTemplate(ctx context.Context, payload *TemplateType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("checklist_templates").SetMap(map[string]interface{}{
+		"pond_id":      payload.PondID,
+		"name":         payload.Name,
+		"description":  payload.Description,
+		"due_time":     payload.DueTime,
+		"reading_type": payload.ReadingType,
+		"active":       payload.Active,
+		"updated_at":   squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *checklistRepository) DeleteTemplate(ctx context.Context, params *templateQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("checklist_templates").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("template doesn't exists")
+		return
+	}
+
+	return
+}
+
+// Generate instantiate every active template into a task of the day, due time is interpreted in farm's timezone.
+// Task already generated for the day is skipped, return number of newly generated tasks
+func (repo *checklistRepository) Generate(ctx context.Context, params *generateQuery) (res int64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	day := squirrel.Expr("(now() at time zone f.timezone)::date")
+	if !params.Date.IsZero() {
+		day = squirrel.Expr("?::date", params.Date.Format(DateLayout))
+	}
+
+	cond := squirrel.And{
+		squirrel.Eq{"t.active": true},
+		squirrel.Eq{"t.deleted_at": nil},
+		squirrel.Eq{"f.deleted_at": nil},
+	}
+
+	if params.FarmID != 0 {
+		cond = append(cond, squirrel.Eq{"t.farm_id": params.FarmID})
+	}
+
+	dayStmt, dayArgs, _ := day.ToSql()
+
+	query := pgSquirrel.Select("t.id", "t.farm_id", "t.pond_id", "t.name", "t.reading_type").
+		Column(squirrel.Expr(dayStmt, dayArgs...)).
+		Column(squirrel.Expr("("+dayStmt+" + t.due_time::time) at time zone f.timezone", dayArgs...)).
+		From("checklist_templates t").
+		Join("farms f on t.farm_id = f.id").
+		Where(cond)
+
+	stmt, args, _ := pgSquirrel.Insert("checklist_tasks").
+		Columns("template_id", "farm_id", "pond_id", "name", "reading_type", "task_date", "due_at").
+		Select(query).
+		Suffix("ON CONFLICT (template_id, task_date) DO NOTHING").ToSql()
+
+	result, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+	res, _ = result.RowsAffected()
+
+	return
+}
+
+func (repo *checklistRepository) GetAllTask(ctx context.Context, params *taskQuery) (res []*TaskType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(taskColumns...).From("checklist_tasks k").
+		Where(params.filter()).
+		OrderBy("k.due_at", "k.id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*TaskType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &TaskType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *checklistRepository) CountTask(ctx context.Context, params *taskQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("checklist_tasks k").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *checklistRepository) GetOneTask(ctx context.Context, params *taskQuery) (res *TaskType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(taskColumns...).From("checklist_tasks k").
+		Where(params.filter()).ToSql()
+
+	res = &TaskType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// CompleteTask save completion of a pending task, task already handled can't be completed again
+func (repo *checklistRepository) CompleteTask(ctx context.Context, payload *TaskType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("checklist_tasks").SetMap(map[string]interface{}{
+		"status":       payload.Status,
+		"completed_by": payload.CompletedBy,
+		"completed_at": payload.CompletedAt,
+		"note":         payload.Note,
+		"reading":      payload.Reading,
+		"updated_at":   squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"status": StatusPending},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrInvalidStateTransition
+	}
+
+	return
+}
+
+// GetReport return number of tasks handled per worker per day, ordered by day
+func (repo *checklistRepository) GetReport(ctx context.Context, params *reportQuery) (res []*WorkerReportType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(
+		"k.task_date", "k.completed_by",
+		"count(*) filter (where k.status = 'done') done",
+		"count(*) filter (where k.status = 'done' and k.completed_at > k.due_at) late",
+		"count(*) filter (where k.status = 'skipped') skipped",
+		"count(*) filter (where k.status = 'pending') pending",
+	).From("checklist_tasks k").
+		Where(squirrel.And{
+			squirrel.Eq{"k.farm_id": params.FarmID},
+			squirrel.GtOrEq{"k.task_date": params.DateFrom.Format(DateLayout)},
+			squirrel.LtOrEq{"k.task_date": params.DateTo.Format(DateLayout)},
+		}).
+		GroupBy("k.task_date", "k.completed_by").
+		OrderBy("k.task_date", "k.completed_by").ToSql()
+
+	res = []*WorkerReportType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &WorkerReportType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
@@ -0,0 +1,59 @@
+package checklists
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldGenerateTaskOfTheDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	checklistRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO checklist_tasks (template_id,farm_id,pond_id,name,reading_type,task_date,due_at) SELECT t.id, t.farm_id, t.pond_id, t.name, t.reading_type, $1::date, ($2::date + t.due_time::time) at time zone f.timezone FROM checklist_templates t JOIN farms f on t.farm_id = f.id WHERE (t.active = $3 AND t.deleted_at IS NULL AND f.deleted_at IS NULL AND t.farm_id = $4) ON CONFLICT (template_id, task_date) DO NOTHING")).
+		WithArgs("2024-08-13", "2024-08-13", true, 1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	count, err := checklistRepo.Generate(context.Background(), &generateQuery{FarmID: 1, Date: time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC)})
+	if err != nil || count != 3 {
+		t.Errorf("unexpected count: %d, err: %v", count, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTCompleteHandledTask(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	checklistRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE checklist_tasks SET")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = checklistRepo.CompleteTask(context.Background(), &TaskType{ID: 1, FarmID: 1, Status: StatusDone, CompletedBy: "budi"})
+	if err != errs.ErrInvalidStateTransition {
+		t.Errorf("expected ErrInvalidStateTransition, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
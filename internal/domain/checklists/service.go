@@ -0,0 +1,443 @@
+package checklists
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/domain/farms"
+	"github.com/rs/zerolog"
+)
+
+// ChecklistService contains public API available to be interacted with
+type ChecklistService interface {
+	GetAllTemplate(context.Context, *TemplateRequestQuery) (*ListTemplateResponse, error)
+	GetOneTemplate(context.Context, *TemplateRequestQuery) (*TemplateResponse, error)
+	CreateTemplate(context.Context, *TemplatePayload) error
+	UpdateTemplate(context.Context, *TemplatePayload) error
+	DeleteTemplate(context.Context, *TemplateRequestQuery) error
+	GetAllTask(context.Context, *TaskRequestQuery) (*ListTaskResponse, error)
+	CompleteTask(context.Context, *CompleteTaskPayload) error
+	GetReport(context.Context, *ReportRequestQuery) (*ReportResponse, error)
+	Generate(context.Context) error
+}
+
+type checklistService struct {
+	repo    ChecklistRepository
+	farmSvc farms.FarmService
+}
+
+// NewService return an instance of ChecklistService containing available usecases
+func NewService(repo ChecklistRepository, farmSvc farms.FarmService) ChecklistService {
+	return &checklistService{repo: repo, farmSvc: farmSvc}
+}
+
+func (svc *checklistService) GetAllTemplate(ctx context.Context, params *TemplateRequestQuery) (res *ListTemplateResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &templateQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListTemplateResponse{
+		Templates: []*TemplateResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.CountTemplate(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	templates, err := svc.repo.GetAllTemplate(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, template := range templates {
+		res.Templates = append(res.Templates, toTemplateResponse(template))
+	}
+
+	return
+}
+
+func (svc *checklistService) GetOneTemplate(ctx context.Context, params *TemplateRequestQuery) (res *TemplateResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	template, err := svc.repo.GetOneTemplate(ctx, &templateQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if template == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toTemplateResponse(template), nil
+}
+
+func (svc *checklistService) CreateTemplate(ctx context.Context, payload *TemplatePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toTemplateType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.StoreTemplate(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *checklistService) UpdateTemplate(ctx context.Context, payload *TemplatePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toTemplateType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.UpdateTemplate(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *checklistService) DeleteTemplate(ctx context.Context, params *TemplateRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	err = svc.repo.DeleteTemplate(ctx, &templateQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// GetAllTask return tasks of a day, today's tasks are generated on demand so newly added templates show up right away
+func (svc *checklistService) GetAllTask(ctx context.Context, params *TaskRequestQuery) (res *ListTaskResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	today, err := svc.today(ctx, params.FarmID)
+	if err != nil {
+		return
+	}
+
+	date := today
+	if params.Date != "" {
+		if date, err = time.Parse(DateLayout, params.Date); err != nil {
+			return nil, errs.ErrBadRequest
+		}
+	}
+
+	repoParams := &taskQuery{
+		FarmID:      params.FarmID,
+		PondID:      params.PondID,
+		Date:        date,
+		Status:      params.Status,
+		CompletedBy: params.CompletedBy,
+		Limit:       params.Limit,
+		Page:        params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if date.Equal(today) {
+		if _, err = svc.repo.Generate(ctx, &generateQuery{FarmID: params.FarmID, Date: today}); err != nil {
+			logger.Error().Err(err).Send()
+			return
+		}
+	}
+
+	res = &ListTaskResponse{
+		Tasks: []*TaskResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.CountTask(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	tasks, err := svc.repo.GetAllTask(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, task := range tasks {
+		res.Tasks = append(res.Tasks, toTaskResponse(task))
+	}
+
+	return
+}
+
+func (svc *checklistService) CompleteTask(ctx context.Context, payload *CompleteTaskPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.CompletedBy == "" {
+		return errs.ErrMissingRequiredAttribute
+	}
+
+	if payload.Status == "" {
+		payload.Status = StatusDone
+	}
+
+	if payload.Status != StatusDone && payload.Status != StatusSkipped {
+		return errs.ErrBadRequest
+	}
+
+	task, err := svc.repo.GetOneTask(ctx, &taskQuery{ID: payload.ID, FarmID: payload.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if task == nil {
+		return errs.ErrNotFound
+	}
+
+	// measuring task isn't done without its reading
+	if payload.Status == StatusDone && task.ReadingType != "" && payload.Reading == nil {
+		return errs.ErrMissingRequiredAttribute
+	}
+
+	task.Status = payload.Status
+	task.CompletedBy = payload.CompletedBy
+	task.CompletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	task.Note = payload.Note
+	if payload.Reading != nil {
+		task.Reading = sql.NullFloat64{Float64: *payload.Reading, Valid: true}
+	}
+
+	err = svc.repo.CompleteTask(ctx, task)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// GetReport return task completion per worker for every day within the range, day without any task is omitted
+func (svc *checklistService) GetReport(ctx context.Context, params *ReportRequestQuery) (res *ReportResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &reportQuery{FarmID: params.FarmID}
+
+	if params.DateFrom == "" {
+		if repoParams.DateFrom, err = svc.today(ctx, params.FarmID); err != nil {
+			return
+		}
+	} else if repoParams.DateFrom, err = time.Parse(DateLayout, params.DateFrom); err != nil {
+		return nil, errs.ErrBadRequest
+	}
+
+	repoParams.DateTo = repoParams.DateFrom
+	if params.DateTo != "" {
+		if repoParams.DateTo, err = time.Parse(DateLayout, params.DateTo); err != nil {
+			return nil, errs.ErrBadRequest
+		}
+	}
+
+	if repoParams.DateTo.Before(repoParams.DateFrom) || repoParams.DateTo.Sub(repoParams.DateFrom) >= MaxReportDays*24*time.Hour {
+		return nil, errs.ErrBadRequest
+	}
+
+	rows, err := svc.repo.GetReport(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	res = &ReportResponse{
+		FarmID: params.FarmID,
+		Days:   []*DailyReportResponse{},
+	}
+
+	var day *DailyReportResponse
+	for _, row := range rows {
+		date := row.TaskDate.Format(DateLayout)
+		if day == nil || day.Date != date {
+			day = &DailyReportResponse{Date: date, Workers: []*WorkerReportResponse{}}
+			res.Days = append(res.Days, day)
+		}
+
+		day.Done += row.Done
+		day.Skipped += row.Skipped
+		day.Pending += row.Pending
+		day.Total += row.Done + row.Skipped + row.Pending
+
+		// pending tasks has no worker yet
+		if row.Worker != "" {
+			day.Workers = append(day.Workers, &WorkerReportResponse{
+				Worker:  row.Worker,
+				Done:    row.Done,
+				Late:    row.Late,
+				Skipped: row.Skipped,
+			})
+		}
+	}
+
+	return
+}
+
+// Generate instantiate today's tasks of every farm, within each farm's own timezone
+func (svc *checklistService) Generate(ctx context.Context) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	count, err := svc.repo.Generate(ctx, &generateQuery{})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	logger.Debug().Int64("tasks", count).Msg("checklist tasks generated")
+
+	return
+}
+
+// today return current date within farm's timezone
+func (svc *checklistService) today(ctx context.Context, farmID int64) (res time.Time, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	farm, err := svc.farmSvc.GetOne(ctx, &farms.FarmRequestQuery{ID: farmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	loc, err := time.LoadLocation(farm.Timezone)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	y, m, d := time.Now().In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
+
+func toTemplateType(payload *TemplatePayload) (res *TemplateType, err error) {
+	if payload.Name == "" || payload.DueTime == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if _, err = time.Parse(DueTimeLayout, payload.DueTime); err != nil {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &TemplateType{
+		ID:          payload.ID,
+		FarmID:      payload.FarmID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		DueTime:     payload.DueTime,
+		ReadingType: payload.ReadingType,
+		Active:      true,
+	}
+
+	if payload.PondID != 0 {
+		res.PondID = sql.NullInt64{Int64: payload.PondID, Valid: true}
+	}
+
+	if payload.Active != nil {
+		res.Active = *payload.Active
+	}
+
+	return
+}
+
+func toTemplateResponse(template *TemplateType) *TemplateResponse {
+	res := &TemplateResponse{
+		ID:          template.ID,
+		FarmID:      template.FarmID,
+		Name:        template.Name,
+		Description: template.Description,
+		DueTime:     template.DueTime,
+		ReadingType: template.ReadingType,
+		Active:      template.Active,
+	}
+
+	if template.PondID.Valid {
+		res.PondID = &template.PondID.Int64
+	}
+
+	return res
+}
+
+func toTaskResponse(task *TaskType) *TaskResponse {
+	res := &TaskResponse{
+		ID:          task.ID,
+		TemplateID:  task.TemplateID,
+		Name:        task.Name,
+		ReadingType: task.ReadingType,
+		Date:        task.TaskDate.Format(DateLayout),
+		DueAt:       task.DueAt,
+		Status:      task.Status,
+		CompletedBy: task.CompletedBy,
+		Note:        task.Note,
+	}
+
+	if task.PondID.Valid {
+		res.PondID = &task.PondID.Int64
+	}
+
+	if task.CompletedAt.Valid {
+		res.CompletedAt = &task.CompletedAt.Time
+	}
+
+	if task.Reading.Valid {
+		res.Reading = &task.Reading.Float64
+	}
+
+	return res
+}
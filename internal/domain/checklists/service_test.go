@@ -0,0 +1,105 @@
+package checklists
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/domain/farms"
+)
+
+type stubChecklistRepository struct {
+	ChecklistRepository
+	task      *TaskType
+	report    []*WorkerReportType
+	completed *TaskType
+}
+
+func (repo *stubChecklistRepository) GetOneTask(context.Context, *taskQuery) (*TaskType, error) {
+	return repo.task, nil
+}
+
+func (repo *stubChecklistRepository) CompleteTask(_ context.Context, payload *TaskType) error {
+	repo.completed = payload
+	return nil
+}
+
+func (repo *stubChecklistRepository) GetReport(context.Context, *reportQuery) ([]*WorkerReportType, error) {
+	return repo.report, nil
+}
+
+type stubFarmService struct {
+	farms.FarmService
+}
+
+func (svc *stubFarmService) GetOne(context.Context, *farms.FarmRequestQuery) (*farms.FarmResponse, error) {
+	return &farms.FarmResponse{Timezone: "Asia/Jakarta"}, nil
+}
+
+func TestShouldNOTCompleteMeasuringTaskWithoutReading(t *testing.T) {
+	repo := &stubChecklistRepository{task: &TaskType{ID: 1, FarmID: 1, ReadingType: "ph", Status: StatusPending}}
+	svc := NewService(repo, &stubFarmService{})
+
+	err := svc.CompleteTask(context.Background(), &CompleteTaskPayload{ID: 1, FarmID: 1, CompletedBy: "budi"})
+	if err != errs.ErrMissingRequiredAttribute {
+		t.Errorf("expected ErrMissingRequiredAttribute, got: %v", err)
+	}
+
+	if repo.completed != nil {
+		t.Errorf("task shouldn't be completed")
+	}
+
+	reading := 7.2
+	err = svc.CompleteTask(context.Background(), &CompleteTaskPayload{ID: 1, FarmID: 1, CompletedBy: "budi", Reading: &reading})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.completed.Status != StatusDone || !repo.completed.Reading.Valid || repo.completed.Reading.Float64 != reading {
+		t.Errorf("unexpected completed task: %+v", repo.completed)
+	}
+}
+
+func TestShouldGroupReportPerDay(t *testing.T) {
+	day1 := time.Date(2024, 8, 12, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC)
+
+	repo := &stubChecklistRepository{report: []*WorkerReportType{
+		{TaskDate: day1, Worker: "andi", Done: 3, Late: 1},
+		{TaskDate: day1, Worker: "budi", Done: 1, Skipped: 1},
+		{TaskDate: day2, Pending: 4},
+	}}
+	svc := NewService(repo, &stubFarmService{})
+
+	res, err := svc.GetReport(context.Background(), &ReportRequestQuery{FarmID: 1, DateFrom: "2024-08-12", DateTo: "2024-08-13"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if len(res.Days) != 2 {
+		t.Fatalf("expected 2 days, got: %d", len(res.Days))
+	}
+
+	if d := res.Days[0]; d.Date != "2024-08-12" || d.Total != 5 || d.Done != 4 || len(d.Workers) != 2 {
+		t.Errorf("unexpected first day: %+v", d)
+	}
+
+	if d := res.Days[1]; d.Total != 4 || d.Pending != 4 || len(d.Workers) != 0 {
+		t.Errorf("unexpected second day: %+v", d)
+	}
+}
+
+func TestShouldNOTGetReportOfInvalidRange(t *testing.T) {
+	svc := NewService(&stubChecklistRepository{}, &stubFarmService{})
+
+	for _, params := range []*ReportRequestQuery{
+		{FarmID: 1, DateFrom: "13-08-2024"},
+		{FarmID: 1, DateFrom: "2024-08-13", DateTo: "2024-08-12"},
+		{FarmID: 1, DateFrom: "2024-07-01", DateTo: "2024-08-13"},
+	} {
+		if _, err := svc.GetReport(context.Background(), params); err != errs.ErrBadRequest {
+			t.Errorf("expected ErrBadRequest for %+v, got: %v", params, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package devices
+
+import "github.com/labstack/echo/v4"
+
+type DeviceController struct {
+	svc DeviceService
+}
+
+func NewController(svc DeviceService) *DeviceController {
+	return &DeviceController{
+		svc: svc,
+	}
+}
+
+const (
+	deviceBasepath    = "/farms/:farmID"
+	devicePath        = "/devices"
+	deviceIDPath      = "/devices/:deviceID"
+	deviceKeyPath     = "/devices/:deviceID/key"
+	sensorReadingPath = "/ponds/:pondID/sensor-readings"
+	seriesPath        = "/ponds/:pondID/sensor-readings/series"
+	topicIngestPath   = "/ponds/:pondID/sensors/:type/readings"
+	ingestPath        = "/devices/:sensorID/readings"
+)
+
+func (dc *DeviceController) Route(grp *echo.Group) {
+	subrouter := grp.Group(deviceBasepath)
+
+	subrouter.GET(devicePath, HandleGetAllDevice(dc.svc.GetAll))
+	subrouter.OPTIONS(devicePath, HandleGetAllDevice(dc.svc.GetAll))
+	subrouter.GET(deviceIDPath, HandleGetOneDevice(dc.svc.GetOne))
+	subrouter.OPTIONS(deviceIDPath, HandleGetOneDevice(dc.svc.GetOne))
+	subrouter.POST(devicePath, HandleCreateDevice(dc.svc.Create))
+	subrouter.OPTIONS(devicePath, HandleCreateDevice(dc.svc.Create))
+	subrouter.PUT(deviceIDPath, HandleUpdateDevice(dc.svc.Update))
+	subrouter.OPTIONS(deviceIDPath, HandleUpdateDevice(dc.svc.Update))
+	subrouter.DELETE(deviceIDPath, HandleDeleteDevice(dc.svc.Delete))
+	subrouter.OPTIONS(deviceIDPath, HandleDeleteDevice(dc.svc.Delete))
+	subrouter.POST(deviceKeyPath, HandleRotateDeviceKey(dc.svc.RotateKey))
+	subrouter.OPTIONS(deviceKeyPath, HandleRotateDeviceKey(dc.svc.RotateKey))
+	subrouter.GET(sensorReadingPath, HandleGetAllSensorReading(dc.svc.GetReadings))
+	subrouter.OPTIONS(sensorReadingPath, HandleGetAllSensorReading(dc.svc.GetReadings))
+	subrouter.GET(seriesPath, HandleGetSensorReadingSeries(dc.svc.GetSeries))
+	subrouter.OPTIONS(seriesPath, HandleGetSensorReadingSeries(dc.svc.GetSeries))
+	subrouter.POST(topicIngestPath, HandleIngestTopic(dc.svc.IngestTopic))
+	subrouter.OPTIONS(topicIngestPath, HandleIngestTopic(dc.svc.IngestTopic))
+
+	// ingestion is addressed by device serial, since device only knows its own identity
+	grp.POST(ingestPath, HandleIngest(dc.svc.Ingest))
+	grp.OPTIONS(ingestPath, HandleIngest(dc.svc.Ingest))
+
+	return
+}
@@ -0,0 +1,145 @@
+package devices
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// DeviceKeyHeader is the header carrying device key on ingestion
+const DeviceKeyHeader = "X-Device-Key"
+
+// DeviceRequestQuery represent query parameters fetch from request
+type DeviceRequestQuery struct {
+	ID     int64  `param:"deviceID" example:"1"`
+	FarmID int64  `param:"farmID" example:"1"`
+	PondID int64  `query:"pond_id" example:"1"`
+	Type   string `query:"type" example:"dissolved_oxygen"`
+	Limit  uint64 `query:"limit" example:"100"`
+	Page   uint64 `query:"page" example:"2"`
+}
+
+// DevicePayload represent payload fetch from request body
+type DevicePayload struct {
+	ID                int64   `param:"deviceID" json:"-" example:"1"`
+	FarmID            int64   `param:"farmID" json:"-" example:"1"`
+	PondID            int64   `json:"pond_id" example:"1"`
+	SensorID          string  `json:"sensor_id" example:"DO-00A1B2"`
+	Type              string  `json:"type" example:"dissolved_oxygen" enums:"dissolved_oxygen,ph,temperature,salinity,ammonia,nitrite,turbidity,water_level,aerator_power"`
+	CalibrationOffset float64 `json:"calibration_offset" example:"-0.15"` // added into every raw value
+}
+
+// DeviceResponse represent domain response for Device entity
+type DeviceResponse struct {
+	ID                int64      `json:"id" example:"1"`
+	FarmID            int64      `json:"farm_id" example:"1"`
+	PondID            int64      `json:"pond_id" example:"1"`
+	SensorID          string     `json:"sensor_id" example:"DO-00A1B2"`
+	Type              string     `json:"type" example:"dissolved_oxygen"`
+	CalibrationOffset float64    `json:"calibration_offset" example:"-0.15"`
+	LastSeenAt        *time.Time `json:"last_seen_at" example:"2024-08-05T06:00:00Z"`
+}
+
+// DeviceKeyResponse represent freshly generated device key, only shown once
+type DeviceKeyResponse struct {
+	ID       int64  `json:"id" example:"1"`
+	SensorID string `json:"sensor_id" example:"DO-00A1B2"`
+	Key      string `json:"key" example:"4f9c2a..."`
+}
+
+// ListDeviceResponse represent domain response for bulk Device entities
+type ListDeviceResponse struct {
+	Devices []*DeviceResponse      `json:"devices"`
+	Meta    httpres.ListPagination `json:"meta"`
+}
+
+// IngestPayload represent batched readings pushed by a device
+type IngestPayload struct {
+	SensorID string                  `param:"sensorID" json:"-" example:"DO-00A1B2"`
+	Key      string                  `json:"-"` // taken from X-Device-Key header
+	Readings []*IngestReadingPayload `json:"readings"`
+}
+
+// IngestReadingPayload represent a single raw measurement within a batch
+type IngestReadingPayload struct {
+	Value      *float64  `json:"value" example:"5.35"`
+	MeasuredAt time.Time `json:"measured_at" example:"2024-08-05T06:00:00Z"` // default to time of ingestion
+}
+
+// TopicIngestPayload represent readings published by a field gateway into farm/{farmID}/pond/{pondID}/sensor/{type},
+// or pushed into its HTTP counterpart
+type TopicIngestPayload struct {
+	FarmID   int64                   `param:"farmID" json:"-"`
+	PondID   int64                   `param:"pondID" json:"-"`
+	Type     string                  `param:"type" json:"-"`
+	SensorID string                  `json:"sensor_id" example:"DO-00A1B2"` // required when the pond has several devices of the same type
+	Key      string                  `json:"key" example:"4f9c2a..."`       // key of the device, taken from X-Device-Key header over HTTP
+	Readings []*IngestReadingPayload `json:"readings"`
+
+	// single reading shorthand, used when readings is empty
+	Value      *float64  `json:"value" example:"5.35"`
+	MeasuredAt time.Time `json:"measured_at" example:"2024-08-05T06:00:00Z"`
+}
+
+// IngestResponse represent ingestion summary
+type IngestResponse struct {
+	Received int64 `json:"received" example:"60"`
+	Stored   int64 `json:"stored" example:"58"` // readings already stored before are skipped
+}
+
+// SensorReadingRequestQuery represent query parameters fetch from request
+type SensorReadingRequestQuery struct {
+	FarmID   int64     `param:"farmID" example:"1"`
+	PondID   int64     `param:"pondID" example:"1"`
+	DeviceID int64     `query:"device_id" example:"1"`
+	Type     string    `query:"type" example:"dissolved_oxygen"`
+	From     time.Time `query:"from" example:"2024-08-05T00:00:00Z"`
+	To       time.Time `query:"to" example:"2024-08-06T00:00:00Z"`
+	Limit    uint64    `query:"limit" example:"100"`
+	Page     uint64    `query:"page" example:"2"`
+}
+
+// SensorReadingResponse represent domain response for Sensor Reading entity
+type SensorReadingResponse struct {
+	DeviceID   int64     `json:"device_id" example:"1"`
+	PondID     int64     `json:"pond_id" example:"1"`
+	Type       string    `json:"type" example:"dissolved_oxygen"`
+	Value      float64   `json:"value" example:"5.2"`
+	RawValue   float64   `json:"raw_value" example:"5.35"`
+	MeasuredAt time.Time `json:"measured_at" example:"2024-08-05T06:00:00Z"`
+}
+
+// ListSensorReadingResponse represent domain response for bulk Sensor Reading entities
+type ListSensorReadingResponse struct {
+	Readings []*SensorReadingResponse `json:"readings"`
+	Meta     httpres.ListPagination   `json:"meta"`
+}
+
+// SeriesRequestQuery represent query parameters fetch from request
+type SeriesRequestQuery struct {
+	FarmID     int64     `param:"farmID" example:"1"`
+	PondID     int64     `param:"pondID" example:"1"`
+	DeviceID   int64     `query:"device_id" example:"1"`
+	Type       string    `query:"type" example:"dissolved_oxygen"`
+	From       time.Time `query:"from" example:"2024-08-05T00:00:00Z"`
+	To         time.Time `query:"to" example:"2024-08-06T00:00:00Z"`
+	Resolution string    `query:"resolution" example:"auto"`
+}
+
+// SeriesPointResponse represent aggregated readings within a single bucket
+type SeriesPointResponse struct {
+	Bucket   time.Time `json:"bucket" example:"2024-08-05T06:00:00Z"`
+	MinValue float64   `json:"min" example:"4.8"`
+	AvgValue float64   `json:"avg" example:"5.2"`
+	MaxValue float64   `json:"max" example:"5.9"`
+	Count    int64     `json:"count" example:"12"`
+}
+
+// SeriesResponse represent domain response for aggregated Sensor Reading series
+type SeriesResponse struct {
+	Type       string                 `json:"type" example:"dissolved_oxygen"`
+	Resolution string                 `json:"resolution" example:"5m"`
+	From       time.Time              `json:"from" example:"2024-08-05T00:00:00Z"`
+	To         time.Time              `json:"to" example:"2024-08-06T00:00:00Z"`
+	Points     []*SeriesPointResponse `json:"points"`
+}
@@ -0,0 +1,386 @@
+package devices
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllDeviceHandler func(context.Context, *DeviceRequestQuery) (*ListDeviceResponse, error)
+
+// Get All Device godoc
+//
+//	@Summary	get all registered devices of a farm
+//	@Tags		Device
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pond_id	query		int		false	"only return device installed in the pond"
+//	@Param		type	query		string	false	"only return device measuring the parameter"
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListDeviceResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/devices [get]
+func HandleGetAllDevice(handler GetAllDeviceHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &DeviceRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneDeviceHandler func(context.Context, *DeviceRequestQuery) (*DeviceResponse, error)
+
+// Get One Device godoc
+//
+//	@Summary	get specific device by ID
+//	@Tags		Device
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		deviceID	path		int	true	"Device ID"
+//	@Success	200			{object}	DeviceResponse
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/devices/{deviceID} [get]
+func HandleGetOneDevice(handler GetOneDeviceHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &DeviceRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateDeviceHandler func(context.Context, *DevicePayload) (*DeviceKeyResponse, error)
+
+// CreateDevice godoc
+//
+//	@Summary	register a new device, the returned key is only shown once
+//	@Tags		Device
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int				true	"Farm ID"
+//	@Param		payload	body		DevicePayload	true	"device payload"
+//	@Success	201		{object}	DeviceKeyResponse
+//	@Failure	404		{object}	httpres.ErrorResponse	"pond not existed in the farm"
+//	@Failure	409		{object}	httpres.ErrorResponse	"device with same sensor ID already registered"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/devices [post]
+func HandleCreateDevice(handler CreateDeviceHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &DevicePayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, data)
+	}
+}
+
+type UpdateDeviceHandler func(context.Context, *DevicePayload) error
+
+// Update Device godoc
+//
+//	@Summary	update device data, ex: move it into another pond
+//	@Tags		Device
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int				true	"Farm ID"
+//	@Param		deviceID	path		int				true	"Device ID"
+//	@Param		payload		body		DevicePayload	true	"device payload"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"device or pond not existed"
+//	@Failure	409			{object}	httpres.ErrorResponse	"device with same sensor ID already registered"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/devices/{deviceID} [put]
+func HandleUpdateDevice(handler UpdateDeviceHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &DevicePayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type RotateDeviceKeyHandler func(context.Context, *DeviceRequestQuery) (*DeviceKeyResponse, error)
+
+// Rotate Device Key godoc
+//
+//	@Summary	generate a new device key, invalidating the previous one
+//	@Tags		Device
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		deviceID	path		int	true	"Device ID"
+//	@Success	200			{object}	DeviceKeyResponse
+//	@Failure	404			{object}	httpres.ErrorResponse	"device not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/devices/{deviceID}/key [post]
+func HandleRotateDeviceKey(handler RotateDeviceKeyHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &DeviceRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type DeleteDeviceHandler func(context.Context, *DeviceRequestQuery) error
+
+// DeleteDevice godoc
+//
+//	@Summary	delete specific device by ID
+//	@Tags		Device
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		deviceID	path		int	true	"Device ID"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"device not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/devices/{deviceID} [delete]
+func HandleDeleteDevice(handler DeleteDeviceHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &DeviceRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetAllSensorReadingHandler func(context.Context, *SensorReadingRequestQuery) (*ListSensorReadingResponse, error)
+
+// Get All Sensor Reading godoc
+//
+//	@Summary	get readings reported by devices of a pond
+//	@Tags		Device
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		pondID		path		int		true	"Pond ID"
+//	@Param		device_id	query		int		false	"only return reading of the device"
+//	@Param		type		query		string	false	"only return reading of the parameter"
+//	@Param		from		query		string	false	"start of time window (inclusive), RFC3339"
+//	@Param		to			query		string	false	"end of time window (exclusive), RFC3339"
+//	@Param		limit		query		string	false	"number of entity per page"
+//	@Param		page		query		string	false	"n-th page"
+//	@Success	200			{object}	ListSensorReadingResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"invalid time window"
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/sensor-readings [get]
+func HandleGetAllSensorReading(handler GetAllSensorReadingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SensorReadingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetSensorReadingSeriesHandler func(context.Context, *SeriesRequestQuery) (*SeriesResponse, error)
+
+// Get Sensor Reading Series godoc
+//
+//	@Summary	get min/avg/max readings of a pond aggregated per bucket, resolution is picked from the time range unless specified
+//	@Tags		Device
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		pondID		path		int		true	"Pond ID"
+//	@Param		type		query		string	true	"measured parameter"
+//	@Param		device_id	query		int		false	"only aggregate reading of the device"
+//	@Param		from		query		string	false	"start of time window (inclusive), RFC3339, default to 24 hours before to"
+//	@Param		to			query		string	false	"end of time window (exclusive), RFC3339, default to now"
+//	@Param		resolution	query		string	false	"raw, 5m, 1h, 1d or auto (default)"
+//	@Success	200			{object}	SeriesResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"invalid time window or too many buckets for the resolution"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/sensor-readings/series [get]
+func HandleGetSensorReadingSeries(handler GetSensorReadingSeriesHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SeriesRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type IngestTopicHandler func(context.Context, *TopicIngestPayload) (*IngestResponse, error)
+
+// Ingest Topic godoc
+//
+//	@Summary	push readings of a pond's sensor on behalf of a field gateway, HTTP counterpart of the MQTT topic farm/{farmID}/pond/{pondID}/sensor/{type}
+//	@Tags		Device
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID			path		int					true	"Farm ID"
+//	@Param		pondID			path		int					true	"Pond ID"
+//	@Param		type			path		string				true	"Sensor type"
+//	@Param		X-Device-Key	header		string				true	"Device key"
+//	@Param		payload			body		TopicIngestPayload	true	"readings"
+//	@Success	201				{object}	IngestResponse
+//	@Failure	400				{object}	httpres.ErrorResponse	"batch too large, missing value or ambiguous sensor"
+//	@Failure	401				{object}	httpres.ErrorResponse	"invalid device key"
+//	@Failure	403				{object}	httpres.ErrorResponse
+//	@Failure	404				{object}	httpres.ErrorResponse	"no device of the type in the pond"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/sensors/{type}/readings [post]
+func HandleIngestTopic(handler IngestTopicHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &TopicIngestPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+		params.Key = c.Request().Header.Get(DeviceKeyHeader)
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, data)
+	}
+}
+
+type IngestHandler func(context.Context, *IngestPayload) (*IngestResponse, error)
+
+// Ingest godoc
+//
+//	@Summary	push batched readings of a device, authenticated by its device key
+//	@Tags		Device
+//	@Accept		json
+//	@Produce	json
+//	@Param		sensorID		path		string			true	"Sensor ID"
+//	@Param		X-Device-Key	header		string			true	"Device key"
+//	@Param		payload			body		IngestPayload	true	"batched readings"
+//	@Success	201				{object}	IngestResponse
+//	@Failure	400				{object}	httpres.ErrorResponse	"batch too large or missing value"
+//	@Failure	401				{object}	httpres.ErrorResponse	"unknown device or invalid key"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/devices/{sensorID}/readings [post]
+func HandleIngest(handler IngestHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &IngestPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+		params.Key = c.Request().Header.Get(DeviceKeyHeader)
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, data)
+	}
+}
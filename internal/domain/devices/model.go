@@ -0,0 +1,85 @@
+package devices
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	TypeDissolvedOxygen = "dissolved_oxygen"
+	TypePH              = "ph"
+	TypeTemperature     = "temperature"
+	TypeSalinity        = "salinity"
+	TypeAmmonia         = "ammonia"
+	TypeNitrite         = "nitrite"
+	TypeTurbidity       = "turbidity"
+	TypeWaterLevel      = "water_level"
+	TypeAeratorPower    = "aerator_power"
+)
+
+// sensorTypes list every parameter a device may report
+var sensorTypes = []string{
+	TypeDissolvedOxygen, TypePH, TypeTemperature, TypeSalinity, TypeAmmonia,
+	TypeNitrite, TypeTurbidity, TypeWaterLevel, TypeAeratorPower,
+}
+
+// MaxBatchSize limit number of readings accepted within a single ingestion
+const MaxBatchSize = 1000
+
+type DeviceType struct {
+	ID                int64        `db:"id"`
+	FarmID            int64        `db:"farm_id"`
+	PondID            int64        `db:"pond_id"`
+	SensorID          string       `db:"sensor_id"`
+	Type              string       `db:"type"`
+	CalibrationOffset float64      `db:"calibration_offset"`
+	KeyHash           string       `db:"key_hash"`
+	LastSeenAt        sql.NullTime `db:"last_seen_at"`
+}
+
+// SensorReadingType represent a single calibrated measurement reported by a device
+type SensorReadingType struct {
+	DeviceID   int64     `db:"device_id"`
+	PondID     int64     `db:"pond_id"`
+	Type       string    `db:"type"`
+	Value      float64   `db:"value"`
+	RawValue   float64   `db:"raw_value"`
+	MeasuredAt time.Time `db:"measured_at"`
+}
+
+// SeriesPointType represent aggregated readings within a bucket
+type SeriesPointType struct {
+	Bucket   time.Time `db:"bucket"`
+	MinValue float64   `db:"min_value"`
+	AvgValue float64   `db:"avg_value"`
+	MaxValue float64   `db:"max_value"`
+	Count    int64     `db:"count"`
+}
+
+// Resolution represent a granularity sensor readings are stored in
+type Resolution struct {
+	Name     string
+	Table    string
+	Size     time.Duration // nominal width of a bucket
+	MaxRange time.Duration // widest time range automatically served by this resolution
+	Lookback time.Duration // readings arriving later than this aren't rolled up
+}
+
+const (
+	ResolutionRaw  = "raw"
+	Resolution5m   = "5m"
+	Resolution1h   = "1h"
+	Resolution1d   = "1d"
+	ResolutionAuto = "auto"
+)
+
+// MaxSeriesPoints limit number of buckets returned within a single series
+const MaxSeriesPoints = 5000
+
+// resolutions is ordered from the finest, every rollup is built from its predecessor
+var resolutions = []*Resolution{
+	{Name: ResolutionRaw, Table: "sensor_readings", Size: time.Minute, MaxRange: 6 * time.Hour},
+	{Name: Resolution5m, Table: "sensor_readings_5m", Size: 5 * time.Minute, MaxRange: 3 * 24 * time.Hour, Lookback: time.Hour},
+	{Name: Resolution1h, Table: "sensor_readings_1h", Size: time.Hour, MaxRange: 60 * 24 * time.Hour, Lookback: 3 * time.Hour},
+	{Name: Resolution1d, Table: "sensor_readings_1d", Size: 24 * time.Hour, Lookback: 2 * 24 * time.Hour},
+}
@@ -0,0 +1,620 @@
+package devices
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// DeviceRepository contain contract that defined all necessary public function available to be interact with
+type DeviceRepository interface {
+	GetAll(context.Context, *deviceQuery) ([]*DeviceType, error)
+	Count(context.Context, *deviceQuery) (uint64, error)
+	GetOne(context.Context, *deviceQuery) (*DeviceType, error)
+	Store(context.Context, *DeviceType) error
+	Update(context.Context, *DeviceType) error
+	UpdateKey(context.Context, *DeviceType) error
+	Delete(context.Context, *deviceQuery) error
+	StoreReadings(context.Context, *DeviceType, []*SensorReadingType) (int64, error)
+	GetReadings(context.Context, *readingQuery) ([]*SensorReadingType, error)
+	CountReadings(context.Context, *readingQuery) (uint64, error)
+	GetSeries(context.Context, *seriesQuery) ([]*SeriesPointType, error)
+	Rollup(context.Context, *Resolution, *Resolution, time.Time) (int64, error)
+}
+
+type deviceRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of deviceRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) DeviceRepository {
+	return &deviceRepository{db: db}
+}
+
+type deviceQuery struct {
+	ID, FarmID, PondID int64
+	SensorID, Type     string
+	Limit, Page        uint64
+}
+
+type readingQuery struct {
+	FarmID, PondID, DeviceID int64
+	Type                     string
+	From, To                 time.Time
+	Limit, Page              uint64
+}
+
+type seriesQuery struct {
+	FarmID, PondID, DeviceID int64
+	Type                     string
+	From, To                 time.Time
+	Resolution               *Resolution
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var deviceColumns = []string{"d.id", "d.farm_id", "d.pond_id", "d.sensor_id", "d.type", "d.calibration_offset", "d.key_hash", "d.last_seen_at"}
+
+var readingColumns = []string{"r.device_id", "r.pond_id", "r.type", "r.value", "r.raw_value", "r.measured_at"}
+
+// insertChunkSize keep number of bind parameters of a multi-row insert below postgres limit
+const insertChunkSize = 500
+
+func (params *deviceQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"d.deleted_at": nil},
+	}
+
+	// device is looked up by its serial on ingestion, thus regardless of farm
+	if params.SensorID != "" {
+		return append(cond, squirrel.Eq{"d.sensor_id": params.SensorID})
+	}
+
+	cond = append(cond, squirrel.Eq{"d.farm_id": params.FarmID})
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"d.id": params.ID})
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"d.pond_id": params.PondID})
+	}
+
+	if params.Type != "" {
+		cond = append(cond, squirrel.Eq{"d.type": params.Type})
+	}
+
+	return cond
+}
+
+func (params *readingQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"r.pond_id": params.PondID},
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+	}
+
+	if params.DeviceID != 0 {
+		cond = append(cond, squirrel.Eq{"r.device_id": params.DeviceID})
+	}
+
+	if params.Type != "" {
+		cond = append(cond, squirrel.Eq{"r.type": params.Type})
+	}
+
+	if !params.From.IsZero() {
+		cond = append(cond, squirrel.GtOrEq{"r.measured_at": params.From})
+	}
+
+	if !params.To.IsZero() {
+		cond = append(cond, squirrel.Lt{"r.measured_at": params.To})
+	}
+
+	return cond
+}
+
+func (repo *deviceRepository) GetAll(ctx context.Context, params *deviceQuery) (res []*DeviceType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(deviceColumns...).From("devices d").
+		Where(params.filter()).
+		OrderBy("d.id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*DeviceType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &DeviceType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *deviceRepository) Count(ctx context.Context, params *deviceQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("devices d").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *deviceRepository) GetOne(ctx context.Context, params *deviceQuery) (res *DeviceType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(deviceColumns...).From("devices d").
+		Where(params.filter()).ToSql()
+
+	res = &DeviceType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure the bound pond is within the farm and the serial isn't registered yet
+func (repo *deviceRepository) validate(ctx context.Context, tx *sqlx.Tx, payload *DeviceType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var count int64
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+		squirrel.Eq{"id": payload.PondID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate pond data existence")
+		return
+	}
+
+	// if selected pond doesn't exists within the farm, bail out from here
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("devices").Where(squirrel.And{
+		squirrel.NotEq{"id": payload.ID},
+		squirrel.Eq{"sensor_id": payload.SensorID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
+		return
+	}
+
+	// if active (non-deleted) device exist with such serial, return duplicated err
+	if count != 0 {
+		return errs.ErrDuplicatedResources
+	}
+
+	return
+}
+
+// Store save a new device, the generated ID will be assigned back into payload
+func (repo *deviceRepository) Store(ctx context.Context, payload *DeviceType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("devices").
+		Columns("farm_id", "pond_id", "sensor_id", "type", "calibration_offset", "key_hash").
+		Values(payload.FarmID, payload.PondID, payload.SensorID, payload.Type, payload.CalibrationOffset, payload.KeyHash).
+		Suffix("RETURNING id").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *deviceRepository) Update(ctx context.Context, payload *DeviceType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("devices").SetMap(map[string]interface{}{
+		"pond_id":            payload.PondID,
+		"sensor_id":          payload.SensorID,
+		"type":               payload.Type,
+		"calibration_offset": payload.CalibrationOffset,
+		"updated_at":         squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// UpdateKey replace the key hash of a device, invalidating the previous key
+func (repo *deviceRepository) UpdateKey(ctx context.Context, payload *DeviceType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("devices").SetMap(map[string]interface{}{
+		"key_hash":   payload.KeyHash,
+		"updated_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("device doesn't exists")
+		return
+	}
+
+	return
+}
+
+func (repo *deviceRepository) Delete(ctx context.Context, params *deviceQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("devices").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("device doesn't exists")
+		return
+	}
+
+	return
+}
+
+// StoreReadings save readings of a device in chunked multi-row insert, return number of newly stored readings.
+// Readings already stored before (same device and timestamp) are silently skipped, so a gateway may safely resend a batch
+func (repo *deviceRepository) StoreReadings(ctx context.Context, device *DeviceType, readings []*SensorReadingType) (res int64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	for start := 0; start < len(readings); start += insertChunkSize {
+		end := min(start+insertChunkSize, len(readings))
+
+		query := pgSquirrel.Insert("sensor_readings").
+			Columns("device_id", "pond_id", "type", "value", "raw_value", "measured_at")
+
+		for _, reading := range readings[start:end] {
+			query = query.Values(reading.DeviceID, reading.PondID, reading.Type, reading.Value, reading.RawValue, reading.MeasuredAt)
+		}
+
+		stmt, args, _ := query.Suffix("ON CONFLICT (device_id, measured_at) DO NOTHING").ToSql()
+
+		result, err := tx.ExecContext(ctx, stmt, args...)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to save data")
+			return 0, err
+		}
+
+		affected, _ := result.RowsAffected()
+		res += affected
+	}
+
+	stmt, args, _ := pgSquirrel.Update("devices").SetMap(map[string]interface{}{
+		"last_seen_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.Eq{"id": device.ID}).ToSql()
+
+	if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return 0, err
+	}
+
+	return
+}
+
+func (repo *deviceRepository) GetReadings(ctx context.Context, params *readingQuery) (res []*SensorReadingType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(readingColumns...).From("sensor_readings r").
+		Join("ponds p on r.pond_id = p.id").
+		Where(params.filter()).
+		OrderBy("r.measured_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*SensorReadingType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &SensorReadingType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *deviceRepository) CountReadings(ctx context.Context, params *readingQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("sensor_readings r").
+		Join("ponds p on r.pond_id = p.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+// timeColumn return column marking the time of a row within resolution's table
+func (res *Resolution) timeColumn() string {
+	if res.Name == ResolutionRaw {
+		return "measured_at"
+	}
+
+	return "bucket"
+}
+
+// aggregateColumns return expressions combining rows of resolution's table into min, avg, max and count
+func (res *Resolution) aggregateColumns() []string {
+	if res.Name == ResolutionRaw {
+		return []string{"min(r.value) min_value", "avg(r.value) avg_value", "max(r.value) max_value", "count(*) count"}
+	}
+
+	// coarser average is weighted by number of raw readings within each finer bucket
+	return []string{"min(r.min_value) min_value", "sum(r.avg_value * r.count) / sum(r.count) avg_value", "max(r.max_value) max_value", "sum(r.count)::bigint count"}
+}
+
+// windowStart align the start of re-aggregated window into bucket boundary, so no bucket is partially recomputed
+func (res *Resolution) windowStart(watermark time.Time) time.Time {
+	start := watermark.Add(-res.Lookback)
+
+	// daily bucket follows farm's timezone, which may start up to a day earlier than UTC midnight
+	if res.Name == Resolution1d {
+		return start.Truncate(24 * time.Hour).Add(-24 * time.Hour)
+	}
+
+	return start.Truncate(res.Size)
+}
+
+func (params *seriesQuery) filter() squirrel.And {
+	timeColumn := "r." + params.Resolution.timeColumn()
+
+	cond := squirrel.And{
+		squirrel.Eq{"r.pond_id": params.PondID},
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"r.type": params.Type},
+		squirrel.GtOrEq{timeColumn: params.From},
+		squirrel.Lt{timeColumn: params.To},
+	}
+
+	if params.DeviceID != 0 {
+		cond = append(cond, squirrel.Eq{"r.device_id": params.DeviceID})
+	}
+
+	return cond
+}
+
+// GetSeries return readings of a pond aggregated per bucket of the requested resolution, combining every matching device
+func (repo *deviceRepository) GetSeries(ctx context.Context, params *seriesQuery) (res []*SeriesPointType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	timeColumn := "r." + params.Resolution.timeColumn()
+
+	stmt, args, _ := pgSquirrel.Select(append([]string{timeColumn + " bucket"}, params.Resolution.aggregateColumns()...)...).
+		From(params.Resolution.Table + " r").
+		Join("ponds p on r.pond_id = p.id").
+		Where(params.filter()).
+		GroupBy(timeColumn).
+		OrderBy(timeColumn).ToSql()
+
+	res = []*SeriesPointType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &SeriesPointType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+// Rollup re-aggregate source readings since target's last watermark (minus its lookback) into target buckets,
+// return number of upserted buckets
+func (repo *deviceRepository) Rollup(ctx context.Context, source, target *Resolution, until time.Time) (res int64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	// lock the watermark, so concurrent instances don't roll up the same window
+	stmt, args, _ := pgSquirrel.Select("rolled_until").From("sensor_rollup_watermarks").
+		Where(squirrel.Eq{"resolution": target.Name}).
+		Suffix("FOR UPDATE").ToSql()
+
+	watermark := time.Unix(0, 0)
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&watermark); err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+
+	timeColumn := "r." + source.timeColumn()
+	seconds := int64(target.Size / time.Second)
+
+	bucket := fmt.Sprintf("to_timestamp(floor(extract(epoch from %s) / %d) * %d)", timeColumn, seconds, seconds)
+	query := pgSquirrel.Select().From(source.Table + " r")
+	if target.Name == Resolution1d {
+		bucket = fmt.Sprintf("date_trunc('day', %s, f.timezone)", timeColumn)
+		query = query.Join("ponds p on r.pond_id = p.id").Join("farms f on p.farm_id = f.id")
+	}
+
+	selectStmt := query.
+		Columns(append([]string{"r.device_id", "r.pond_id", "r.type", bucket}, source.aggregateColumns()...)...).
+		Where(squirrel.And{
+			squirrel.GtOrEq{timeColumn: target.windowStart(watermark)},
+			squirrel.Lt{timeColumn: until},
+		}).
+		GroupBy("1", "2", "3", "4")
+
+	stmt, args, _ = pgSquirrel.Insert(target.Table).
+		Columns("device_id", "pond_id", "type", "bucket", "min_value", "avg_value", "max_value", "count").
+		Select(selectStmt).
+		Suffix("ON CONFLICT (device_id, pond_id, type, bucket) DO UPDATE SET " +
+			"min_value = excluded.min_value, avg_value = excluded.avg_value, max_value = excluded.max_value, count = excluded.count").ToSql()
+
+	result, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+	res, _ = result.RowsAffected()
+
+	stmt, args, _ = pgSquirrel.Insert("sensor_rollup_watermarks").
+		Columns("resolution", "rolled_until").
+		Values(target.Name, until).
+		Suffix("ON CONFLICT (resolution) DO UPDATE SET rolled_until = excluded.rolled_until").ToSql()
+
+	if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return 0, err
+	}
+
+	return
+}
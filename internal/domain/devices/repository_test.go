@@ -0,0 +1,227 @@
+package devices
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldGetDeviceBySensorID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	deviceRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "farm_id", "pond_id", "sensor_id", "type", "calibration_offset", "key_hash", "last_seen_at"}).
+		AddRow(1, 1, 2, "DO-00A1B2", TypeDissolvedOxygen, -0.15, "hash", nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT d.id, d.farm_id, d.pond_id, d.sensor_id, d.type, d.calibration_offset, d.key_hash, d.last_seen_at FROM devices d WHERE (d.deleted_at IS NULL AND d.sensor_id = $1)")).
+		WithArgs("DO-00A1B2").
+		WillReturnRows(rows)
+
+	res, err := deviceRepo.GetOne(context.Background(), &deviceQuery{SensorID: "DO-00A1B2"})
+	if err != nil || res == nil || res.PondID != 2 || res.LastSeenAt.Valid {
+		t.Errorf("unexpected device: %+v, err: %v", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreDevice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	deviceRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM devices WHERE (id <> $1 AND sensor_id = $2 AND deleted_at IS NULL)")).
+		WithArgs(0, "DO-00A1B2").
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO devices (farm_id,pond_id,sensor_id,type,calibration_offset,key_hash) VALUES ($1,$2,$3,$4,$5,$6) RETURNING id")).
+		WithArgs(1, 2, "DO-00A1B2", TypeDissolvedOxygen, -0.15, "hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	payload := &DeviceType{FarmID: 1, PondID: 2, SensorID: "DO-00A1B2", Type: TypeDissolvedOxygen, CalibrationOffset: -0.15, KeyHash: "hash"}
+	if err = deviceRepo.Store(context.Background(), payload); err != nil || payload.ID != 7 {
+		t.Errorf("unexpected result: %+v, err: %v", payload, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreDuplicatedDevice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	deviceRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM devices WHERE (id <> $1 AND sensor_id = $2 AND deleted_at IS NULL)")).
+		WithArgs(0, "DO-00A1B2").
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectRollback()
+
+	payload := &DeviceType{FarmID: 1, PondID: 2, SensorID: "DO-00A1B2", Type: TypeDissolvedOxygen}
+	if err = deviceRepo.Store(context.Background(), payload); err != errs.ErrDuplicatedResources {
+		t.Errorf("expected duplicated resources, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreReadingsIgnoringDuplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	deviceRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	measuredAt := time.Date(2024, 8, 5, 6, 0, 0, 0, time.UTC)
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sensor_readings (device_id,pond_id,type,value,raw_value,measured_at) VALUES ($1,$2,$3,$4,$5,$6),($7,$8,$9,$10,$11,$12) ON CONFLICT (device_id, measured_at) DO NOTHING")).
+		WithArgs(1, 2, TypeDissolvedOxygen, 5.2, 5.35, measuredAt, 1, 2, TypeDissolvedOxygen, 5.3, 5.45, measuredAt.Add(time.Minute)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE devices SET last_seen_at = NOW() WHERE id = $1")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	stored, err := deviceRepo.StoreReadings(context.Background(), &DeviceType{ID: 1}, []*SensorReadingType{
+		{DeviceID: 1, PondID: 2, Type: TypeDissolvedOxygen, Value: 5.2, RawValue: 5.35, MeasuredAt: measuredAt},
+		{DeviceID: 1, PondID: 2, Type: TypeDissolvedOxygen, Value: 5.3, RawValue: 5.45, MeasuredAt: measuredAt.Add(time.Minute)},
+	})
+	if err != nil || stored != 1 {
+		t.Errorf("unexpected stored readings: %d, err: %v", stored, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTDeleteMissingDevice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	deviceRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE devices SET deleted_at = NOW(), updated_at = NOW() WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).
+		WithArgs(1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err = deviceRepo.Delete(context.Background(), &deviceQuery{ID: 1, FarmID: 1}); err != errs.ErrNotFound {
+		t.Errorf("expected not found, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldRollupSinceWatermark(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	deviceRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	watermark := time.Date(2024, 8, 5, 6, 42, 0, 0, time.UTC)
+	until := watermark.Add(time.Minute)
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT rolled_until FROM sensor_rollup_watermarks WHERE resolution = $1 FOR UPDATE")).
+		WithArgs(Resolution5m).
+		WillReturnRows(sqlmock.NewRows([]string{"rolled_until"}).AddRow(watermark))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sensor_readings_5m (device_id,pond_id,type,bucket,min_value,avg_value,max_value,count) "+
+		"SELECT r.device_id, r.pond_id, r.type, to_timestamp(floor(extract(epoch from r.measured_at) / 300) * 300), "+
+		"min(r.value) min_value, avg(r.value) avg_value, max(r.value) max_value, count(*) count FROM sensor_readings r "+
+		"WHERE (r.measured_at >= $1 AND r.measured_at < $2) GROUP BY 1, 2, 3, 4 "+
+		"ON CONFLICT (device_id, pond_id, type, bucket) DO UPDATE SET min_value = excluded.min_value, avg_value = excluded.avg_value, max_value = excluded.max_value, count = excluded.count")).
+		// re-aggregate an hour back, aligned into bucket boundary
+		WithArgs(time.Date(2024, 8, 5, 5, 40, 0, 0, time.UTC), until).
+		WillReturnResult(sqlmock.NewResult(0, 13))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sensor_rollup_watermarks (resolution,rolled_until) VALUES ($1,$2) ON CONFLICT (resolution) DO UPDATE SET rolled_until = excluded.rolled_until")).
+		WithArgs(Resolution5m, until).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	count, err := deviceRepo.Rollup(context.Background(), resolutions[0], resolutions[1], until)
+	if err != nil || count != 13 {
+		t.Errorf("unexpected rolled up buckets: %d, err: %v", count, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldGetWeightedSeries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	deviceRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	from := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	// expected queries
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT r.bucket bucket, min(r.min_value) min_value, sum(r.avg_value * r.count) / sum(r.count) avg_value, "+
+		"max(r.max_value) max_value, sum(r.count)::bigint count FROM sensor_readings_1h r JOIN ponds p on r.pond_id = p.id "+
+		"WHERE (r.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND r.type = $3 AND r.bucket >= $4 AND r.bucket < $5) "+
+		"GROUP BY r.bucket ORDER BY r.bucket")).
+		WithArgs(2, 1, TypePH, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "min_value", "avg_value", "max_value", "count"}).
+			AddRow(from, 7.1, 7.4, 7.9, 24))
+
+	points, err := deviceRepo.GetSeries(context.Background(), &seriesQuery{
+		FarmID: 1, PondID: 2, Type: TypePH, From: from, To: to, Resolution: resolutions[2],
+	})
+	if err != nil || len(points) != 1 || points[0].Count != 24 {
+		t.Errorf("unexpected series: %+v, err: %v", points, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
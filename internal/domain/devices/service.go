@@ -0,0 +1,529 @@
+package devices
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// DeviceService contains public API available to be interacted with
+type DeviceService interface {
+	GetAll(context.Context, *DeviceRequestQuery) (*ListDeviceResponse, error)
+	GetOne(context.Context, *DeviceRequestQuery) (*DeviceResponse, error)
+	Create(context.Context, *DevicePayload) (*DeviceKeyResponse, error)
+	Update(context.Context, *DevicePayload) error
+	RotateKey(context.Context, *DeviceRequestQuery) (*DeviceKeyResponse, error)
+	Delete(context.Context, *DeviceRequestQuery) error
+	Ingest(context.Context, *IngestPayload) (*IngestResponse, error)
+	IngestTopic(context.Context, *TopicIngestPayload) (*IngestResponse, error)
+	GetReadings(context.Context, *SensorReadingRequestQuery) (*ListSensorReadingResponse, error)
+	GetSeries(context.Context, *SeriesRequestQuery) (*SeriesResponse, error)
+	Rollup(context.Context) error
+}
+
+type deviceService struct {
+	repo DeviceRepository
+}
+
+// NewService return an instance of DeviceService containing available usecases
+func NewService(repo DeviceRepository) DeviceService {
+	return &deviceService{repo: repo}
+}
+
+func (svc *deviceService) GetAll(ctx context.Context, params *DeviceRequestQuery) (res *ListDeviceResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &deviceQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		Type:   params.Type,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListDeviceResponse{
+		Devices: []*DeviceResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	devices, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, device := range devices {
+		res.Devices = append(res.Devices, toDeviceResponse(device))
+	}
+
+	return
+}
+
+func (svc *deviceService) GetOne(ctx context.Context, params *DeviceRequestQuery) (res *DeviceResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	device, err := svc.repo.GetOne(ctx, &deviceQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if device == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toDeviceResponse(device), nil
+}
+
+func (svc *deviceService) Create(ctx context.Context, payload *DevicePayload) (res *DeviceKeyResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toDeviceType(payload)
+	if err != nil {
+		return
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+	data.KeyHash = hashKey(key)
+
+	err = svc.repo.Store(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return &DeviceKeyResponse{ID: data.ID, SensorID: data.SensorID, Key: key}, nil
+}
+
+func (svc *deviceService) Update(ctx context.Context, payload *DevicePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toDeviceType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Update(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *deviceService) RotateKey(ctx context.Context, params *DeviceRequestQuery) (res *DeviceKeyResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	device, err := svc.repo.GetOne(ctx, &deviceQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if device == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+	device.KeyHash = hashKey(key)
+
+	err = svc.repo.UpdateKey(ctx, device)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return &DeviceKeyResponse{ID: device.ID, SensorID: device.SensorID, Key: key}, nil
+}
+
+func (svc *deviceService) Delete(ctx context.Context, params *DeviceRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	err = svc.repo.Delete(ctx, &deviceQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *deviceService) Ingest(ctx context.Context, payload *IngestPayload) (res *IngestResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.SensorID == "" || payload.Key == "" {
+		return nil, errs.ErrInvalidCred
+	}
+
+	device, err := svc.repo.GetOne(ctx, &deviceQuery{SensorID: payload.SensorID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	// unknown serial and wrong key are indistinguishable to the caller
+	if device == nil || subtle.ConstantTimeCompare([]byte(device.KeyHash), []byte(hashKey(payload.Key))) != 1 {
+		return nil, errs.ErrInvalidCred
+	}
+
+	return svc.storeReadings(ctx, device, payload.Readings)
+}
+
+// IngestTopic store readings received from message broker, the device is resolved from the topic and then
+// authenticated by its key the same way as ingestion, since any client of the broker may publish into the topic
+func (svc *deviceService) IngestTopic(ctx context.Context, payload *TopicIngestPayload) (res *IngestResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.Key == "" {
+		return nil, errs.ErrInvalidCred
+	}
+
+	devices, err := svc.repo.GetAll(ctx, &deviceQuery{
+		FarmID: payload.FarmID,
+		PondID: payload.PondID,
+		Type:   payload.Type,
+		Limit:  100,
+		Page:   1,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	var device *DeviceType
+	switch {
+	case payload.SensorID != "":
+		for _, candidate := range devices {
+			if candidate.SensorID == payload.SensorID {
+				device = candidate
+			}
+		}
+	case len(devices) == 1:
+		device = devices[0]
+	case len(devices) > 1:
+		// ambiguous without explicit sensor ID
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if device == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	if subtle.ConstantTimeCompare([]byte(device.KeyHash), []byte(hashKey(payload.Key))) != 1 {
+		return nil, errs.ErrInvalidCred
+	}
+
+	readings := payload.Readings
+	if len(readings) == 0 && payload.Value != nil {
+		readings = []*IngestReadingPayload{{Value: payload.Value, MeasuredAt: payload.MeasuredAt}}
+	}
+
+	return svc.storeReadings(ctx, device, readings)
+}
+
+// storeReadings calibrate raw readings of a device and persist them
+func (svc *deviceService) storeReadings(ctx context.Context, device *DeviceType, items []*IngestReadingPayload) (res *IngestResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if len(items) == 0 {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if len(items) > MaxBatchSize {
+		return nil, errs.ErrBadRequest
+	}
+
+	now := time.Now()
+	readings := make([]*SensorReadingType, 0, len(items))
+	for _, item := range items {
+		if item.Value == nil {
+			return nil, errs.ErrMissingRequiredAttribute
+		}
+
+		reading := &SensorReadingType{
+			DeviceID:   device.ID,
+			PondID:     device.PondID,
+			Type:       device.Type,
+			Value:      *item.Value + device.CalibrationOffset,
+			RawValue:   *item.Value,
+			MeasuredAt: item.MeasuredAt,
+		}
+
+		// reading without explicit timestamp is assumed to be taken right now
+		if reading.MeasuredAt.IsZero() {
+			reading.MeasuredAt = now
+		}
+
+		readings = append(readings, reading)
+	}
+
+	stored, err := svc.repo.StoreReadings(ctx, device, readings)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return &IngestResponse{Received: int64(len(readings)), Stored: stored}, nil
+}
+
+func (svc *deviceService) GetReadings(ctx context.Context, params *SensorReadingRequestQuery) (res *ListSensorReadingResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &readingQuery{
+		FarmID:   params.FarmID,
+		PondID:   params.PondID,
+		DeviceID: params.DeviceID,
+		Type:     params.Type,
+		From:     params.From,
+		To:       params.To,
+		Limit:    params.Limit,
+		Page:     params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &ListSensorReadingResponse{
+		Readings: []*SensorReadingResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.CountReadings(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	readings, err := svc.repo.GetReadings(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, reading := range readings {
+		res.Readings = append(res.Readings, &SensorReadingResponse{
+			DeviceID:   reading.DeviceID,
+			PondID:     reading.PondID,
+			Type:       reading.Type,
+			Value:      reading.Value,
+			RawValue:   reading.RawValue,
+			MeasuredAt: reading.MeasuredAt,
+		})
+	}
+
+	return
+}
+
+func (svc *deviceService) GetSeries(ctx context.Context, params *SeriesRequestQuery) (res *SeriesResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if params.Type == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if !slices.Contains(sensorTypes, params.Type) {
+		return nil, errs.ErrBadRequest
+	}
+
+	repoParams := &seriesQuery{
+		FarmID:   params.FarmID,
+		PondID:   params.PondID,
+		DeviceID: params.DeviceID,
+		Type:     params.Type,
+		From:     params.From,
+		To:       params.To,
+	}
+
+	// default into the last 24 hours
+	if repoParams.To.IsZero() {
+		repoParams.To = time.Now()
+	}
+
+	if repoParams.From.IsZero() {
+		repoParams.From = repoParams.To.Add(-24 * time.Hour)
+	}
+
+	if !repoParams.From.Before(repoParams.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	repoParams.Resolution, err = pickResolution(params.Resolution, repoParams.To.Sub(repoParams.From))
+	if err != nil {
+		return
+	}
+
+	points, err := svc.repo.GetSeries(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	res = &SeriesResponse{
+		Type:       repoParams.Type,
+		Resolution: repoParams.Resolution.Name,
+		From:       repoParams.From,
+		To:         repoParams.To,
+		Points:     []*SeriesPointResponse{},
+	}
+
+	for _, point := range points {
+		res.Points = append(res.Points, &SeriesPointResponse{
+			Bucket:   point.Bucket,
+			MinValue: point.MinValue,
+			AvgValue: point.AvgValue,
+			MaxValue: point.MaxValue,
+			Count:    point.Count,
+		})
+	}
+
+	return
+}
+
+// Rollup aggregate newly arrived readings into every coarser resolution, each one built from its predecessor
+func (svc *deviceService) Rollup(ctx context.Context) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	now := time.Now()
+	for i := 1; i < len(resolutions); i++ {
+		count, err := svc.repo.Rollup(ctx, resolutions[i-1], resolutions[i], now)
+		if err != nil {
+			logger.Error().Err(err).Str("resolution", resolutions[i].Name).Send()
+			return err
+		}
+
+		logger.Debug().Str("resolution", resolutions[i].Name).Int64("buckets", count).Msg("sensor readings rolled up")
+	}
+
+	return
+}
+
+// pickResolution return the requested resolution, or the finest one able to serve the time range when it's auto.
+// resolution that would yield more than MaxSeriesPoints buckets is rejected
+func pickResolution(name string, span time.Duration) (res *Resolution, err error) {
+	if name == "" {
+		name = ResolutionAuto
+	}
+
+	for _, candidate := range resolutions {
+		if name == candidate.Name || (name == ResolutionAuto && (candidate.MaxRange == 0 || span <= candidate.MaxRange)) {
+			res = candidate
+			break
+		}
+	}
+
+	if res == nil || span/res.Size > MaxSeriesPoints {
+		return nil, errs.ErrBadRequest
+	}
+
+	return
+}
+
+func toDeviceType(payload *DevicePayload) (res *DeviceType, err error) {
+	if payload.SensorID == "" || payload.PondID == 0 || payload.Type == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if !slices.Contains(sensorTypes, payload.Type) {
+		return nil, errs.ErrBadRequest
+	}
+
+	return &DeviceType{
+		ID:                payload.ID,
+		FarmID:            payload.FarmID,
+		PondID:            payload.PondID,
+		SensorID:          payload.SensorID,
+		Type:              payload.Type,
+		CalibrationOffset: payload.CalibrationOffset,
+	}, nil
+}
+
+func toDeviceResponse(device *DeviceType) *DeviceResponse {
+	res := &DeviceResponse{
+		ID:                device.ID,
+		FarmID:            device.FarmID,
+		PondID:            device.PondID,
+		SensorID:          device.SensorID,
+		Type:              device.Type,
+		CalibrationOffset: device.CalibrationOffset,
+	}
+
+	if device.LastSeenAt.Valid {
+		res.LastSeenAt = &device.LastSeenAt.Time
+	}
+
+	return res
+}
+
+// generateKey return a random hex-encoded 256 bit device key
+func generateKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// hashKey return hex-encoded sha256 of the key, device key has enough entropy thus no salt needed
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,200 @@
+package devices
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+type stubDeviceRepository struct {
+	DeviceRepository
+	device   *DeviceType
+	devices  []*DeviceType
+	stored   *DeviceType
+	readings []*SensorReadingType
+	series   *seriesQuery
+	rollups  []string
+}
+
+func (repo *stubDeviceRepository) GetOne(context.Context, *deviceQuery) (*DeviceType, error) {
+	return repo.device, nil
+}
+
+func (repo *stubDeviceRepository) GetAll(context.Context, *deviceQuery) ([]*DeviceType, error) {
+	return repo.devices, nil
+}
+
+func (repo *stubDeviceRepository) Store(_ context.Context, payload *DeviceType) error {
+	repo.stored = payload
+	return nil
+}
+
+func (repo *stubDeviceRepository) StoreReadings(_ context.Context, _ *DeviceType, readings []*SensorReadingType) (int64, error) {
+	repo.readings = readings
+	return int64(len(readings)), nil
+}
+
+func (repo *stubDeviceRepository) GetSeries(_ context.Context, params *seriesQuery) ([]*SeriesPointType, error) {
+	repo.series = params
+	return []*SeriesPointType{}, nil
+}
+
+func (repo *stubDeviceRepository) Rollup(_ context.Context, source, target *Resolution, _ time.Time) (int64, error) {
+	repo.rollups = append(repo.rollups, source.Name+">"+target.Name)
+	return 0, nil
+}
+
+func TestShouldCreateDeviceWithHashedKey(t *testing.T) {
+	repo := &stubDeviceRepository{}
+	svc := NewService(repo)
+
+	res, err := svc.Create(context.Background(), &DevicePayload{FarmID: 1, PondID: 2, SensorID: "DO-00A1B2", Type: TypeDissolvedOxygen})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(res.Key) != 64 || repo.stored.KeyHash != hashKey(res.Key) || repo.stored.KeyHash == res.Key {
+		t.Errorf("unexpected key: %+v, stored: %+v", res, repo.stored)
+	}
+}
+
+func TestShouldNOTCreateDeviceWithUnknownType(t *testing.T) {
+	svc := NewService(&stubDeviceRepository{})
+
+	_, err := svc.Create(context.Background(), &DevicePayload{FarmID: 1, PondID: 2, SensorID: "X-1", Type: "radiation"})
+	if err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+}
+
+func TestShouldIngestCalibratedReadings(t *testing.T) {
+	repo := &stubDeviceRepository{device: &DeviceType{ID: 1, PondID: 2, Type: TypeDissolvedOxygen, CalibrationOffset: -0.15, KeyHash: hashKey("secret")}}
+	svc := NewService(repo)
+
+	value := 5.35
+	res, err := svc.Ingest(context.Background(), &IngestPayload{SensorID: "DO-00A1B2", Key: "secret", Readings: []*IngestReadingPayload{{Value: &value}}})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	reading := repo.readings[0]
+	if res.Stored != 1 || reading.PondID != 2 || reading.RawValue != 5.35 || math.Abs(reading.Value-5.2) > 1e-9 || reading.MeasuredAt.IsZero() {
+		t.Errorf("unexpected reading: %+v", reading)
+	}
+}
+
+func TestShouldNOTIngestWithInvalidKey(t *testing.T) {
+	repo := &stubDeviceRepository{device: &DeviceType{ID: 1, PondID: 2, KeyHash: hashKey("secret")}}
+	svc := NewService(repo)
+
+	value := 5.35
+	if _, err := svc.Ingest(context.Background(), &IngestPayload{SensorID: "DO-00A1B2", Key: "guess", Readings: []*IngestReadingPayload{{Value: &value}}}); err != errs.ErrInvalidCred {
+		t.Errorf("expected invalid credential, got: %v", err)
+	}
+
+	if repo.readings != nil {
+		t.Errorf("readings shouldn't be stored")
+	}
+}
+
+func TestShouldNOTIngestFromUnknownDevice(t *testing.T) {
+	svc := NewService(&stubDeviceRepository{})
+
+	if _, err := svc.Ingest(context.Background(), &IngestPayload{SensorID: "DO-00A1B2", Key: "secret"}); err != errs.ErrInvalidCred {
+		t.Errorf("expected invalid credential, got: %v", err)
+	}
+}
+
+func TestShouldIngestTopicIntoTheOnlyDevice(t *testing.T) {
+	repo := &stubDeviceRepository{devices: []*DeviceType{{ID: 1, PondID: 2, Type: TypePH, CalibrationOffset: 0.1, KeyHash: hashKey("secret")}}}
+	svc := NewService(repo)
+
+	value := 7.3
+	res, err := svc.IngestTopic(context.Background(), &TopicIngestPayload{FarmID: 1, PondID: 2, Type: TypePH, Key: "secret", Value: &value})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if res.Stored != 1 || repo.readings[0].DeviceID != 1 || math.Abs(repo.readings[0].Value-7.4) > 1e-9 {
+		t.Errorf("unexpected reading: %+v", repo.readings[0])
+	}
+}
+
+func TestShouldNOTIngestTopicIntoAmbiguousDevice(t *testing.T) {
+	repo := &stubDeviceRepository{devices: []*DeviceType{{ID: 1, SensorID: "PH-1", KeyHash: hashKey("secret-1")}, {ID: 2, SensorID: "PH-2", KeyHash: hashKey("secret-2")}}}
+	svc := NewService(repo)
+
+	value := 7.3
+	if _, err := svc.IngestTopic(context.Background(), &TopicIngestPayload{FarmID: 1, PondID: 2, Type: TypePH, Key: "secret-2", Value: &value}); err != errs.ErrMissingRequiredAttribute {
+		t.Errorf("expected missing attribute, got: %v", err)
+	}
+
+	if _, err := svc.IngestTopic(context.Background(), &TopicIngestPayload{FarmID: 1, PondID: 2, Type: TypePH, SensorID: "PH-2", Key: "secret-2", Value: &value}); err != nil || repo.readings[0].DeviceID != 2 {
+		t.Errorf("expected reading stored into PH-2, err: %v", err)
+	}
+}
+
+func TestShouldNOTIngestTopicWithoutDeviceKey(t *testing.T) {
+	repo := &stubDeviceRepository{devices: []*DeviceType{{ID: 1, SensorID: "PH-1", KeyHash: hashKey("secret-1")}, {ID: 2, SensorID: "PH-2", KeyHash: hashKey("secret-2")}}}
+	svc := NewService(repo)
+
+	// key of another device in the pond doesn't authenticate the addressed one
+	value := 7.3
+	for _, key := range []string{"", "wrong", "secret-1"} {
+		if _, err := svc.IngestTopic(context.Background(), &TopicIngestPayload{FarmID: 1, PondID: 2, Type: TypePH, SensorID: "PH-2", Key: key, Value: &value}); err != errs.ErrInvalidCred {
+			t.Errorf("expected invalid credential on key %q, got: %v", key, err)
+		}
+	}
+
+	if len(repo.readings) != 0 {
+		t.Errorf("unexpected readings stored: %+v", repo.readings)
+	}
+}
+
+func TestShouldPickSeriesResolutionFromRange(t *testing.T) {
+	repo := &stubDeviceRepository{}
+	svc := NewService(repo)
+
+	to := time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC)
+	cases := map[time.Duration]string{
+		time.Hour:            ResolutionRaw,
+		24 * time.Hour:       Resolution5m,
+		30 * 24 * time.Hour:  Resolution1h,
+		365 * 24 * time.Hour: Resolution1d,
+	}
+
+	for span, expected := range cases {
+		res, err := svc.GetSeries(context.Background(), &SeriesRequestQuery{Type: TypePH, From: to.Add(-span), To: to})
+		if err != nil || res.Resolution != expected || repo.series.Resolution.Name != expected {
+			t.Errorf("expected %s resolution for %s range, got: %+v, err: %v", expected, span, res, err)
+		}
+	}
+}
+
+func TestShouldNOTGetSeriesWithTooManyPoints(t *testing.T) {
+	svc := NewService(&stubDeviceRepository{})
+
+	to := time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC)
+	if _, err := svc.GetSeries(context.Background(), &SeriesRequestQuery{
+		Type: TypePH, From: to.Add(-30 * 24 * time.Hour), To: to, Resolution: ResolutionRaw,
+	}); err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+}
+
+func TestShouldRollupEveryResolutionInOrder(t *testing.T) {
+	repo := &stubDeviceRepository{}
+	svc := NewService(repo)
+
+	if err := svc.Rollup(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if strings.Join(repo.rollups, ",") != "raw>5m,5m>1h,1h>1d" {
+		t.Errorf("unexpected rollups: %v", repo.rollups)
+	}
+}
@@ -1,20 +1,49 @@
 package domain
 
 import (
+	"net/http"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
 	ecMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
 	"github.com/nmluci/da-farm-be/internal/core/middleware"
+	"github.com/nmluci/da-farm-be/internal/domain/access"
+	"github.com/nmluci/da-farm-be/internal/domain/alerts"
+	"github.com/nmluci/da-farm-be/internal/domain/apikeys"
+	"github.com/nmluci/da-farm-be/internal/domain/audit"
+	"github.com/nmluci/da-farm-be/internal/domain/checklists"
+	"github.com/nmluci/da-farm-be/internal/domain/devices"
+	"github.com/nmluci/da-farm-be/internal/domain/equipments"
 	"github.com/nmluci/da-farm-be/internal/domain/farms"
+	"github.com/nmluci/da-farm-be/internal/domain/feeding"
+	"github.com/nmluci/da-farm-be/internal/domain/growth"
+	"github.com/nmluci/da-farm-be/internal/domain/harvest"
+	"github.com/nmluci/da-farm-be/internal/domain/maintenance"
+	"github.com/nmluci/da-farm-be/internal/domain/mortality"
+	"github.com/nmluci/da-farm-be/internal/domain/organizations"
 	"github.com/nmluci/da-farm-be/internal/domain/ping"
 	"github.com/nmluci/da-farm-be/internal/domain/ponds"
+	"github.com/nmluci/da-farm-be/internal/domain/stocking"
 	"github.com/nmluci/da-farm-be/internal/domain/telemetry"
+	"github.com/nmluci/da-farm-be/internal/domain/users"
+	"github.com/nmluci/da-farm-be/internal/domain/waterquality"
 	"github.com/rs/zerolog"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
-func InitDomain(logger zerolog.Logger, db *sqlx.DB, ec *echo.Echo) {
+// Domain expose services required by non-HTTP entrypoints
+type Domain struct {
+	DeviceService    devices.DeviceService
+	WorkOrderService maintenance.WorkOrderService
+	ChecklistService checklists.ChecklistService
+	UserService      users.UserService
+	FarmService      farms.FarmService
+	PondService      ponds.PondService
+}
+
+func InitDomain(logger zerolog.Logger, db *sqlx.DB, ec *echo.Echo, jwtConf *auth.JWTConfig) *Domain {
 	// initialize swagger api route
 	ec.GET("/api/swagger/*", echoSwagger.WrapHandler)
 
@@ -22,12 +51,44 @@ func InitDomain(logger zerolog.Logger, db *sqlx.DB, ec *echo.Echo) {
 	farmRepository := farms.NewRepository(db)
 	pondRepository := ponds.NewRepository(db)
 	telemetryRepository := telemetry.NewRepository(db)
+	readingRepository := waterquality.NewRepository(db)
+	alertRepository := alerts.NewRepository(db)
+	stockingRepository := stocking.NewRepository(db)
+	feedingRepository := feeding.NewRepository(db)
+	mortalityRepository := mortality.NewRepository(db)
+	harvestRepository := harvest.NewRepository(db)
+	sampleRepository := growth.NewRepository(db)
+	deviceRepository := devices.NewRepository(db)
+	equipmentRepository := equipments.NewRepository(db)
+	workOrderRepository := maintenance.NewRepository(db)
+	checklistRepository := checklists.NewRepository(db)
+	userRepository := users.NewRepository(db)
+	memberRepository := access.NewRepository(db)
+	apiKeyRepository := apikeys.NewRepository(db)
+	organizationRepository := organizations.NewRepository(db)
+	auditRepository := audit.NewRepository(db)
 
 	// services
 	pingService := ping.NewService()
-	farmService := farms.NewService(farmRepository)
-	pondService := ponds.NewService(pondRepository)
+	accessService := access.NewService(memberRepository)
+	auditService := audit.NewService(auditRepository, accessService)
+	farmService := farms.NewService(farmRepository, auditService)
 	telemetryService := telemetry.NewService(telemetryRepository)
+	alertService := alerts.NewService(alertRepository)
+	readingService := waterquality.NewService(readingRepository, alertService)
+	stockingService := stocking.NewService(stockingRepository)
+	mortalityService := mortality.NewService(mortalityRepository)
+	growthService := growth.NewService(sampleRepository, stockingService, mortalityService)
+	pondService := ponds.NewService(pondRepository, growthService, auditService)
+	feedingService := feeding.NewService(feedingRepository, stockingService, mortalityService, growthService)
+	harvestService := harvest.NewService(harvestRepository)
+	deviceService := devices.NewService(deviceRepository)
+	equipmentService := equipments.NewService(equipmentRepository)
+	workOrderService := maintenance.NewService(workOrderRepository, pondService)
+	checklistService := checklists.NewService(checklistRepository, farmService)
+	userService := users.NewService(userRepository, jwtConf)
+	apiKeyService := apikeys.NewService(apiKeyRepository)
+	organizationService := organizations.NewService(organizationRepository)
 
 	// initialize root for backend API
 	root := ec.Group("/api/v1",
@@ -36,11 +97,48 @@ func InitDomain(logger zerolog.Logger, db *sqlx.DB, ec *echo.Echo) {
 		middleware.RequestLogger(&logger, telemetryService),
 		middleware.HandlerLogger(&logger),
 		ecMiddleware.CORS(),
+		middleware.Authenticate(userService, apiKeyService,
+			"/api/v1/misc/ping",
+			"/api/v1/auth/login",
+			"/api/v1/auth/refresh",
+			"/api/v1/devices/:sensorID/readings", // authenticated by device key
+		),
+		middleware.ScopeGuard(
+			middleware.ScopeRule{Scope: auth.ScopeReadFarms, Prefix: "/api/v1/farms", Methods: []string{http.MethodGet, http.MethodHead, http.MethodOptions}},
+			middleware.ScopeRule{Scope: auth.ScopeWritePonds, Prefix: "/api/v1/farms/:farmID/ponds", Methods: []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}},
+			middleware.ScopeRule{Scope: auth.ScopeIngestSensors, Prefix: "/api/v1/farms/:farmID/ponds/:pondID/sensors", Methods: []string{http.MethodPost}},
+		),
+		middleware.AuthorizeFarm(accessService),
 	)
 
 	// handler
 	ping.NewController(pingService).Route(root)
-	farms.NewController(farmService).Route(root)
-	ponds.NewController(pondService).Route(root)
+	farms.NewController(farmService, accessService).Route(root)
+	ponds.NewController(pondService, accessService).Route(root)
 	telemetry.NewController(telemetryService).Route(root)
+	waterquality.NewController(readingService).Route(root)
+	alerts.NewController(alertService).Route(root)
+	stocking.NewController(stockingService).Route(root)
+	feeding.NewController(feedingService).Route(root)
+	mortality.NewController(mortalityService).Route(root)
+	harvest.NewController(harvestService).Route(root)
+	growth.NewController(growthService).Route(root)
+	devices.NewController(deviceService).Route(root)
+	equipments.NewController(equipmentService).Route(root)
+	maintenance.NewController(workOrderService).Route(root)
+	checklists.NewController(checklistService).Route(root)
+	users.NewController(userService).Route(root)
+	access.NewController(accessService).Route(root)
+	apikeys.NewController(apiKeyService).Route(root)
+	organizations.NewController(organizationService).Route(root)
+	audit.NewController(auditService).Route(root)
+
+	return &Domain{
+		DeviceService:    deviceService,
+		WorkOrderService: workOrderService,
+		ChecklistService: checklistService,
+		UserService:      userService,
+		FarmService:      farmService,
+		PondService:      pondService,
+	}
 }
@@ -0,0 +1,47 @@
+package equipments
+
+import "github.com/labstack/echo/v4"
+
+type EquipmentController struct {
+	svc EquipmentService
+}
+
+func NewController(svc EquipmentService) *EquipmentController {
+	return &EquipmentController{
+		svc: svc,
+	}
+}
+
+const (
+	equipmentBasepath = "/farms/:farmID"
+	equipmentPath     = "/equipments"
+	equipmentIDPath   = "/equipments/:equipmentID"
+	runtimePath       = "/equipments/:equipmentID/runtimes"
+	farmEnergyPath    = "/energy"
+	pondEnergyPath    = "/ponds/:pondID/energy"
+)
+
+func (ec *EquipmentController) Route(grp *echo.Group) {
+	subrouter := grp.Group(equipmentBasepath)
+
+	subrouter.GET(equipmentPath, HandleGetAllEquipment(ec.svc.GetAll))
+	subrouter.OPTIONS(equipmentPath, HandleGetAllEquipment(ec.svc.GetAll))
+	subrouter.GET(equipmentIDPath, HandleGetOneEquipment(ec.svc.GetOne))
+	subrouter.OPTIONS(equipmentIDPath, HandleGetOneEquipment(ec.svc.GetOne))
+	subrouter.POST(equipmentPath, HandleCreateEquipment(ec.svc.Create))
+	subrouter.OPTIONS(equipmentPath, HandleCreateEquipment(ec.svc.Create))
+	subrouter.PUT(equipmentIDPath, HandleUpdateEquipment(ec.svc.Update))
+	subrouter.OPTIONS(equipmentIDPath, HandleUpdateEquipment(ec.svc.Update))
+	subrouter.DELETE(equipmentIDPath, HandleDeleteEquipment(ec.svc.Delete))
+	subrouter.OPTIONS(equipmentIDPath, HandleDeleteEquipment(ec.svc.Delete))
+	subrouter.GET(runtimePath, HandleGetAllRuntime(ec.svc.GetRuntimes))
+	subrouter.OPTIONS(runtimePath, HandleGetAllRuntime(ec.svc.GetRuntimes))
+	subrouter.POST(runtimePath, HandleSwitchEquipment(ec.svc.Switch))
+	subrouter.OPTIONS(runtimePath, HandleSwitchEquipment(ec.svc.Switch))
+	subrouter.GET(farmEnergyPath, HandleGetFarmEnergy(ec.svc.GetFarmEnergy))
+	subrouter.OPTIONS(farmEnergyPath, HandleGetFarmEnergy(ec.svc.GetFarmEnergy))
+	subrouter.GET(pondEnergyPath, HandleGetPondEnergy(ec.svc.GetPondEnergy))
+	subrouter.OPTIONS(pondEnergyPath, HandleGetPondEnergy(ec.svc.GetPondEnergy))
+
+	return
+}
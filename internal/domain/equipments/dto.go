@@ -0,0 +1,121 @@
+package equipments
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// EquipmentRequestQuery represent query parameters fetch from request
+type EquipmentRequestQuery struct {
+	ID     int64  `param:"equipmentID" example:"1"`
+	FarmID int64  `param:"farmID" example:"1"`
+	PondID int64  `query:"pond_id" example:"1"`
+	Type   string `query:"type" example:"aerator"`
+	Status string `query:"status" example:"active"`
+	Limit  uint64 `query:"limit" example:"100"`
+	Page   uint64 `query:"page" example:"2"`
+}
+
+// EquipmentPayload represent payload fetch from request body
+type EquipmentPayload struct {
+	ID          int64      `param:"equipmentID" json:"-" example:"1"`
+	FarmID      int64      `param:"farmID" json:"-" example:"1"`
+	PondID      int64      `json:"pond_id" example:"1"` // leave empty for farm-wide equipment
+	Name        string     `json:"name" example:"Paddlewheel A1"`
+	Type        string     `json:"type" example:"aerator" enums:"aerator,pump,feeder,blower"`
+	Make        string     `json:"make" example:"Taiwan Paddlewheel PW-2HP"`
+	PowerRating float64    `json:"power_rating" example:"1.5"` // in kW
+	InstallDate *time.Time `json:"install_date" example:"2024-01-15T00:00:00Z"`
+	Status      string     `json:"status" example:"active" enums:"active,inactive,broken,retired"` // default to active
+}
+
+// EquipmentResponse represent domain response for Equipment entity
+type EquipmentResponse struct {
+	ID          int64      `json:"id" example:"1"`
+	FarmID      int64      `json:"farm_id" example:"1"`
+	PondID      *int64     `json:"pond_id" example:"1"`
+	Name        string     `json:"name" example:"Paddlewheel A1"`
+	Type        string     `json:"type" example:"aerator"`
+	Make        string     `json:"make" example:"Taiwan Paddlewheel PW-2HP"`
+	PowerRating float64    `json:"power_rating" example:"1.5"`
+	InstallDate *time.Time `json:"install_date" example:"2024-01-15T00:00:00Z"`
+	Status      string     `json:"status" example:"active"`
+	Running     bool       `json:"running" example:"true"`
+	RunHours    float64    `json:"run_hours" example:"1250.5"` // cumulative since installed
+}
+
+// ListEquipmentResponse represent domain response for bulk Equipment entities
+type ListEquipmentResponse struct {
+	Equipments []*EquipmentResponse   `json:"equipments"`
+	Meta       httpres.ListPagination `json:"meta"`
+}
+
+// SwitchPayload represent on/off event of equipment
+type SwitchPayload struct {
+	ID     int64     `param:"equipmentID" json:"-" example:"1"`
+	FarmID int64     `param:"farmID" json:"-" example:"1"`
+	State  string    `json:"state" example:"on" enums:"on,off"`
+	At     time.Time `json:"at" example:"2024-08-05T18:00:00Z"` // default to now
+}
+
+// RuntimeRequestQuery represent query parameters fetch from request
+type RuntimeRequestQuery struct {
+	ID     int64     `param:"equipmentID" example:"1"`
+	FarmID int64     `param:"farmID" example:"1"`
+	From   time.Time `query:"from" example:"2024-08-01T00:00:00Z"`
+	To     time.Time `query:"to" example:"2024-09-01T00:00:00Z"`
+	Limit  uint64    `query:"limit" example:"100"`
+	Page   uint64    `query:"page" example:"2"`
+}
+
+// RuntimeResponse represent domain response for a single on/off cycle
+type RuntimeResponse struct {
+	StartedAt time.Time  `json:"started_at" example:"2024-08-05T18:00:00Z"`
+	StoppedAt *time.Time `json:"stopped_at" example:"2024-08-06T06:00:00Z"` // null while still running
+	Hours     float64    `json:"hours" example:"12"`
+}
+
+// ListRuntimeResponse represent domain response for bulk runtime entities
+type ListRuntimeResponse struct {
+	Runtimes []*RuntimeResponse     `json:"runtimes"`
+	Meta     httpres.ListPagination `json:"meta"`
+}
+
+// EnergyRequestQuery represent query parameters fetch from request
+type EnergyRequestQuery struct {
+	FarmID int64     `param:"farmID" example:"1"`
+	PondID int64     `param:"pondID" example:"1"`
+	From   time.Time `query:"from" example:"2024-08-01T00:00:00Z"`
+	To     time.Time `query:"to" example:"2024-09-01T00:00:00Z"`
+}
+
+// EquipmentEnergyResponse represent energy consumed by a single equipment
+type EquipmentEnergyResponse struct {
+	ID          int64   `json:"id" example:"1"`
+	Name        string  `json:"name" example:"Paddlewheel A1"`
+	Type        string  `json:"type" example:"aerator"`
+	PowerRating float64 `json:"power_rating" example:"1.5"`
+	RunHours    float64 `json:"run_hours" example:"360"`
+	Energy      float64 `json:"energy" example:"540"` // in kWh
+}
+
+// PondEnergyResponse represent energy consumed by equipments of a pond within the time window
+type PondEnergyResponse struct {
+	PondID     *int64                     `json:"pond_id" example:"1"` // null for farm-wide equipments
+	From       time.Time                  `json:"from" example:"2024-08-01T00:00:00Z"`
+	To         time.Time                  `json:"to" example:"2024-09-01T00:00:00Z"`
+	RunHours   float64                    `json:"run_hours" example:"720"`
+	Energy     float64                    `json:"energy" example:"1080"` // in kWh
+	Equipments []*EquipmentEnergyResponse `json:"equipments"`
+}
+
+// FarmEnergyResponse represent energy consumed by every equipment of a farm, broken down per pond
+type FarmEnergyResponse struct {
+	FarmID   int64                 `json:"farm_id" example:"1"`
+	From     time.Time             `json:"from" example:"2024-08-01T00:00:00Z"`
+	To       time.Time             `json:"to" example:"2024-09-01T00:00:00Z"`
+	RunHours float64               `json:"run_hours" example:"2160"`
+	Energy   float64               `json:"energy" example:"3240"` // in kWh
+	Ponds    []*PondEnergyResponse `json:"ponds"`
+}
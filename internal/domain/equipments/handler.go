@@ -0,0 +1,341 @@
+package equipments
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllEquipmentHandler func(context.Context, *EquipmentRequestQuery) (*ListEquipmentResponse, error)
+
+// Get All Equipment godoc
+//
+//	@Summary	get all equipments of a farm along with their cumulative run hours
+//	@Tags		Equipment
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pond_id	query		int		false	"only return equipment attached into the pond"
+//	@Param		type	query		string	false	"only return equipment of the type"
+//	@Param		status	query		string	false	"only return equipment with the status"
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListEquipmentResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/equipments [get]
+func HandleGetAllEquipment(handler GetAllEquipmentHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &EquipmentRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneEquipmentHandler func(context.Context, *EquipmentRequestQuery) (*EquipmentResponse, error)
+
+// Get One Equipment godoc
+//
+//	@Summary	get specific equipment by ID
+//	@Tags		Equipment
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		equipmentID	path		int	true	"Equipment ID"
+//	@Success	200				{object}	EquipmentResponse
+//	@Failure	404				{object}	httpres.ErrorResponse
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/equipments/{equipmentID} [get]
+func HandleGetOneEquipment(handler GetOneEquipmentHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &EquipmentRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateEquipmentHandler func(context.Context, *EquipmentPayload) error
+
+// CreateEquipment godoc
+//
+//	@Summary	register a new equipment into a farm or one of its ponds
+//	@Tags		Equipment
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int					true	"Farm ID"
+//	@Param		payload	body		EquipmentPayload	true	"equipment payload"
+//	@Success	201		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"unknown type or status"
+//	@Failure	404		{object}	httpres.ErrorResponse	"pond not existed in the farm"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/equipments [post]
+func HandleCreateEquipment(handler CreateEquipmentHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &EquipmentPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateEquipmentHandler func(context.Context, *EquipmentPayload) error
+
+// Update Equipment godoc
+//
+//	@Summary	update equipment data, ex: move it into another pond or retire it
+//	@Tags		Equipment
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int					true	"Farm ID"
+//	@Param		equipmentID	path		int					true	"Equipment ID"
+//	@Param		payload		body		EquipmentPayload	true	"equipment payload"
+//	@Success	200				{object}	string
+//	@Failure	400				{object}	httpres.ErrorResponse	"unknown type or status"
+//	@Failure	404				{object}	httpres.ErrorResponse	"equipment or pond not existed"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/equipments/{equipmentID} [put]
+func HandleUpdateEquipment(handler UpdateEquipmentHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &EquipmentPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteEquipmentHandler func(context.Context, *EquipmentRequestQuery) error
+
+// DeleteEquipment godoc
+//
+//	@Summary	delete specific equipment by ID
+//	@Tags		Equipment
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		equipmentID	path		int	true	"Equipment ID"
+//	@Success	200				{object}	string
+//	@Failure	404				{object}	httpres.ErrorResponse	"equipment not existed"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/equipments/{equipmentID} [delete]
+func HandleDeleteEquipment(handler DeleteEquipmentHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &EquipmentRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type SwitchEquipmentHandler func(context.Context, *SwitchPayload) error
+
+// Switch Equipment godoc
+//
+//	@Summary	log equipment being turned on or off, switching into its current state is ignored
+//	@Tags		Equipment
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int				true	"Farm ID"
+//	@Param		equipmentID	path		int				true	"Equipment ID"
+//	@Param		payload		body		SwitchPayload	true	"on/off event"
+//	@Success	201				{object}	string
+//	@Failure	400				{object}	httpres.ErrorResponse	"unknown state or equipment isn't active"
+//	@Failure	404				{object}	httpres.ErrorResponse	"equipment not existed"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/equipments/{equipmentID}/runtimes [post]
+func HandleSwitchEquipment(handler SwitchEquipmentHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SwitchPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type GetAllRuntimeHandler func(context.Context, *RuntimeRequestQuery) (*ListRuntimeResponse, error)
+
+// Get All Runtime godoc
+//
+//	@Summary	get on/off cycles of an equipment
+//	@Tags		Equipment
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		equipmentID	path		int		true	"Equipment ID"
+//	@Param		from		query		string	false	"start of time window, RFC3339"
+//	@Param		to			query		string	false	"end of time window, RFC3339"
+//	@Param		limit		query		string	false	"number of entity per page"
+//	@Param		page		query		string	false	"n-th page"
+//	@Success	200				{object}	ListRuntimeResponse
+//	@Failure	400				{object}	httpres.ErrorResponse	"invalid time window"
+//	@Failure	404				{object}	httpres.ErrorResponse
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/equipments/{equipmentID}/runtimes [get]
+func HandleGetAllRuntime(handler GetAllRuntimeHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &RuntimeRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetFarmEnergyHandler func(context.Context, *EnergyRequestQuery) (*FarmEnergyResponse, error)
+
+// Get Farm Energy godoc
+//
+//	@Summary	get energy consumed by equipments of a farm within a time window, broken down per pond
+//	@Tags		Equipment
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		from	query		string	false	"start of time window, RFC3339, default to 30 days before to"
+//	@Param		to		query		string	false	"end of time window, RFC3339, default to now"
+//	@Success	200		{object}	FarmEnergyResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid time window"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/energy [get]
+func HandleGetFarmEnergy(handler GetFarmEnergyHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &EnergyRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetPondEnergyHandler func(context.Context, *EnergyRequestQuery) (*PondEnergyResponse, error)
+
+// Get Pond Energy godoc
+//
+//	@Summary	get energy consumed by equipments of a pond within a time window
+//	@Tags		Equipment
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pondID	path		int		true	"Pond ID"
+//	@Param		from	query		string	false	"start of time window, RFC3339, default to 30 days before to"
+//	@Param		to		query		string	false	"end of time window, RFC3339, default to now"
+//	@Success	200		{object}	PondEnergyResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid time window"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/energy [get]
+func HandleGetPondEnergy(handler GetPondEnergyHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &EnergyRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
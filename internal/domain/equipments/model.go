@@ -0,0 +1,66 @@
+package equipments
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	TypeAerator = "aerator"
+	TypePump    = "pump"
+	TypeFeeder  = "feeder"
+	TypeBlower  = "blower"
+)
+
+const (
+	StatusActive   = "active"
+	StatusInactive = "inactive"
+	StatusBroken   = "broken"
+	StatusRetired  = "retired"
+)
+
+const (
+	StateOn  = "on"
+	StateOff = "off"
+)
+
+// equipmentTypes list every supported kind of equipment
+var equipmentTypes = []string{TypeAerator, TypePump, TypeFeeder, TypeBlower}
+
+// equipmentStatuses list every lifecycle status of equipment, only active equipment may be switched on
+var equipmentStatuses = []string{StatusActive, StatusInactive, StatusBroken, StatusRetired}
+
+// DefaultEnergyWindow is the time range of energy consumption when not specified, roughly a billing cycle
+const DefaultEnergyWindow = 30 * 24 * time.Hour
+
+type EquipmentType struct {
+	ID          int64         `db:"id"`
+	FarmID      int64         `db:"farm_id"`
+	PondID      sql.NullInt64 `db:"pond_id"`
+	Name        string        `db:"name"`
+	Type        string        `db:"type"`
+	Make        string        `db:"make"`
+	PowerRating float64       `db:"power_rating"`
+	InstallDate sql.NullTime  `db:"install_date"`
+	Status      string        `db:"status"`
+	Running     bool          `db:"running"`
+	RunSeconds  float64       `db:"run_seconds"` // cumulative over every runtime
+}
+
+// RuntimeType represent a single on/off cycle of equipment, StoppedAt is null while it's running
+type RuntimeType struct {
+	ID          int64        `db:"id"`
+	EquipmentID int64        `db:"equipment_id"`
+	StartedAt   time.Time    `db:"started_at"`
+	StoppedAt   sql.NullTime `db:"stopped_at"`
+}
+
+// EnergyType represent run time of equipment clipped into a time window
+type EnergyType struct {
+	ID          int64         `db:"id"`
+	PondID      sql.NullInt64 `db:"pond_id"`
+	Name        string        `db:"name"`
+	Type        string        `db:"type"`
+	PowerRating float64       `db:"power_rating"`
+	RunSeconds  float64       `db:"run_seconds"`
+}
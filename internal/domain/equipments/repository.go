@@ -0,0 +1,451 @@
+package equipments
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// EquipmentRepository contain contract that defined all necessary public function available to be interact with
+type EquipmentRepository interface {
+	GetAll(context.Context, *equipmentQuery) ([]*EquipmentType, error)
+	Count(context.Context, *equipmentQuery) (uint64, error)
+	GetOne(context.Context, *equipmentQuery) (*EquipmentType, error)
+	Store(context.Context, *EquipmentType) error
+	Update(context.Context, *EquipmentType) error
+	Delete(context.Context, *equipmentQuery) error
+	SwitchOn(context.Context, *RuntimeType) error
+	SwitchOff(context.Context, *RuntimeType) error
+	GetRuntimes(context.Context, *runtimeQuery) ([]*RuntimeType, error)
+	CountRuntimes(context.Context, *runtimeQuery) (uint64, error)
+	GetEnergy(context.Context, *energyQuery) ([]*EnergyType, error)
+}
+
+type equipmentRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of equipmentRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) EquipmentRepository {
+	return &equipmentRepository{db: db}
+}
+
+type equipmentQuery struct {
+	ID, FarmID, PondID int64
+	Type, Status       string
+	Limit, Page        uint64
+}
+
+type runtimeQuery struct {
+	ID, FarmID  int64
+	From, To    time.Time
+	Limit, Page uint64
+}
+
+type energyQuery struct {
+	FarmID, PondID int64
+	From, To       time.Time
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var equipmentColumns = []string{
+	"e.id", "e.farm_id", "e.pond_id", "e.name", "e.type", "e.make", "e.power_rating", "e.install_date", "e.status",
+	"exists(select 1 from equipment_runtimes l where l.equipment_id = e.id and l.stopped_at is null) running",
+	"coalesce((select sum(extract(epoch from coalesce(l.stopped_at, now()) - l.started_at)) from equipment_runtimes l where l.equipment_id = e.id), 0) run_seconds",
+}
+
+var runtimeColumns = []string{"l.id", "l.equipment_id", "l.started_at", "l.stopped_at"}
+
+func (params *equipmentQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"e.farm_id": params.FarmID},
+		squirrel.Eq{"e.deleted_at": nil},
+	}
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"e.id": params.ID})
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"e.pond_id": params.PondID})
+	}
+
+	if params.Type != "" {
+		cond = append(cond, squirrel.Eq{"e.type": params.Type})
+	}
+
+	if params.Status != "" {
+		cond = append(cond, squirrel.Eq{"e.status": params.Status})
+	}
+
+	return cond
+}
+
+// filter match every runtime overlapping the time window
+func (params *runtimeQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"l.equipment_id": params.ID},
+		squirrel.Eq{"e.farm_id": params.FarmID},
+		squirrel.Eq{"e.deleted_at": nil},
+	}
+
+	if !params.From.IsZero() {
+		cond = append(cond, squirrel.Or{
+			squirrel.Eq{"l.stopped_at": nil},
+			squirrel.Gt{"l.stopped_at": params.From},
+		})
+	}
+
+	if !params.To.IsZero() {
+		cond = append(cond, squirrel.Lt{"l.started_at": params.To})
+	}
+
+	return cond
+}
+
+func (params *energyQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"e.farm_id": params.FarmID},
+		squirrel.Eq{"e.deleted_at": nil},
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"e.pond_id": params.PondID})
+	}
+
+	return cond
+}
+
+func (repo *equipmentRepository) GetAll(ctx context.Context, params *equipmentQuery) (res []*EquipmentType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(equipmentColumns...).From("equipments e").
+		Where(params.filter()).
+		OrderBy("e.id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*EquipmentType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &EquipmentType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *equipmentRepository) Count(ctx context.Context, params *equipmentQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("equipments e").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *equipmentRepository) GetOne(ctx context.Context, params *equipmentQuery) (res *EquipmentType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(equipmentColumns...).From("equipments e").
+		Where(params.filter()).ToSql()
+
+	res = &EquipmentType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure the pond equipment is attached into belongs to the farm
+func (repo *equipmentRepository) validate(ctx context.Context, tx *sqlx.Tx, payload *EquipmentType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	// farm-wide equipment
+	if !payload.PondID.Valid {
+		return
+	}
+
+	var count int64
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+		squirrel.Eq{"id": payload.PondID.Int64},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate pond data existence")
+		return
+	}
+
+	// if selected pond doesn't exists within the farm, bail out from here
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+// Store save a new equipment, the generated ID will be assigned back into payload
+func (repo *equipmentRepository) Store(ctx context.Context, payload *EquipmentType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("equipments").
+		Columns("farm_id", "pond_id", "name", "type", "make", "power_rating", "install_date", "status").
+		Values(payload.FarmID, payload.PondID, payload.Name, payload.Type, payload.Make, payload.PowerRating, payload.InstallDate, payload.Status).
+		Suffix("RETURNING id").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *equipmentRepository) Update(ctx context.Context, payload *EquipmentType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("equipments").SetMap(map[string]interface{}{
+		"pond_id":      payload.PondID,
+		"name":         payload.Name,
+		"type":         payload.Type,
+		"make":         payload.Make,
+		"power_rating": payload.PowerRating,
+		"install_date": payload.InstallDate,
+		"status":       payload.Status,
+		"updated_at":   squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *equipmentRepository) Delete(ctx context.Context, params *equipmentQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("equipments").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("equipment doesn't exists")
+		return
+	}
+
+	return
+}
+
+// SwitchOn open a new runtime, it's a no-op when the equipment is already running
+func (repo *equipmentRepository) SwitchOn(ctx context.Context, payload *RuntimeType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Insert("equipment_runtimes").
+		Columns("equipment_id", "started_at").
+		Values(payload.EquipmentID, payload.StartedAt).
+		Suffix("ON CONFLICT (equipment_id) WHERE stopped_at IS NULL DO NOTHING").ToSql()
+
+	if _, err = repo.db.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	return
+}
+
+// SwitchOff close the running runtime, it's a no-op when the equipment isn't running
+func (repo *equipmentRepository) SwitchOff(ctx context.Context, payload *RuntimeType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("equipment_runtimes").SetMap(map[string]interface{}{
+		"stopped_at": payload.StoppedAt,
+		"updated_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"equipment_id": payload.EquipmentID},
+		squirrel.Eq{"stopped_at": nil},
+		squirrel.LtOrEq{"started_at": payload.StoppedAt},
+	}).ToSql()
+
+	if _, err = repo.db.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	return
+}
+
+func (repo *equipmentRepository) GetRuntimes(ctx context.Context, params *runtimeQuery) (res []*RuntimeType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(runtimeColumns...).From("equipment_runtimes l").
+		Join("equipments e on l.equipment_id = e.id").
+		Where(params.filter()).
+		OrderBy("l.started_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*RuntimeType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &RuntimeType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *equipmentRepository) CountRuntimes(ctx context.Context, params *runtimeQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("equipment_runtimes l").
+		Join("equipments e on l.equipment_id = e.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+// GetEnergy return run time of every equipment clipped into the time window, a still running equipment is counted up to now
+func (repo *equipmentRepository) GetEnergy(ctx context.Context, params *energyQuery) (res []*EnergyType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("e.id", "e.pond_id", "e.name", "e.type", "e.power_rating").
+		Column(squirrel.Expr("coalesce(sum(greatest(extract(epoch from least(coalesce(l.stopped_at, now()), ?) - greatest(l.started_at, ?)), 0)), 0) run_seconds", params.To, params.From)).
+		From("equipments e").
+		LeftJoin("equipment_runtimes l on l.equipment_id = e.id and l.started_at < ? and (l.stopped_at is null or l.stopped_at > ?)", params.To, params.From).
+		Where(params.filter()).
+		GroupBy("e.id").
+		OrderBy("e.pond_id", "e.id").ToSql()
+
+	res = []*EnergyType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &EnergyType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
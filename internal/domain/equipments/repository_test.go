@@ -0,0 +1,95 @@
+package equipments
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldNOTStoreEquipmentIntoForeignPond(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	equipmentRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	err = equipmentRepo.Store(context.Background(), &EquipmentType{
+		FarmID: 1, PondID: sql.NullInt64{Int64: 2, Valid: true}, Name: "Paddlewheel A1", Type: TypeAerator, Status: StatusActive,
+	})
+	if err != errs.ErrNotFound {
+		t.Errorf("expected not found, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldSwitchOnIgnoringRunningEquipment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	equipmentRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	startedAt := time.Date(2024, 8, 5, 18, 0, 0, 0, time.UTC)
+
+	// expected queries
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO equipment_runtimes (equipment_id,started_at) VALUES ($1,$2) ON CONFLICT (equipment_id) WHERE stopped_at IS NULL DO NOTHING")).
+		WithArgs(1, startedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err = equipmentRepo.SwitchOn(context.Background(), &RuntimeType{EquipmentID: 1, StartedAt: startedAt}); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldGetEnergyWithinWindow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	equipmentRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	from := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(DefaultEnergyWindow)
+
+	// expected queries
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT e.id, e.pond_id, e.name, e.type, e.power_rating, "+
+		"coalesce(sum(greatest(extract(epoch from least(coalesce(l.stopped_at, now()), $1) - greatest(l.started_at, $2)), 0)), 0) run_seconds "+
+		"FROM equipments e LEFT JOIN equipment_runtimes l on l.equipment_id = e.id and l.started_at < $3 and (l.stopped_at is null or l.stopped_at > $4) "+
+		"WHERE (e.farm_id = $5 AND e.deleted_at IS NULL AND e.pond_id = $6) GROUP BY e.id ORDER BY e.pond_id, e.id")).
+		WithArgs(to, from, to, from, 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "pond_id", "name", "type", "power_rating", "run_seconds"}).
+			AddRow(1, 2, "Paddlewheel A1", TypeAerator, 1.5, 7200))
+
+	res, err := equipmentRepo.GetEnergy(context.Background(), &energyQuery{FarmID: 1, PondID: 2, From: from, To: to})
+	if err != nil || len(res) != 1 || res[0].RunSeconds != 7200 {
+		t.Errorf("unexpected energy: %+v, err: %v", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
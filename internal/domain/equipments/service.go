@@ -0,0 +1,444 @@
+package equipments
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// EquipmentService contains public API available to be interacted with
+type EquipmentService interface {
+	GetAll(context.Context, *EquipmentRequestQuery) (*ListEquipmentResponse, error)
+	GetOne(context.Context, *EquipmentRequestQuery) (*EquipmentResponse, error)
+	Create(context.Context, *EquipmentPayload) error
+	Update(context.Context, *EquipmentPayload) error
+	Delete(context.Context, *EquipmentRequestQuery) error
+	Switch(context.Context, *SwitchPayload) error
+	GetRuntimes(context.Context, *RuntimeRequestQuery) (*ListRuntimeResponse, error)
+	GetPondEnergy(context.Context, *EnergyRequestQuery) (*PondEnergyResponse, error)
+	GetFarmEnergy(context.Context, *EnergyRequestQuery) (*FarmEnergyResponse, error)
+}
+
+type equipmentService struct {
+	repo EquipmentRepository
+}
+
+// NewService return an instance of EquipmentService containing available usecases
+func NewService(repo EquipmentRepository) EquipmentService {
+	return &equipmentService{repo: repo}
+}
+
+func (svc *equipmentService) GetAll(ctx context.Context, params *EquipmentRequestQuery) (res *ListEquipmentResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &equipmentQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		Type:   params.Type,
+		Status: params.Status,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListEquipmentResponse{
+		Equipments: []*EquipmentResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	equipments, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, equipment := range equipments {
+		res.Equipments = append(res.Equipments, toEquipmentResponse(equipment))
+	}
+
+	return
+}
+
+func (svc *equipmentService) GetOne(ctx context.Context, params *EquipmentRequestQuery) (res *EquipmentResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	equipment, err := svc.repo.GetOne(ctx, &equipmentQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if equipment == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toEquipmentResponse(equipment), nil
+}
+
+func (svc *equipmentService) Create(ctx context.Context, payload *EquipmentPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toEquipmentType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Store(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *equipmentService) Update(ctx context.Context, payload *EquipmentPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toEquipmentType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Update(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *equipmentService) Delete(ctx context.Context, params *EquipmentRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	err = svc.repo.Delete(ctx, &equipmentQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// Switch record equipment being turned on or off, switching into its current state is a no-op
+// thus a controller may safely resend the event
+func (svc *equipmentService) Switch(ctx context.Context, payload *SwitchPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.State != StateOn && payload.State != StateOff {
+		return errs.ErrBadRequest
+	}
+
+	equipment, err := svc.repo.GetOne(ctx, &equipmentQuery{ID: payload.ID, FarmID: payload.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if equipment == nil {
+		return errs.ErrNotFound
+	}
+
+	at := payload.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if payload.State == StateOff {
+		err = svc.repo.SwitchOff(ctx, &RuntimeType{EquipmentID: equipment.ID, StoppedAt: sql.NullTime{Time: at, Valid: true}})
+		if err != nil {
+			logger.Error().Err(err).Send()
+		}
+
+		return
+	}
+
+	// broken or retired unit can't be running
+	if equipment.Status != StatusActive {
+		return errs.ErrBadRequest
+	}
+
+	err = svc.repo.SwitchOn(ctx, &RuntimeType{EquipmentID: equipment.ID, StartedAt: at})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *equipmentService) GetRuntimes(ctx context.Context, params *RuntimeRequestQuery) (res *ListRuntimeResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &runtimeQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		From:   params.From,
+		To:     params.To,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &ListRuntimeResponse{
+		Runtimes: []*RuntimeResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.CountRuntimes(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	runtimes, err := svc.repo.GetRuntimes(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	now := time.Now()
+	for _, runtime := range runtimes {
+		item := &RuntimeResponse{StartedAt: runtime.StartedAt}
+
+		// still running, count up to now
+		stoppedAt := now
+		if runtime.StoppedAt.Valid {
+			item.StoppedAt = &runtime.StoppedAt.Time
+			stoppedAt = runtime.StoppedAt.Time
+		}
+		item.Hours = stoppedAt.Sub(runtime.StartedAt).Hours()
+
+		res.Runtimes = append(res.Runtimes, item)
+	}
+
+	return
+}
+
+func (svc *equipmentService) GetPondEnergy(ctx context.Context, params *EnergyRequestQuery) (res *PondEnergyResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams, err := toEnergyQuery(params)
+	if err != nil {
+		return
+	}
+
+	items, err := svc.repo.GetEnergy(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	res = &PondEnergyResponse{
+		PondID:     &params.PondID,
+		From:       repoParams.From,
+		To:         repoParams.To,
+		Equipments: []*EquipmentEnergyResponse{},
+	}
+
+	for _, item := range items {
+		res.add(item)
+	}
+
+	return
+}
+
+// GetFarmEnergy return energy consumed by every equipment of a farm, grouped per pond. Farm-wide equipments are
+// grouped together with null pond ID
+func (svc *equipmentService) GetFarmEnergy(ctx context.Context, params *EnergyRequestQuery) (res *FarmEnergyResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams, err := toEnergyQuery(params)
+	if err != nil {
+		return
+	}
+	repoParams.PondID = 0
+
+	items, err := svc.repo.GetEnergy(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	res = &FarmEnergyResponse{
+		FarmID: params.FarmID,
+		From:   repoParams.From,
+		To:     repoParams.To,
+		Ponds:  []*PondEnergyResponse{},
+	}
+
+	ponds := map[int64]*PondEnergyResponse{}
+	for _, item := range items {
+		pond, ok := ponds[item.PondID.Int64]
+		if !ok {
+			pond = &PondEnergyResponse{
+				From:       repoParams.From,
+				To:         repoParams.To,
+				Equipments: []*EquipmentEnergyResponse{},
+			}
+
+			if item.PondID.Valid {
+				pond.PondID = &item.PondID.Int64
+			}
+
+			ponds[item.PondID.Int64] = pond
+			res.Ponds = append(res.Ponds, pond)
+		}
+
+		equipment := pond.add(item)
+		res.RunHours += equipment.RunHours
+		res.Energy += equipment.Energy
+	}
+
+	return
+}
+
+// add accumulate energy consumed by the equipment into the pond
+func (pond *PondEnergyResponse) add(item *EnergyType) *EquipmentEnergyResponse {
+	runHours := item.RunSeconds / time.Hour.Seconds()
+
+	equipment := &EquipmentEnergyResponse{
+		ID:          item.ID,
+		Name:        item.Name,
+		Type:        item.Type,
+		PowerRating: item.PowerRating,
+		RunHours:    runHours,
+		Energy:      runHours * item.PowerRating,
+	}
+
+	pond.Equipments = append(pond.Equipments, equipment)
+	pond.RunHours += equipment.RunHours
+	pond.Energy += equipment.Energy
+
+	return equipment
+}
+
+// toEnergyQuery default the time window into the last DefaultEnergyWindow
+func toEnergyQuery(params *EnergyRequestQuery) (res *energyQuery, err error) {
+	res = &energyQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		From:   params.From,
+		To:     params.To,
+	}
+
+	if res.To.IsZero() {
+		res.To = time.Now()
+	}
+
+	if res.From.IsZero() {
+		res.From = res.To.Add(-DefaultEnergyWindow)
+	}
+
+	if !res.From.Before(res.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	return
+}
+
+func toEquipmentType(payload *EquipmentPayload) (res *EquipmentType, err error) {
+	if payload.Name == "" || payload.Type == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if payload.Status == "" {
+		payload.Status = StatusActive
+	}
+
+	if !slices.Contains(equipmentTypes, payload.Type) || !slices.Contains(equipmentStatuses, payload.Status) || payload.PowerRating < 0 {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &EquipmentType{
+		ID:          payload.ID,
+		FarmID:      payload.FarmID,
+		Name:        payload.Name,
+		Type:        payload.Type,
+		Make:        payload.Make,
+		PowerRating: payload.PowerRating,
+		Status:      payload.Status,
+	}
+
+	if payload.PondID != 0 {
+		res.PondID = sql.NullInt64{Int64: payload.PondID, Valid: true}
+	}
+
+	if payload.InstallDate != nil {
+		res.InstallDate = sql.NullTime{Time: *payload.InstallDate, Valid: true}
+	}
+
+	return
+}
+
+func toEquipmentResponse(equipment *EquipmentType) *EquipmentResponse {
+	res := &EquipmentResponse{
+		ID:          equipment.ID,
+		FarmID:      equipment.FarmID,
+		Name:        equipment.Name,
+		Type:        equipment.Type,
+		Make:        equipment.Make,
+		PowerRating: equipment.PowerRating,
+		Status:      equipment.Status,
+		Running:     equipment.Running,
+		RunHours:    equipment.RunSeconds / time.Hour.Seconds(),
+	}
+
+	if equipment.PondID.Valid {
+		res.PondID = &equipment.PondID.Int64
+	}
+
+	if equipment.InstallDate.Valid {
+		res.InstallDate = &equipment.InstallDate.Time
+	}
+
+	return res
+}
@@ -0,0 +1,95 @@
+package equipments
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+type stubEquipmentRepository struct {
+	EquipmentRepository
+	equipment *EquipmentType
+	energy    []*EnergyType
+	switched  *RuntimeType
+}
+
+func (repo *stubEquipmentRepository) GetOne(context.Context, *equipmentQuery) (*EquipmentType, error) {
+	return repo.equipment, nil
+}
+
+func (repo *stubEquipmentRepository) SwitchOn(_ context.Context, payload *RuntimeType) error {
+	repo.switched = payload
+	return nil
+}
+
+func (repo *stubEquipmentRepository) SwitchOff(_ context.Context, payload *RuntimeType) error {
+	repo.switched = payload
+	return nil
+}
+
+func (repo *stubEquipmentRepository) GetEnergy(context.Context, *energyQuery) ([]*EnergyType, error) {
+	return repo.energy, nil
+}
+
+func TestShouldNOTCreateEquipmentWithUnknownType(t *testing.T) {
+	svc := NewService(&stubEquipmentRepository{})
+
+	if err := svc.Create(context.Background(), &EquipmentPayload{FarmID: 1, Name: "Paddlewheel A1", Type: "windmill"}); err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+}
+
+func TestShouldSwitchOnActiveEquipment(t *testing.T) {
+	repo := &stubEquipmentRepository{equipment: &EquipmentType{ID: 1, FarmID: 1, Status: StatusActive}}
+	svc := NewService(repo)
+
+	if err := svc.Switch(context.Background(), &SwitchPayload{ID: 1, FarmID: 1, State: StateOn}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.switched == nil || repo.switched.EquipmentID != 1 || repo.switched.StartedAt.IsZero() {
+		t.Errorf("unexpected runtime: %+v", repo.switched)
+	}
+}
+
+func TestShouldNOTSwitchOnBrokenEquipment(t *testing.T) {
+	repo := &stubEquipmentRepository{equipment: &EquipmentType{ID: 1, FarmID: 1, Status: StatusBroken}}
+	svc := NewService(repo)
+
+	if err := svc.Switch(context.Background(), &SwitchPayload{ID: 1, FarmID: 1, State: StateOn}); err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+
+	// broken unit may still be reported as stopped
+	if err := svc.Switch(context.Background(), &SwitchPayload{ID: 1, FarmID: 1, State: StateOff}); err != nil || !repo.switched.StoppedAt.Valid {
+		t.Errorf("unexpected runtime: %+v, err: %v", repo.switched, err)
+	}
+}
+
+func TestShouldGroupFarmEnergyPerPond(t *testing.T) {
+	repo := &stubEquipmentRepository{energy: []*EnergyType{
+		{ID: 1, PondID: sql.NullInt64{Int64: 2, Valid: true}, PowerRating: 1.5, RunSeconds: 7200},
+		{ID: 2, PondID: sql.NullInt64{Int64: 2, Valid: true}, PowerRating: 0.75, RunSeconds: 3600},
+		{ID: 3, PowerRating: 5.5, RunSeconds: 3600},
+	}}
+	svc := NewService(repo)
+
+	res, err := svc.GetFarmEnergy(context.Background(), &EnergyRequestQuery{FarmID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if len(res.Ponds) != 2 || *res.Ponds[0].PondID != 2 || res.Ponds[0].Energy != 3.75 || res.Ponds[0].RunHours != 3 {
+		t.Errorf("unexpected pond energy: %+v", res.Ponds[0])
+	}
+
+	if res.Ponds[1].PondID != nil || res.Ponds[1].Energy != 5.5 {
+		t.Errorf("unexpected farm-wide energy: %+v", res.Ponds[1])
+	}
+
+	if res.Energy != 9.25 || res.RunHours != 4 {
+		t.Errorf("unexpected farm energy: %+v", res)
+	}
+}
@@ -1,20 +1,27 @@
 package farms
 
-import "github.com/labstack/echo/v4"
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/middleware"
+)
 
 type FarmController struct {
-	svc FarmService
+	svc   FarmService
+	authz middleware.Authorizer
 }
 
-func NewController(svc FarmService) *FarmController {
+func NewController(svc FarmService, authz middleware.Authorizer) *FarmController {
 	return &FarmController{
-		svc: svc,
+		svc:   svc,
+		authz: authz,
 	}
 }
 
 const (
 	farmBasepath = "/farms"
 	farmIDPath   = "/:farmID"
+	restorePath  = "/:farmID/restore"
 )
 
 func (fc *FarmController) Route(grp *echo.Group) {
@@ -26,10 +33,12 @@ func (fc *FarmController) Route(grp *echo.Group) {
 	subrouter.OPTIONS(farmIDPath, HandleGetOneFarm(fc.svc.GetOne))
 	subrouter.POST("", HandleCreateFarm(fc.svc.Create))
 	subrouter.OPTIONS("", HandleCreateFarm(fc.svc.Create))
-	subrouter.PUT(farmIDPath, HandleUpdateFarm(fc.svc.Update))
+	subrouter.PUT(farmIDPath, HandleUpdateFarm(fc.svc.Update), middleware.RequireAction(fc.authz, auth.ActionWriteFarm))
 	subrouter.OPTIONS(farmIDPath, HandleUpdateFarm(fc.svc.Update))
-	subrouter.DELETE(farmIDPath, HandleDeleteFarm(fc.svc.Delete))
+	subrouter.DELETE(farmIDPath, HandleDeleteFarm(fc.svc.Delete), middleware.RequireAction(fc.authz, auth.ActionDeleteFarm))
 	subrouter.OPTIONS(farmIDPath, HandleDeleteFarm(fc.svc.Delete))
+	subrouter.POST(restorePath, HandleRestoreFarm(fc.svc.Restore), middleware.RequireAction(fc.authz, auth.ActionDeleteFarm))
+	subrouter.OPTIONS(restorePath, HandleRestoreFarm(fc.svc.Restore))
 
 	return
 }
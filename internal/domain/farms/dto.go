@@ -1,25 +1,80 @@
 package farms
 
-import "github.com/nmluci/da-farm-be/internal/core/httpres"
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/geo"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/core/listquery"
+)
 
 // FarmRequestQuery represent query parameter fetch from request
 type FarmRequestQuery struct {
-	ID      int64  `param:"farmID" example:"1"`
-	Keyword string `query:"keyword" example:"Farm"`
-	Limit   uint64 `query:"limit" example:"100"`
-	Page    uint64 `query:"page" example:"2"`
+	ID      int64   `param:"farmID" example:"1"`
+	Keyword string  `query:"keyword" example:"Farm"`
+	Region  string  `query:"region" example:"Bali"`
+	Near    string  `query:"near" example:"-8.65,115.22"` // formatted as lat,lon
+	Radius  float64 `query:"radius" example:"10"`         // in km, default to 10
+	Deleted bool    `query:"deleted" example:"false"`     // list soft-deleted farms instead, which can be restored
+	DryRun  bool    `query:"dry_run" example:"true"`      // report records affected by deletion without deleting them
+	Limit   uint64  `query:"limit" example:"100"`
+	Page    uint64  `query:"page" example:"2"`
+
+	List *listquery.Params // sort, filter and fields, parsed out of query string by handler
 }
 
 // FarmPayload represent payload fetch from request
 type FarmPayload struct {
 	ID   int64  `param:"farmID" example:"1" json:"-"` // ignore any value assigned via JSON body
 	Name string `json:"name" example:"Farm A"`
+
+	Latitude  *float64       `json:"latitude" example:"-8.65"`
+	Longitude *float64       `json:"longitude" example:"115.22"`
+	Address   string         `json:"address" example:"Jl. Pantai No. 1, Denpasar"`
+	Region    string         `json:"region" example:"Bali"`
+	Timezone  string         `json:"timezone" example:"Asia/Makassar"` // IANA timezone, default to Asia/Makassar
+	OwnerName string         `json:"owner_name" example:"John Doe"`
+	Contact   string         `json:"contact" example:"+6281234567890"`
+	Metadata  map[string]any `json:"metadata" swaggertype:"object"`
+	Area      float64        `json:"area" example:"25000"` // in m2, default to area of boundary
+	Boundary  *geo.Polygon   `json:"boundary"`
+}
+
+// PurgePayload represent parameter of purging soft-deleted farms
+type PurgePayload struct {
+	Retention time.Duration // farms deleted longer than this are purged
 }
 
 // FarmResponse represent domain response for Farm entity
 type FarmResponse struct {
 	ID   int64  `json:"id" example:"1"`
 	Name string `json:"name" example:"Farm A"`
+
+	Latitude  *float64       `json:"latitude" example:"-8.65"`
+	Longitude *float64       `json:"longitude" example:"115.22"`
+	Address   string         `json:"address" example:"Jl. Pantai No. 1, Denpasar"`
+	Region    string         `json:"region" example:"Bali"`
+	Timezone  string         `json:"timezone" example:"Asia/Makassar"`
+	OwnerName string         `json:"owner_name" example:"John Doe"`
+	Contact   string         `json:"contact" example:"+6281234567890"`
+	Metadata  map[string]any `json:"metadata" swaggertype:"object"`
+	Area      float64        `json:"area" example:"25000"`
+	Boundary  *geo.Polygon   `json:"boundary"`
+	Distance  *float64       `json:"distance,omitempty" example:"1.5"` // in km, only on proximity query
+
+	list *listquery.Params // field selection of the listing, unselected fields are left out
+}
+
+// MarshalJSON serialize farm without fields left out of the listing's selection
+func (res FarmResponse) MarshalJSON() ([]byte, error) {
+	type farmResponse FarmResponse
+	return res.list.Project(farmResponse(res))
+}
+
+// DeleteFarmResponse represent records affected by deleting a farm, nothing is deleted on dry-run
+type DeleteFarmResponse struct {
+	DryRun   bool             `json:"dry_run" example:"false"`
+	Affected map[string]int64 `json:"affected"` // keyed by table, ex: {"farms": 1, "ponds": 4}, append-only logs are only removed on purge
 }
 
 // ListFarmResponse represent domain response for bulk Farm entities
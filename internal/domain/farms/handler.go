@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/nmluci/da-farm-be/internal/core/listquery"
 	"github.com/rs/zerolog"
 )
 
@@ -16,10 +17,20 @@ type GetAllFarmHandler func(context.Context, *FarmRequestQuery) (*ListFarmRespon
 //	@Summary	get all farm
 //	@Tags		Farm
 //	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
 //	@Success	200		{object}	ListFarmResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"field not allowed to sort, filter or select"
 //	@Failure	404		{object}	httpres.ErrorResponse
 //	@Failure	500		{object}	httpres.ErrorResponse
-//	@Param		keyword	query		string	false	"Keyword to search"
+//	@Param		keyword	query		string	false	"search by name, region, address or owner, matched by word prefix and tolerating typos, ordered by relevance unless near is given"
+//	@Param		region	query		string	false	"only return farm within the region"
+//	@Param		near	query		string	false	"only return farm around coordinate, formatted as lat,lon"
+//	@Param		radius	query		number	false	"search radius around near in km, default to 10"
+//	@Param		deleted	query		bool	false	"list soft-deleted farms instead, which can be restored"
+//	@Param		sort	query		string	false	"comma separated id, name, region, owner_name, area, created_at or updated_at, prefixed by - for descending, ex: -created_at,name"
+//	@Param		filter	query		string	false	"filter[name|region|timezone|owner_name]=a,b matches any of the values, filter[created_at|updated_at][gt|gte|lt|lte] accepts RFC3339 or YYYY-MM-DD, ex: filter[created_at][gte]=2024-08-01"
+//	@Param		fields	query		string	false	"comma separated fields to fetch along with id, the rest are omitted from response, ex: id,name"
 //	@Param		limit	query		string	false	"number of entity per page"
 //	@Param		page	query		string	false	"n-th page"
 //	@Router		/farms [get]
@@ -34,6 +45,10 @@ func HandleGetAllFarm(handler GetAllFarmHandler) echo.HandlerFunc {
 			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
 		}
 
+		if params.List, err = listquery.Parse(c.QueryParams(), farmFields); err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
 		data, err := handler(ctx, params)
 		if err != nil {
 			return httputil.WriteErrorResponse(c, err)
@@ -50,6 +65,8 @@ type GetOneFarmHandler func(context.Context, *FarmRequestQuery) (*FarmResponse,
 //	@Summary	get specific farm by ID
 //	@Tags		Farm
 //	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
 //	@Param		farmID	path		int	true	"Farm ID"
 //	@Success	200		{object}	FarmResponse
 //	@Failure	404		{object}	httpres.ErrorResponse
@@ -83,9 +100,11 @@ type CreateFarmHandler func(context.Context, *FarmPayload) error
 //	@Tags		Farm
 //	@Accept		json
 //	@Produce	json
+//	@Security	BearerAuth
 //	@Param		payload	body		FarmPayload	true	"farm payload"
 //	@Success	201		{object}	string
-//	@Failure	409		{object}	httpres.ErrorResponse	"farm with same name already exists"
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid coordinate or timezone"
+//	@Failure	409		{object}	httpres.ErrorResponse	"farm with same name already exists within the organization"
 //	@Failure	500		{object}	httpres.ErrorResponse
 //	@Router		/farms [post]
 func HandleCreateFarm(handler CreateFarmHandler) echo.HandlerFunc {
@@ -116,9 +135,12 @@ type UpdateFarmHandler func(context.Context, *FarmPayload) error
 //	@Tags		Farm
 //	@Accept		json
 //	@Produce	json
+//	@Security	BearerAuth
 //	@Param		farmID	path		int			true	"Farm ID"
 //	@Param		payload	body		FarmPayload	true	"farm payload"
 //	@Success	200		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse "invalid coordinate or timezone"
+//	@Failure	403		{object}	httpres.ErrorResponse "not an owner or manager of the farm"
 //	@Failure	404		{object}	httpres.ErrorResponse "farm not existed"
 //	@Failure	409		{object}	httpres.ErrorResponse "duplicated farm found"
 //	@Failure	500		{object}	httpres.ErrorResponse
@@ -144,19 +166,57 @@ func HandleUpdateFarm(handler UpdateFarmHandler) echo.HandlerFunc {
 	}
 }
 
-type DeleteFarmHandler func(context.Context, *FarmRequestQuery) error
+type DeleteFarmHandler func(context.Context, *FarmRequestQuery) (*DeleteFarmResponse, error)
 
 // DeleteFarm godoc
 //
-//	@Summary	delete specific farm by ID
+//	@Summary	delete specific farm by ID along with its ponds and their records
 //	@Tags		Farm
 //	@Produce	json
-//	@Param		farmID	path		int	true	"Farm ID"
-//	@Success	200		{object}	FarmResponse
+//	@Security	BearerAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		dry_run	query		bool	false	"only report records which would be deleted"
+//	@Success	200		{object}	DeleteFarmResponse
+//	@Failure	403		{object}	httpres.ErrorResponse "not an owner of the farm"
 //	@Failure	404		{object}	httpres.ErrorResponse "farm not existed"
 //	@Failure	500		{object}	httpres.ErrorResponse
 //	@Router		/farms/{farmID} [delete]
 func HandleDeleteFarm(handler DeleteFarmHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &FarmRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type RestoreFarmHandler func(context.Context, *FarmRequestQuery) error
+
+// RestoreFarm godoc
+//
+//	@Summary	restore soft-deleted farm by ID along with records deleted together with it
+//	@Tags		Farm
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Success	200		{object}	string
+//	@Failure	403		{object}	httpres.ErrorResponse "not an owner of the farm"
+//	@Failure	404		{object}	httpres.ErrorResponse "farm not deleted"
+//	@Failure	409		{object}	httpres.ErrorResponse "name taken by an active farm"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/restore [post]
+func HandleRestoreFarm(handler RestoreFarmHandler) echo.HandlerFunc {
 	return func(c echo.Context) (err error) {
 		ctx := c.Request().Context()
 		logger := zerolog.Ctx(ctx)
@@ -0,0 +1,57 @@
+package farms
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestShouldOmitUnselectedFieldsOfFarmListing(t *testing.T) {
+	repo := &stubFarmRepository{farms: []*FarmType{{ID: 1, Name: "Farm A"}}}
+	svc := NewService(repo, &stubAuditService{})
+
+	ec := echo.New()
+	ec.GET("/farms", HandleGetAllFarm(svc.GetAll))
+
+	cases := []struct {
+		query string
+		keys  []string
+	}{
+		{"fields=id,name", []string{"id", "name"}},
+		{"fields=latitude", []string{"id", "latitude", "longitude"}}, // coordinate is only shown in pair
+		{"", []string{"address", "area", "boundary", "contact", "id", "latitude", "longitude", "metadata", "name", "owner_name", "region", "timezone"}},
+	}
+
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		ec.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/farms?"+tc.query, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status on %q: %d", tc.query, rec.Code)
+		}
+
+		body := struct {
+			Data struct {
+				Farms []map[string]any `json:"farms"`
+			} `json:"data"`
+		}{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || len(body.Data.Farms) != 1 {
+			t.Fatalf("unexpected body on %q: %s", tc.query, rec.Body.String())
+		}
+
+		keys := []string{}
+		for key := range body.Data.Farms[0] {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		if !reflect.DeepEqual(keys, tc.keys) {
+			t.Errorf("unexpected keys on %q: %v", tc.query, keys)
+		}
+	}
+}
@@ -1,6 +1,61 @@
 package farms
 
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/nmluci/da-farm-be/internal/core/geo"
+)
+
+// DefaultTimezone is assigned to farm without explicit timezone
+const DefaultTimezone = "Asia/Makassar"
+
 type FarmType struct {
-	ID   int64  `db:"id"`
-	Name string `db:"name"`
+	ID             int64           `db:"id"`
+	OrganizationID int64           `db:"organization_id"`
+	Name           string          `db:"name"`
+	Latitude       sql.NullFloat64 `db:"latitude"`
+	Longitude      sql.NullFloat64 `db:"longitude"`
+	Address        string          `db:"address"`
+	Region         string          `db:"region"`
+	Timezone       string          `db:"timezone"`
+	OwnerName      string          `db:"owner_name"`
+	Contact        string          `db:"contact"`
+	Metadata       Metadata        `db:"metadata"`
+	Area           float64         `db:"area"`
+	Boundary       *geo.Polygon    `db:"boundary"`
+	Distance       sql.NullFloat64 `db:"distance"` // only populated on proximity query
+	CreatedBy      int64           `db:"-"`        // user granted owner role on creation, none when zero
+}
+
+// Cascade represent number of records affected by deleting a farm, keyed by table
+type Cascade map[string]int64
+
+// Metadata represent free-form attributes stored as jsonb object
+type Metadata map[string]any
+
+// Value implements driver.Valuer
+func (m Metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(m)
+}
+
+// Scan implements sql.Scanner
+func (m *Metadata) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = Metadata{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	}
+
+	return errors.New("unsupported type for Metadata")
 }
@@ -4,12 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/geo"
+	"github.com/nmluci/da-farm-be/internal/core/listquery"
+	"github.com/nmluci/da-farm-be/internal/core/search"
+	"github.com/nmluci/da-farm-be/internal/domain/access"
 	"github.com/rs/zerolog"
 )
 
@@ -20,7 +26,9 @@ type FarmRepository interface {
 	GetOne(context.Context, *farmQuery) (*FarmType, error)
 	Store(context.Context, *FarmType) error
 	Upsert(context.Context, *FarmType) error
-	Delete(context.Context, *farmQuery) error
+	Delete(context.Context, *farmQuery) (Cascade, error)
+	Restore(context.Context, *farmQuery) error
+	Purge(context.Context, time.Time) (int64, error)
 }
 
 type farmRepository struct {
@@ -35,20 +43,176 @@ func NewRepository(db *sqlx.DB) FarmRepository {
 }
 
 type farmQuery struct {
-	ID          int64
-	Keyword     string
-	Limit, Page uint64
+	ID              int64
+	Keyword, Region string
+	Near            *geo.Point
+	Radius          float64 // in km
+	UserID          int64   // only farms the user has a role on, when non-zero
+	OrganizationID  int64   // only farms of the tenant, when non-zero
+	Deleted         bool    // soft-deleted farms instead of active ones
+	DryRun          bool    // count records affected by deletion without deleting them
+	List            *listquery.Params
+	Limit, Page     uint64
 }
 
 var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
+// farmOwned, pondOwned and equipmentOwned match records of a farm, of its ponds and of its equipments respectively.
+// Ponds are narrowed down into those sharing the deletion state of the farm, see owned
+const (
+	farmOwned      = "farm_id = ?"
+	pondOwned      = "pond_id in (select id from ponds where farm_id = ? and ?)"
+	equipmentOwned = "equipment_id in (select id from equipments where farm_id = ?)"
+)
+
+// purgeable map owner condition of cascade into the one matching records of farms deleted before a cutoff.
+// Every pond of the purged farms is purged, including the ones deleted on their own
+var purgeable = map[string]string{
+	farmOwned:      "farm_id in (select id from farms where deleted_at < ?)",
+	pondOwned:      "pond_id in (select id from ponds where farm_id in (select id from farms where deleted_at < ?))",
+	equipmentOwned: "equipment_id in (select id from equipments where farm_id in (select id from farms where deleted_at < ?))",
+}
+
+type dependency struct {
+	table string
+	owner string
+}
+
+// owned match records of the farm owned through dep's owner. Pond records only follow ponds deleted at
+// deletedAt, nil meaning active ones, so ponds deleted on their own keep their records out of the cascade
+func (dep dependency) owned(farmID int64, deletedAt any) squirrel.Sqlizer {
+	if dep.owner == pondOwned {
+		return squirrel.Expr(dep.owner, farmID, squirrel.Eq{"deleted_at": deletedAt})
+	}
+
+	return squirrel.Expr(dep.owner, farmID)
+}
+
+// cascade list soft-deletable records following deletion and restoration of their farm, children are handled first
+var cascade = []dependency{
+	{"ponds", farmOwned},
+	{"water_quality_readings", pondOwned},
+	{"stockings", pondOwned},
+	{"feedings", pondOwned},
+	{"mortalities", pondOwned},
+	{"harvests", pondOwned},
+	{"growth_samples", pondOwned},
+	{"alert_rules", farmOwned},
+	{"alerts", farmOwned},
+	{"devices", farmOwned},
+	{"equipments", farmOwned},
+	{"work_orders", farmOwned},
+	{"checklist_templates", farmOwned},
+}
+
+// logs list append-only records of a farm. Having no soft delete, they're only reported on deletion and removed on purge,
+// while their owners being deleted already hide them
+var logs = []dependency{
+	{"checklist_tasks", farmOwned},
+	{"equipment_runtimes", equipmentOwned},
+	{"sensor_readings", pondOwned},
+	{"sensor_readings_5m", pondOwned},
+	{"sensor_readings_1h", pondOwned},
+	{"sensor_readings_1d", pondOwned},
+}
+
+var farmColumns = []string{"id", "name", "latitude", "longitude", "address", "region", "timezone", "owner_name", "contact", "metadata", "area", "boundary"}
+
+// farmFields whitelist fields of farm listing usable by sort, filter and fields
+var farmFields = listquery.Spec{
+	"id":         {Column: "id", Select: []string{"id"}, Sort: true},
+	"name":       {Column: "name", Select: []string{"name"}, Sort: true, Filter: true},
+	"latitude":   {Select: []string{"latitude", "longitude"}}, // coordinate is only shown in pair
+	"longitude":  {Select: []string{"latitude", "longitude"}},
+	"address":    {Select: []string{"address"}},
+	"region":     {Column: "region", Select: []string{"region"}, Sort: true, Filter: true},
+	"timezone":   {Column: "timezone", Select: []string{"timezone"}, Filter: true},
+	"owner_name": {Column: "owner_name", Select: []string{"owner_name"}, Sort: true, Filter: true},
+	"contact":    {Select: []string{"contact"}},
+	"metadata":   {Select: []string{"metadata"}},
+	"area":       {Column: "area", Select: []string{"area"}, Sort: true},
+	"boundary":   {Select: []string{"boundary"}},
+	"created_at": {Column: "created_at", Sort: true, Range: true},
+	"updated_at": {Column: "updated_at", Sort: true, Range: true},
+}
+
+// distanceExpr calculate great-circle distance in km between farm coordinate and (lat, lat, lon) using haversine formula
+const distanceExpr = "6371 * 2 * asin(sqrt(power(sin(radians(latitude - ?) / 2), 2) + cos(radians(?)) * cos(radians(latitude)) * power(sin(radians(longitude - ?) / 2), 2)))"
+
+func (params *farmQuery) distance() squirrel.Sqlizer {
+	return squirrel.Expr(distanceExpr, params.Near.Latitude, params.Near.Latitude, params.Near.Longitude)
+}
+
+func (params *farmQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"deleted_at": nil},
+	}
+
+	if params.Deleted {
+		cond = squirrel.And{
+			squirrel.NotEq{"deleted_at": nil},
+		}
+	}
+
+	if params.Region != "" {
+		cond = append(cond, squirrel.Eq{"region": params.Region})
+	}
+
+	if keyword := search.Parse(params.Keyword); keyword != nil {
+		cond = append(cond, keyword.Match("search", "name"))
+	}
+
+	// farm without recorded coordinate yields null distance, thus excluded
+	if params.Near != nil {
+		cond = append(cond, squirrel.Expr(distanceExpr+" <= ?", params.Near.Latitude, params.Near.Latitude, params.Near.Longitude, params.Radius))
+	}
+
+	if params.UserID != 0 {
+		cond = append(cond, squirrel.Expr("exists (select 1 from farm_members m where m.farm_id = farms.id and m.user_id = ?)", params.UserID))
+	}
+
+	cond = append(cond, params.List.Where()...)
+
+	return params.scope(cond)
+}
+
+// scope narrow down cond into the tenant, when non-zero
+func (params *farmQuery) scope(cond squirrel.And) squirrel.And {
+	if params.OrganizationID != 0 {
+		cond = append(cond, squirrel.Eq{"organization_id": params.OrganizationID})
+	}
+
+	return cond
+}
+
 func (repo *farmRepository) GetAll(ctx context.Context, params *farmQuery) (res []*FarmType, err error) {
 	logger := zerolog.Ctx(ctx)
 
-	stmt, args, _ := pgSquirrel.Select("id", "name").From("farms").
-		Where(squirrel.And{
-			squirrel.Eq{"deleted_at": nil},
-		}).
+	columns := farmColumns
+	if selected := params.List.Columns(); len(selected) > 0 {
+		columns = selected
+	}
+
+	query := pgSquirrel.Select(columns...).From("farms").
+		Where(params.filter())
+
+	if params.Near != nil {
+		query = query.Column(squirrel.Alias(params.distance(), "distance"))
+	}
+
+	// requested sort takes precedence, otherwise nearest farm goes first on proximity query
+	// and the most relevant one on keyword search
+	keyword := search.Parse(params.Keyword)
+	switch {
+	case len(params.List.Sort()) > 0:
+		query = query.OrderBy(params.List.Sort()...)
+	case params.Near != nil:
+		query = query.OrderBy("distance")
+	case keyword != nil:
+		query = query.OrderByClause(keyword.Rank("search", "name"))
+	}
+
+	stmt, args, _ := query.
 		Limit(params.Limit).
 		Offset((params.Page - 1) * params.Limit).ToSql()
 
@@ -77,9 +241,7 @@ func (repo *farmRepository) Count(ctx context.Context, params *farmQuery) (res u
 	logger := zerolog.Ctx(ctx)
 
 	stmt, args, _ := pgSquirrel.Select("count(*)").From("farms").
-		Where(squirrel.And{
-			squirrel.Eq{"deleted_at": nil},
-		}).ToSql()
+		Where(params.filter()).ToSql()
 
 	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
 	if err != nil && err != sql.ErrNoRows {
@@ -95,11 +257,11 @@ func (repo *farmRepository) Count(ctx context.Context, params *farmQuery) (res u
 func (repo *farmRepository) GetOne(ctx context.Context, params *farmQuery) (res *FarmType, err error) {
 	logger := zerolog.Ctx(ctx)
 
-	stmt, args, _ := pgSquirrel.Select("id", "name").From("farms").
-		Where(squirrel.And{
+	stmt, args, _ := pgSquirrel.Select(farmColumns...).From("farms").
+		Where(params.scope(squirrel.And{
 			squirrel.Eq{"id": params.ID},
 			squirrel.Eq{"deleted_at": nil},
-		}).ToSql()
+		})).ToSql()
 
 	res = &FarmType{}
 	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
@@ -127,8 +289,9 @@ func (repo *farmRepository) Store(ctx context.Context, payload *FarmType) (err e
 	var args []any
 	var count int64
 
-	// check for duplicated name existence
+	// check for duplicated name existence within the tenant
 	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
+		squirrel.Eq{"organization_id": payload.OrganizationID},
 		squirrel.Eq{"name": payload.Name},
 		squirrel.Eq{"deleted_at": nil},
 	}).ToSql()
@@ -144,15 +307,27 @@ func (repo *farmRepository) Store(ctx context.Context, payload *FarmType) (err e
 	}
 
 	stmt, args, _ = pgSquirrel.Insert("farms").
-		Columns("name").
-		Values(payload.Name).ToSql()
+		Columns("organization_id", "name", "latitude", "longitude", "address", "region", "timezone", "owner_name", "contact", "metadata", "area", "boundary").
+		Values(payload.OrganizationID, payload.Name, payload.Latitude, payload.Longitude, payload.Address, payload.Region, payload.Timezone, payload.OwnerName, payload.Contact, payload.Metadata, payload.Area, payload.Boundary).
+		Suffix("RETURNING id").ToSql()
 
-	_, err = tx.ExecContext(ctx, stmt, args...)
-	if err != nil {
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID); err != nil {
 		logger.Error().Err(err).Msg("failed to save data")
 		return
 	}
 
+	// creator owns the farm, so it stays manageable without an admin
+	if payload.CreatedBy != 0 {
+		stmt, args, _ = pgSquirrel.Insert("farm_members").
+			Columns("farm_id", "user_id", "role").
+			Values(payload.ID, payload.CreatedBy, access.RoleOwner).ToSql()
+
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			logger.Error().Err(err).Msg("failed to save data")
+			return
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		logger.Error().Err(err).Msg("failed to commit transaction")
 		return
@@ -176,9 +351,10 @@ func (repo *farmRepository) Upsert(ctx context.Context, payload *FarmType) (err
 	var args []any
 	var count int64
 
-	// check for duplicated name existence
+	// check for duplicated name existence within the tenant
 	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
 		squirrel.NotEq{"id": payload.ID},
+		squirrel.Eq{"organization_id": payload.OrganizationID},
 		squirrel.Eq{"name": payload.Name},
 		squirrel.Eq{"deleted_at": nil},
 	}).ToSql()
@@ -193,9 +369,10 @@ func (repo *farmRepository) Upsert(ctx context.Context, payload *FarmType) (err
 		return errs.ErrDuplicatedResources
 	}
 
-	// check for farm existence
+	// check for farm existence, farm of another tenant is treated as non-existing
 	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
 		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"organization_id": payload.OrganizationID},
 		squirrel.Eq{"deleted_at": nil},
 	}).ToSql()
 
@@ -206,15 +383,36 @@ func (repo *farmRepository) Upsert(ctx context.Context, payload *FarmType) (err
 
 	switch count {
 	case 0:
-		stmt, args, _ = pgSquirrel.Insert("farms").Columns("name").Values(payload.Name).ToSql()
+		stmt, args, _ = pgSquirrel.Insert("farms").
+			Columns("organization_id", "name", "latitude", "longitude", "address", "region", "timezone", "owner_name", "contact", "metadata", "area", "boundary").
+			Values(payload.OrganizationID, payload.Name, payload.Latitude, payload.Longitude, payload.Address, payload.Region, payload.Timezone, payload.OwnerName, payload.Contact, payload.Metadata, payload.Area, payload.Boundary).
+			Suffix("RETURNING id").ToSql()
 	default:
 		stmt, args, _ = pgSquirrel.Update("farms").SetMap(map[string]interface{}{
 			"name":       payload.Name,
+			"latitude":   payload.Latitude,
+			"longitude":  payload.Longitude,
+			"address":    payload.Address,
+			"region":     payload.Region,
+			"timezone":   payload.Timezone,
+			"owner_name": payload.OwnerName,
+			"contact":    payload.Contact,
+			"metadata":   payload.Metadata,
+			"area":       payload.Area,
+			"boundary":   payload.Boundary,
 			"updated_at": squirrel.Expr("NOW()"),
-		}).Where(squirrel.Eq{"id": payload.ID}).ToSql()
+		}).Where(squirrel.And{
+			squirrel.Eq{"id": payload.ID},
+			squirrel.Eq{"organization_id": payload.OrganizationID},
+		}).ToSql()
 	}
 
-	_, err = tx.ExecContext(ctx, stmt, args...)
+	// inserted farm gets a new ID, keep it for the caller
+	if count == 0 {
+		err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID)
+	} else {
+		_, err = tx.ExecContext(ctx, stmt, args...)
+	}
 	if err != nil {
 		// if DB return an Unique Violation err, then there's duplicated data
 		var pgErr *pgconn.PgError
@@ -236,7 +434,9 @@ func (repo *farmRepository) Upsert(ctx context.Context, payload *FarmType) (err
 	return
 }
 
-func (repo *farmRepository) Delete(ctx context.Context, payload *farmQuery) (err error) {
+// Delete soft-delete the farm along with its ponds and records depending on them, all within the same timestamp.
+// On dry-run nothing is deleted, only the number of affected records is reported
+func (repo *farmRepository) Delete(ctx context.Context, payload *farmQuery) (res Cascade, err error) {
 	logger := zerolog.Ctx(ctx)
 
 	tx, err := repo.db.BeginTxx(ctx, nil)
@@ -250,10 +450,10 @@ func (repo *farmRepository) Delete(ctx context.Context, payload *farmQuery) (err
 	var args []any
 
 	// check for row existence
-	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(payload.scope(squirrel.And{
 		squirrel.Eq{"id": payload.ID},
 		squirrel.Eq{"deleted_at": nil},
-	}).ToSql()
+	})).ToSql()
 
 	var count int64
 	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows {
@@ -267,6 +467,60 @@ func (repo *farmRepository) Delete(ctx context.Context, payload *farmQuery) (err
 		return
 	}
 
+	res = Cascade{"farms": count}
+
+	for _, dep := range logs {
+		stmt, args, _ = pgSquirrel.Select("count(*)").From(dep.table).Where(dep.owned(payload.ID, nil)).ToSql()
+		if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil {
+			logger.Error().Err(err).Str("table", dep.table).Msg("failed to fetch data")
+			return
+		}
+
+		res[dep.table] = count
+	}
+
+	// NOW() is fixed within a transaction, thus every record shares the farm's deletion timestamp.
+	// Records of ponds goes before the ponds, which are still active by then
+	for i := len(cascade) - 1; i >= 0; i-- {
+		dep := cascade[i]
+		cond := squirrel.And{
+			dep.owned(payload.ID, nil),
+			squirrel.Eq{"deleted_at": nil},
+		}
+
+		if payload.DryRun {
+			stmt, args, _ = pgSquirrel.Select("count(*)").From(dep.table).Where(cond).ToSql()
+			if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil {
+				logger.Error().Err(err).Str("table", dep.table).Msg("failed to fetch data")
+				return
+			}
+
+			res[dep.table] = count
+			continue
+		}
+
+		stmt, args, _ = pgSquirrel.Update(dep.table).SetMap(map[string]interface{}{
+			"updated_at": squirrel.Expr("NOW()"),
+			"deleted_at": squirrel.Expr("NOW()"),
+		}).Where(cond).ToSql()
+
+		result, err := tx.ExecContext(ctx, stmt, args...)
+		if err != nil {
+			logger.Error().Err(err).Str("table", dep.table).Msg("failed to delete data")
+			return nil, err
+		}
+
+		if res[dep.table], err = result.RowsAffected(); err != nil {
+			logger.Error().Err(err).Str("table", dep.table).Msg("failed to delete data")
+			return nil, err
+		}
+	}
+
+	// dry-run is rolled back, yet there's nothing to be rolled back anyway
+	if payload.DryRun {
+		return
+	}
+
 	stmt, args, _ = pgSquirrel.Update("farms").SetMap(map[string]interface{}{
 		"updated_at": squirrel.Expr("NOW()"),
 		"deleted_at": squirrel.Expr("NOW()"),
@@ -285,3 +539,153 @@ func (repo *farmRepository) Delete(ctx context.Context, payload *farmQuery) (err
 
 	return
 }
+
+// Restore bring back a soft-deleted farm along with records deleted together with it, records deleted on their own stay deleted.
+// Restore is rejected when an active farm or pond of the tenant already took the name
+func (repo *farmRepository) Restore(ctx context.Context, params *farmQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var stmt string
+	var args []any
+
+	// check for deleted row existence
+	stmt, args, _ = pgSquirrel.Select("organization_id", "name", "deleted_at").From("farms").Where(params.scope(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.NotEq{"deleted_at": nil},
+	})).ToSql()
+
+	var organizationID int64
+	var name string
+	var deletedAt time.Time
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&organizationID, &name, &deletedAt); err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return errs.ErrNotFound
+	}
+
+	// check for duplicated name existence within the tenant
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
+		squirrel.Eq{"organization_id": organizationID},
+		squirrel.Eq{"name": name},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	var count int64
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
+		return
+	}
+
+	if count != 0 {
+		return errs.ErrDuplicatedResources
+	}
+
+	// ponds coming back along with the farm may collide with active ponds of the tenant as well
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+		squirrel.Eq{"farm_id": params.ID},
+		squirrel.Eq{"deleted_at": deletedAt},
+		squirrel.Expr("name in (select name from ponds where deleted_at is null and farm_id in (select id from farms where organization_id = ?))", organizationID),
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
+		return
+	}
+
+	if count != 0 {
+		return errs.ErrDuplicatedResources
+	}
+
+	// records of ponds goes before the ponds, which are still deleted by then
+	for i := len(cascade) - 1; i >= 0; i-- {
+		dep := cascade[i]
+		stmt, args, _ = pgSquirrel.Update(dep.table).SetMap(map[string]interface{}{
+			"updated_at": squirrel.Expr("NOW()"),
+			"deleted_at": nil,
+		}).Where(squirrel.And{
+			dep.owned(params.ID, deletedAt),
+			squirrel.Eq{"deleted_at": deletedAt},
+		}).ToSql()
+
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			// ex: sensor already registered into another farm
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				err = errs.ErrDuplicatedResources
+			}
+
+			logger.Error().Err(err).Str("table", dep.table).Msg("failed to update data")
+			return
+		}
+	}
+
+	stmt, args, _ = pgSquirrel.Update("farms").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": nil,
+	}).Where(squirrel.Eq{"id": params.ID}).ToSql()
+
+	if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// Purge permanently remove farms deleted before the cutoff along with their ponds and members, return number of purged farms
+func (repo *farmRepository) Purge(ctx context.Context, cutoff time.Time) (res int64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	// children goes first, records of ponds and equipments are matched through them thus deleted before them
+	deps := slices.Concat(cascade, logs)
+	for i := len(deps) - 1; i >= 0; i-- {
+		stmt, args, _ := pgSquirrel.Delete(deps[i].table).Where(purgeable[deps[i].owner], cutoff).ToSql()
+
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			logger.Error().Err(err).Str("table", deps[i].table).Msg("failed to delete data")
+			return
+		}
+	}
+
+	stmt, args, _ := pgSquirrel.Delete("farm_members").Where(purgeable[farmOwned], cutoff).ToSql()
+
+	if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Str("table", "farm_members").Msg("failed to delete data")
+		return
+	}
+
+	stmt, args, _ = pgSquirrel.Delete("farms").Where(squirrel.Lt{"deleted_at": cutoff}).ToSql()
+
+	result, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return result.RowsAffected()
+}
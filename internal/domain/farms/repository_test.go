@@ -2,12 +2,17 @@ package farms
 
 import (
 	"context"
+	"database/sql"
+	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/geo"
+	"github.com/nmluci/da-farm-be/internal/core/listquery"
 )
 
 func TestShouldGetFarmWithResult(t *testing.T) {
@@ -33,6 +38,33 @@ func TestShouldGetFarmWithResult(t *testing.T) {
 	}
 }
 
+func TestShouldGetFarmNearCoordinate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "name", "distance"}).
+		AddRow(1, "Farm A", 1.5)
+
+	mock.ExpectQuery(`^SELECT (.+), \((.+)\) AS distance FROM farms WHERE \(deleted_at IS NULL AND (.+) <= \$7\) ORDER BY distance LIMIT 100 OFFSET 0$`).
+		WithArgs(-8.65, -8.65, 115.22, -8.65, -8.65, 115.22, 10.0).
+		WillReturnRows(rows)
+
+	res, err := farmRepo.GetAll(context.Background(), &farmQuery{Near: &geo.Point{Latitude: -8.65, Longitude: 115.22}, Radius: 10, Limit: 100, Page: 1})
+	if err != nil || len(res) != 1 || res[0].Distance.Float64 != 1.5 {
+		t.Errorf("unexpected farms: %+v, err: %v", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
 func TestShouldCountFarmAboveZero(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -73,6 +105,44 @@ func TestShouldCountFarmLessThanZero(t *testing.T) {
 	}
 }
 
+func TestShouldCountFarmWithinRegion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	rows := sqlmock.NewRows([]string{"count(*)"}).AddRow(3)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms WHERE (deleted_at IS NULL AND region = $1)")).WithArgs("Bali").WillReturnRows(rows)
+
+	farmRepo.Count(context.Background(), &farmQuery{Region: "Bali", Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldCountFarmWithinTenant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	rows := sqlmock.NewRows([]string{"count(*)"}).AddRow(2)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms WHERE (deleted_at IS NULL AND organization_id = $1)")).WithArgs(2).WillReturnRows(rows)
+
+	farmRepo.Count(context.Background(), &farmQuery{OrganizationID: 2, Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
 func TestShouldGetOneFarmWithID(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -83,10 +153,10 @@ func TestShouldGetOneFarmWithID(t *testing.T) {
 	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	// expected queries
-	rows := sqlmock.NewRows([]string{"id", "name"}).
-		AddRow(1, "Farm A")
+	rows := sqlmock.NewRows([]string{"id", "name", "latitude", "longitude", "address", "region", "timezone", "owner_name", "contact", "metadata", "area", "boundary"}).
+		AddRow(1, "Farm A", -8.65, 115.22, "Denpasar", "Bali", DefaultTimezone, "John Doe", "+6281234567890", []byte(`{"certified":true}`), 25000, nil)
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM farms WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, latitude, longitude, address, region, timezone, owner_name, contact, metadata, area, boundary FROM farms WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).WillReturnRows(rows)
 
 	farmRepo.GetOne(context.Background(), &farmQuery{ID: 1})
 
@@ -107,7 +177,7 @@ func TestShouldNOTGetOneFarmWithID(t *testing.T) {
 	// expected queries
 	rows := sqlmock.NewRows([]string{"id", "name"})
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM farms WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(2).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, latitude, longitude, address, region, timezone, owner_name, contact, metadata, area, boundary FROM farms WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(2).WillReturnRows(rows)
 
 	farmRepo.GetOne(context.Background(), &farmQuery{ID: 2})
 
@@ -126,13 +196,14 @@ func TestShouldStoreFarm(t *testing.T) {
 	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (name = $1 AND deleted_at IS NULL)`)).WithArgs("Farm A").
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (organization_id = $1 AND name = $2 AND deleted_at IS NULL)`)).WithArgs(1, "Farm A").
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO farms (name) VALUES ($1)")).WithArgs("Farm A").
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO farms (organization_id,name,latitude,longitude,address,region,timezone,owner_name,contact,metadata,area,boundary) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING id")).
+		WithArgs(1, "Farm A", sql.NullFloat64{}, sql.NullFloat64{}, "", "Bali", DefaultTimezone, "", "", []byte("{}"), 0.0, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectCommit()
 
-	farmRepo.Store(context.Background(), &FarmType{Name: "Farm A"})
+	farmRepo.Store(context.Background(), &FarmType{OrganizationID: 1, Name: "Farm A", Region: "Bali", Timezone: DefaultTimezone})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -149,18 +220,19 @@ func TestShouldInsertOnUpdateFarm(t *testing.T) {
 	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id <> $1 AND name = $2 AND deleted_at IS NULL)`)).WithArgs(1, "Farm A").
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id <> $1 AND organization_id = $2 AND name = $3 AND deleted_at IS NULL)`)).WithArgs(1, 1, "Farm A").
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id = $1 AND deleted_at IS NULL)`)).WithArgs(1).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id = $1 AND organization_id = $2 AND deleted_at IS NULL)`)).WithArgs(1, 1).
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
 
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO farms (name) VALUES ($1)")).WithArgs("Farm A").
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO farms (organization_id,name,latitude,longitude,address,region,timezone,owner_name,contact,metadata,area,boundary) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING id")).
+		WithArgs(1, "Farm A", sql.NullFloat64{}, sql.NullFloat64{}, "", "Bali", DefaultTimezone, "", "", []byte("{}"), 0.0, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 	mock.ExpectCommit()
 
-	farmRepo.Upsert(context.Background(), &FarmType{ID: 1, Name: "Farm A"})
+	farmRepo.Upsert(context.Background(), &FarmType{ID: 1, OrganizationID: 1, Name: "Farm A", Region: "Bali", Timezone: DefaultTimezone})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -177,11 +249,11 @@ func TestShouldNOTInsertFarmOnUpdateDueDuplicated(t *testing.T) {
 	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id <> $1 AND name = $2 AND deleted_at IS NULL)`)).WithArgs(1, "Farm A").
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id <> $1 AND organization_id = $2 AND name = $3 AND deleted_at IS NULL)`)).WithArgs(1, 1, "Farm A").
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0)).WillReturnError(errs.ErrDuplicatedResources)
 	mock.ExpectRollback()
 
-	farmRepo.Upsert(context.Background(), &FarmType{ID: 1, Name: "Farm A"})
+	farmRepo.Upsert(context.Background(), &FarmType{ID: 1, OrganizationID: 1, Name: "Farm A", Region: "Bali", Timezone: DefaultTimezone})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -198,18 +270,19 @@ func TestShouldUpdateFarm(t *testing.T) {
 	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id <> $1 AND name = $2 AND deleted_at IS NULL)`)).WithArgs(1, "Farm A").
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id <> $1 AND organization_id = $2 AND name = $3 AND deleted_at IS NULL)`)).WithArgs(1, 1, "Farm A").
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id = $1 AND deleted_at IS NULL)`)).WithArgs(1).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id = $1 AND organization_id = $2 AND deleted_at IS NULL)`)).WithArgs(1, 1).
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
 
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE farms SET name = $1, updated_at = NOW() WHERE id = $2")).WithArgs("Farm A", 1).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE farms SET address = $1, area = $2, boundary = $3, contact = $4, latitude = $5, longitude = $6, metadata = $7, name = $8, owner_name = $9, region = $10, timezone = $11, updated_at = NOW() WHERE (id = $12 AND organization_id = $13)")).
+		WithArgs("", 0.0, nil, "", sql.NullFloat64{}, sql.NullFloat64{}, []byte("{}"), "Farm A", "", "Bali", DefaultTimezone, 1, 1).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	mock.ExpectCommit()
 
-	farmRepo.Upsert(context.Background(), &FarmType{ID: 1, Name: "Farm A"})
+	farmRepo.Upsert(context.Background(), &FarmType{ID: 1, OrganizationID: 1, Name: "Farm A", Region: "Bali", Timezone: DefaultTimezone})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -226,11 +299,11 @@ func TestShouldNOTUpdateFarmDuplicated(t *testing.T) {
 	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id <> $1 AND name = $2 AND deleted_at IS NULL)`)).WithArgs(1, "Farm A").
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id <> $1 AND organization_id = $2 AND name = $3 AND deleted_at IS NULL)`)).WithArgs(1, 1, "Farm A").
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1)).WillReturnError(errs.ErrDuplicatedResources)
 	mock.ExpectRollback()
 
-	farmRepo.Upsert(context.Background(), &FarmType{ID: 1, Name: "Farm A"})
+	farmRepo.Upsert(context.Background(), &FarmType{ID: 1, OrganizationID: 1, Name: "Farm A", Region: "Bali", Timezone: DefaultTimezone})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -249,11 +322,65 @@ func TestShouldDeleteFarm(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id = $1 AND deleted_at IS NULL)`)).WithArgs(1).
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM checklist_tasks WHERE farm_id = $1")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(30))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM equipment_runtimes WHERE equipment_id in (select id from equipments where farm_id = $1)")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	for _, dep := range logs[2:] {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM " + dep.table + " WHERE pond_id in (select id from ponds where farm_id = $1 and deleted_at IS NULL)")).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	}
+	for i := len(cascade) - 1; i > 1; i-- {
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE " + cascade[i].table + " SET deleted_at = NOW(), updated_at = NOW() WHERE")).WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	// records of ponds deleted on their own are left out
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE water_quality_readings SET deleted_at = NOW(), updated_at = NOW() WHERE (pond_id in (select id from ponds where farm_id = $1 and deleted_at IS NULL) AND deleted_at IS NULL)")).WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE ponds SET deleted_at = NOW(), updated_at = NOW() WHERE (farm_id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE farms SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1")).WithArgs(1).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	farmRepo.Delete(context.Background(), &farmQuery{ID: 1})
+	res, err := farmRepo.Delete(context.Background(), &farmQuery{ID: 1})
+	if err != nil || res["farms"] != 1 || res["ponds"] != 2 || res["water_quality_readings"] != 10 || res["checklist_tasks"] != 30 {
+		t.Errorf("unexpected affected records: %v, err: %v", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTDeleteFarmOnDryRun(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (id = $1 AND deleted_at IS NULL)`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	for _, dep := range logs {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM " + dep.table + " WHERE")).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	}
+	for i := len(cascade) - 1; i > 0; i-- {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM " + cascade[i].table + " WHERE")).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (farm_id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+	mock.ExpectRollback()
+
+	res, err := farmRepo.Delete(context.Background(), &farmQuery{ID: 1, DryRun: true})
+	if err != nil || len(res) != len(cascade)+len(logs)+1 || res["ponds"] != 2 {
+		t.Errorf("unexpected affected records: %v, err: %v", res, err)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -281,3 +408,205 @@ func TestShouldNOTDeleteFarmDueNotExisted(t *testing.T) {
 	}
 
 }
+
+func TestShouldSearchFarmOrderedByRelevance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, latitude, longitude, address, region, timezone, owner_name, contact, metadata, area, boundary FROM farms WHERE (deleted_at IS NULL AND (search @@ to_tsquery('simple', $1) OR name % $2)) ORDER BY ts_rank(search, to_tsquery('simple', $3)) + similarity(name, $4) DESC LIMIT 100 OFFSET 0")).
+		WithArgs("tambak:*", "Tambak", "tambak:*", "Tambak").
+		WillReturnRows(sqlmock.NewRows(farmColumns))
+
+	farmRepo.GetAll(context.Background(), &farmQuery{Keyword: "Tambak", Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldGetFarmSortedAndFilteredByListQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	values, _ := url.ParseQuery("sort=-updated_at&filter[region]=Bali,NTB&filter[updated_at][lt]=2024-08-24T10:00:00Z&fields=name,latitude")
+	list, err := listquery.Parse(values, farmFields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// only selected columns along with id are fetched, requested sort takes precedence over distance which is still selected
+	mock.ExpectQuery(`^SELECT id, name, latitude, longitude, \((.+)\) AS distance FROM farms WHERE \(deleted_at IS NULL AND (.+) <= \$7 AND region IN \(\$8,\$9\) AND updated_at < \$10\) ORDER BY updated_at DESC LIMIT 100 OFFSET 0$`).
+		WithArgs(-8.65, -8.65, 115.22, -8.65, -8.65, 115.22, 10.0, "Bali", "NTB", time.Date(2024, 8, 24, 10, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows(farmColumns))
+
+	farmRepo.GetAll(context.Background(), &farmQuery{Near: &geo.Point{Latitude: -8.65, Longitude: 115.22}, Radius: 10, List: list, Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldCountFarmMatchingKeyword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// count shares the search condition, so total page follows the matching farms
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms WHERE (deleted_at IS NULL AND (search @@ to_tsquery('simple', $1) OR name % $2))")).
+		WithArgs("tambak:*", "tambak").
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+
+	farmRepo.Count(context.Background(), &farmQuery{Keyword: "tambak"})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldCountDeletedFarm(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms WHERE (deleted_at IS NOT NULL AND organization_id = $1)")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+
+	farmRepo.Count(context.Background(), &farmQuery{OrganizationID: 1, Deleted: true})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldRestoreFarmAlongWithItsRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	deletedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT organization_id, name, deleted_at FROM farms WHERE (id = $1 AND deleted_at IS NOT NULL AND organization_id = $2)`)).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"organization_id", "name", "deleted_at"}).AddRow(1, "Farm A", deletedAt))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (organization_id = $1 AND name = $2 AND deleted_at IS NULL)`)).WithArgs(1, "Farm A").
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM ponds WHERE (farm_id = $1 AND deleted_at = $2 AND name in (select name from ponds where deleted_at is null and farm_id in (select id from farms where organization_id = $3)))`)).
+		WithArgs(1, deletedAt, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+
+	// only records deleted along with the farm come back, along with records of its ponds deleted together
+	for i := len(cascade) - 1; i > 6; i-- {
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE "+cascade[i].table+" SET deleted_at = $1, updated_at = NOW() WHERE (farm_id = $2 AND deleted_at = $3)")).WithArgs(nil, 1, deletedAt).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	for i := 6; i > 0; i-- {
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE "+cascade[i].table+" SET deleted_at = $1, updated_at = NOW() WHERE (pond_id in (select id from ponds where farm_id = $2 and deleted_at = $3) AND deleted_at = $4)")).
+			WithArgs(nil, 1, deletedAt, deletedAt).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE ponds SET deleted_at = $1, updated_at = NOW() WHERE (farm_id = $2 AND deleted_at = $3)")).WithArgs(nil, 1, deletedAt).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE farms SET deleted_at = $1, updated_at = NOW() WHERE id = $2")).WithArgs(nil, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err = farmRepo.Restore(context.Background(), &farmQuery{ID: 1, OrganizationID: 1}); err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTRestoreFarmDueNameTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT organization_id, name, deleted_at FROM farms WHERE (id = $1 AND deleted_at IS NOT NULL)`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"organization_id", "name", "deleted_at"}).AddRow(1, "Farm A", time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM farms WHERE (organization_id = $1 AND name = $2 AND deleted_at IS NULL)`)).WithArgs(1, "Farm A").
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectRollback()
+
+	if err = farmRepo.Restore(context.Background(), &farmQuery{ID: 1}); err != errs.ErrDuplicatedResources {
+		t.Errorf("expected ErrDuplicatedResources, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldPurgeFarmAlongWithItsPonds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	farmRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	cutoff := time.Now()
+
+	mock.ExpectBegin()
+	// records of the purged farms goes before their ponds and equipments, which goes before the farms
+	for _, table := range []string{"sensor_readings_1d", "sensor_readings_1h", "sensor_readings_5m", "sensor_readings"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE pond_id in (select id from ponds where farm_id in (select id from farms where deleted_at < $1))")).WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 5))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM equipment_runtimes WHERE equipment_id in (select id from equipments where farm_id in (select id from farms where deleted_at < $1))")).WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM checklist_tasks WHERE farm_id in (select id from farms where deleted_at < $1)")).WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	for _, table := range []string{"checklist_templates", "work_orders", "equipments", "devices", "alerts", "alert_rules"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE farm_id in (select id from farms where deleted_at < $1)")).WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+	}
+	for _, table := range []string{"growth_samples", "harvests", "mortalities", "feedings", "stockings", "water_quality_readings"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE pond_id in (select id from ponds where farm_id in (select id from farms where deleted_at < $1))")).WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 5))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM ponds WHERE farm_id in (select id from farms where deleted_at < $1)")).WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM farm_members WHERE farm_id in (select id from farms where deleted_at < $1)")).WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM farms WHERE deleted_at < $1")).WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	count, err := farmRepo.Purge(context.Background(), cutoff)
+	if err != nil || count != 1 {
+		t.Errorf("unexpected purge result: %d, err: %v", count, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -2,37 +2,52 @@ package farms
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
+	"github.com/nmluci/da-farm-be/internal/core/auth"
 	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/geo"
 	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/domain/audit"
 	"github.com/rs/zerolog"
 )
 
+// DefaultRadius is used on proximity query without explicit radius, in km
+const DefaultRadius = 10
+
 // FarmService contains public API available to be interacted with
 type FarmService interface {
 	GetAll(context.Context, *FarmRequestQuery) (*ListFarmResponse, error)
 	GetOne(context.Context, *FarmRequestQuery) (*FarmResponse, error)
 	Create(context.Context, *FarmPayload) error
 	Update(context.Context, *FarmPayload) error
-	Delete(context.Context, *FarmRequestQuery) error
+	Delete(context.Context, *FarmRequestQuery) (*DeleteFarmResponse, error)
+	Restore(context.Context, *FarmRequestQuery) error
+	Purge(context.Context, *PurgePayload) error
 }
 
 type farmService struct {
-	repo FarmRepository
+	repo     FarmRepository
+	auditSvc audit.AuditService
 }
 
 // NewService return an instance of FarmService containing available usecases
-func NewService(repo FarmRepository) FarmService {
-	return &farmService{repo: repo}
+func NewService(repo FarmRepository, auditSvc audit.AuditService) FarmService {
+	return &farmService{repo: repo, auditSvc: auditSvc}
 }
 
 func (svc *farmService) GetAll(ctx context.Context, params *FarmRequestQuery) (res *ListFarmResponse, err error) {
 	logger := zerolog.Ctx(ctx)
 
 	repoParams := &farmQuery{
-		Keyword: params.Keyword,
-		Limit:   params.Limit,
-		Page:    params.Page,
+		Keyword:        params.Keyword,
+		Region:         params.Region,
+		OrganizationID: auth.OrganizationFromContext(ctx),
+		Deleted:        params.Deleted,
+		List:           params.List,
+		Limit:          params.Limit,
+		Page:           params.Page,
 	}
 
 	if params.Limit >= 100 || params.Limit <= 0 {
@@ -43,11 +58,31 @@ func (svc *farmService) GetAll(ctx context.Context, params *FarmRequestQuery) (r
 		repoParams.Page = 1
 	}
 
+	// non-admin only sees farms they have a role on
+	if identity := auth.FromContext(ctx); identity != nil && !identity.Admin {
+		repoParams.UserID = identity.UserID
+	}
+
+	if params.Near != "" {
+		if repoParams.Near, err = geo.ParsePoint(params.Near); err != nil {
+			return
+		}
+
+		if params.Radius < 0 {
+			return nil, errs.ErrBadRequest
+		}
+
+		repoParams.Radius = params.Radius
+		if repoParams.Radius == 0 {
+			repoParams.Radius = DefaultRadius
+		}
+	}
+
 	res = &ListFarmResponse{
 		Farms: []*FarmResponse{},
 		Meta: httpres.ListPagination{
 			Limit:     repoParams.Limit,
-			Page:      0,
+			Page:      repoParams.Page,
 			TotalPage: 0,
 		},
 	}
@@ -61,7 +96,7 @@ func (svc *farmService) GetAll(ctx context.Context, params *FarmRequestQuery) (r
 	if count == 0 {
 		return nil, errs.ErrNotFound
 	}
-	res.Meta.TotalPage = count / repoParams.Limit
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
 
 	farms, err := svc.repo.GetAll(ctx, repoParams)
 	if err != nil {
@@ -70,10 +105,10 @@ func (svc *farmService) GetAll(ctx context.Context, params *FarmRequestQuery) (r
 	}
 
 	for _, farm := range farms {
-		res.Farms = append(res.Farms, &FarmResponse{
-			ID:   farm.ID,
-			Name: farm.Name,
-		})
+		farmRes := toFarmResponse(farm)
+		farmRes.list = params.List
+
+		res.Farms = append(res.Farms, farmRes)
 	}
 
 	return
@@ -82,7 +117,7 @@ func (svc *farmService) GetAll(ctx context.Context, params *FarmRequestQuery) (r
 func (svc *farmService) GetOne(ctx context.Context, params *FarmRequestQuery) (res *FarmResponse, err error) {
 	logger := zerolog.Ctx(ctx)
 
-	repoParams := &farmQuery{ID: params.ID}
+	repoParams := &farmQuery{ID: params.ID, OrganizationID: auth.OrganizationFromContext(ctx)}
 
 	farm, err := svc.repo.GetOne(ctx, repoParams)
 	if err != nil {
@@ -94,10 +129,7 @@ func (svc *farmService) GetOne(ctx context.Context, params *FarmRequestQuery) (r
 		return nil, errs.ErrNotFound
 	}
 
-	res = &FarmResponse{
-		ID:   farm.ID,
-		Name: farm.Name,
-	}
+	res = toFarmResponse(farm)
 
 	return
 }
@@ -105,8 +137,14 @@ func (svc *farmService) GetOne(ctx context.Context, params *FarmRequestQuery) (r
 func (svc *farmService) Create(ctx context.Context, payload *FarmPayload) (err error) {
 	logger := zerolog.Ctx(ctx)
 
-	data := &FarmType{
-		Name: payload.Name,
+	data, err := toFarmType(payload)
+	if err != nil {
+		return
+	}
+
+	if identity := auth.FromContext(ctx); identity != nil {
+		data.OrganizationID = identity.OrganizationID
+		data.CreatedBy = identity.UserID
 	}
 
 	err = svc.repo.Store(ctx, data)
@@ -115,15 +153,24 @@ func (svc *farmService) Create(ctx context.Context, payload *FarmPayload) (err e
 		return
 	}
 
+	svc.record(ctx, audit.ActionCreate, data.ID, nil, toFarmResponse(data))
+
 	return
 }
 
 func (svc *farmService) Update(ctx context.Context, payload *FarmPayload) (err error) {
 	logger := zerolog.Ctx(ctx)
 
-	data := &FarmType{
-		ID:   payload.ID,
-		Name: payload.Name,
+	data, err := toFarmType(payload)
+	if err != nil {
+		return
+	}
+	data.OrganizationID = auth.OrganizationFromContext(ctx)
+
+	before, err := svc.repo.GetOne(ctx, &farmQuery{ID: data.ID, OrganizationID: data.OrganizationID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
 	}
 
 	err = svc.repo.Upsert(ctx, data)
@@ -132,21 +179,197 @@ func (svc *farmService) Update(ctx context.Context, payload *FarmPayload) (err e
 		return
 	}
 
+	// upsert on non-existing farm creates a new one
+	if before == nil {
+		svc.record(ctx, audit.ActionCreate, data.ID, nil, toFarmResponse(data))
+	} else {
+		svc.record(ctx, audit.ActionUpdate, data.ID, toFarmResponse(before), toFarmResponse(data))
+	}
+
+	return
+}
+
+// Delete soft-delete the farm along with its ponds and their records, so they're restorable together.
+// Dry-run only reports the records which would be deleted
+func (svc *farmService) Delete(ctx context.Context, params *FarmRequestQuery) (res *DeleteFarmResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &farmQuery{
+		ID:             params.ID,
+		OrganizationID: auth.OrganizationFromContext(ctx),
+		DryRun:         params.DryRun,
+	}
+
+	before, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	affected, err := svc.repo.Delete(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	res = &DeleteFarmResponse{DryRun: params.DryRun, Affected: affected}
+
+	if before != nil && !params.DryRun {
+		svc.record(ctx, audit.ActionDelete, before.ID, toFarmResponse(before), nil)
+	}
+
 	return
 }
 
-func (svc *farmService) Delete(ctx context.Context, params *FarmRequestQuery) (err error) {
+// Restore bring back a soft-deleted farm as long as its name isn't taken by an active farm
+func (svc *farmService) Restore(ctx context.Context, params *FarmRequestQuery) (err error) {
 	logger := zerolog.Ctx(ctx)
 
 	repoParams := &farmQuery{
-		ID: params.ID,
+		ID:             params.ID,
+		OrganizationID: auth.OrganizationFromContext(ctx),
+	}
+
+	err = svc.repo.Restore(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	farm, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
 	}
 
-	err = svc.repo.Delete(ctx, repoParams)
+	if farm != nil {
+		svc.record(ctx, audit.ActionRestore, farm.ID, nil, toFarmResponse(farm))
+	}
+
+	return
+}
+
+// Purge permanently remove farms which stayed deleted longer than the retention, meant to be run by scheduler
+func (svc *farmService) Purge(ctx context.Context, payload *PurgePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.Retention <= 0 {
+		return errs.ErrBadRequest
+	}
+
+	count, err := svc.repo.Purge(ctx, time.Now().Add(-payload.Retention))
 	if err != nil {
 		logger.Error().Err(err).Send()
 		return
 	}
 
+	if count != 0 {
+		logger.Info().Int64("count", count).Msg("purged deleted farms")
+	}
+
+	return
+}
+
+// record keep the mutation in audit trail, the mutation is already committed so failure is only logged
+func (svc *farmService) record(ctx context.Context, action string, farmID int64, before, after *FarmResponse) {
+	payload := &audit.RecordPayload{
+		FarmID:   farmID,
+		Entity:   audit.EntityFarm,
+		EntityID: farmID,
+		Action:   action,
+		Before:   before,
+		After:    after,
+	}
+
+	if err := svc.auditSvc.Record(ctx, payload); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("failed to record audit")
+	}
+}
+
+func toFarmType(payload *FarmPayload) (res *FarmType, err error) {
+	// coordinate is only meaningful when both latitude and longitude are given
+	if (payload.Latitude == nil) != (payload.Longitude == nil) {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if payload.Latitude != nil && (*payload.Latitude < -90 || *payload.Latitude > 90 || *payload.Longitude < -180 || *payload.Longitude > 180) {
+		return nil, errs.ErrBadRequest
+	}
+
+	if payload.Area < 0 {
+		return nil, errs.ErrBadRequest
+	}
+
+	if payload.Boundary != nil {
+		if err = payload.Boundary.Validate(); err != nil {
+			return
+		}
+	}
+
+	res = &FarmType{
+		ID:        payload.ID,
+		Name:      payload.Name,
+		Address:   payload.Address,
+		Region:    payload.Region,
+		Timezone:  payload.Timezone,
+		OwnerName: payload.OwnerName,
+		Contact:   payload.Contact,
+		Metadata:  Metadata(payload.Metadata),
+		Area:      payload.Area,
+		Boundary:  payload.Boundary,
+	}
+
+	// surveyed area takes precedence, otherwise derive it from the boundary
+	if res.Area == 0 && res.Boundary != nil {
+		res.Area = res.Boundary.Area()
+	}
+
+	if payload.Latitude != nil {
+		res.Latitude = sql.NullFloat64{Float64: *payload.Latitude, Valid: true}
+		res.Longitude = sql.NullFloat64{Float64: *payload.Longitude, Valid: true}
+	}
+
+	if res.Timezone == "" {
+		res.Timezone = DefaultTimezone
+	}
+
+	if _, err = time.LoadLocation(res.Timezone); err != nil {
+		return nil, errs.ErrBadRequest
+	}
+
+	if res.Metadata == nil {
+		res.Metadata = Metadata{}
+	}
+
 	return
 }
+
+func toFarmResponse(farm *FarmType) *FarmResponse {
+	res := &FarmResponse{
+		ID:        farm.ID,
+		Name:      farm.Name,
+		Address:   farm.Address,
+		Region:    farm.Region,
+		Timezone:  farm.Timezone,
+		OwnerName: farm.OwnerName,
+		Contact:   farm.Contact,
+		Metadata:  farm.Metadata,
+		Area:      farm.Area,
+		Boundary:  farm.Boundary,
+	}
+
+	if farm.Distance.Valid {
+		res.Distance = &farm.Distance.Float64
+	}
+
+	if farm.Latitude.Valid && farm.Longitude.Valid {
+		res.Latitude = &farm.Latitude.Float64
+		res.Longitude = &farm.Longitude.Float64
+	}
+
+	if res.Metadata == nil {
+		res.Metadata = map[string]any{}
+	}
+
+	return res
+}
@@ -0,0 +1,196 @@
+package farms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/geo"
+	"github.com/nmluci/da-farm-be/internal/domain/audit"
+)
+
+type stubFarmRepository struct {
+	FarmRepository
+	current *FarmType
+	stored  *FarmType
+	farms   []*FarmType
+}
+
+func (repo *stubFarmRepository) GetAll(context.Context, *farmQuery) ([]*FarmType, error) {
+	return repo.farms, nil
+}
+
+func (repo *stubFarmRepository) Count(context.Context, *farmQuery) (uint64, error) {
+	return uint64(len(repo.farms)), nil
+}
+
+func (repo *stubFarmRepository) GetOne(_ context.Context, _ *farmQuery) (*FarmType, error) {
+	return repo.current, nil
+}
+
+func (repo *stubFarmRepository) Store(_ context.Context, payload *FarmType) error {
+	payload.ID = 1
+	repo.stored = payload
+	return nil
+}
+
+func (repo *stubFarmRepository) Delete(context.Context, *farmQuery) (Cascade, error) {
+	return Cascade{"farms": 1, "ponds": 2}, nil
+}
+
+func (repo *stubFarmRepository) Upsert(_ context.Context, payload *FarmType) error {
+	repo.stored = payload
+	return nil
+}
+
+type stubAuditService struct {
+	audit.AuditService
+	recorded []*audit.RecordPayload
+}
+
+func (svc *stubAuditService) Record(_ context.Context, payload *audit.RecordPayload) error {
+	svc.recorded = append(svc.recorded, payload)
+	return nil
+}
+
+func TestShouldCreateFarmWithDefaultTimezone(t *testing.T) {
+	repo := &stubFarmRepository{}
+	svc := NewService(repo, &stubAuditService{})
+
+	lat, lon := -8.65, 115.22
+	err := svc.Create(context.Background(), &FarmPayload{Name: "Farm A", Latitude: &lat, Longitude: &lon, Region: "Bali"})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.stored.Timezone != DefaultTimezone || !repo.stored.Latitude.Valid || repo.stored.Metadata == nil {
+		t.Errorf("unexpected stored farm: %+v", repo.stored)
+	}
+}
+
+func TestShouldCreateFarmWithinOrganizationOfCreator(t *testing.T) {
+	repo := &stubFarmRepository{}
+	svc := NewService(repo, &stubAuditService{})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 2, OrganizationID: 3})
+
+	if err := svc.Create(ctx, &FarmPayload{Name: "Farm A"}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.stored.OrganizationID != 3 || repo.stored.CreatedBy != 2 {
+		t.Errorf("unexpected stored farm: %+v", repo.stored)
+	}
+}
+
+func TestShouldRecordCreatedFarm(t *testing.T) {
+	auditSvc := &stubAuditService{}
+	svc := NewService(&stubFarmRepository{}, auditSvc)
+
+	if err := svc.Create(context.Background(), &FarmPayload{Name: "Farm A"}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(auditSvc.recorded) != 1 || auditSvc.recorded[0].Action != audit.ActionCreate || auditSvc.recorded[0].EntityID != 1 {
+		t.Errorf("unexpected recorded audit: %+v", auditSvc.recorded)
+	}
+}
+
+func TestShouldRecordStateBeforeUpdatingFarm(t *testing.T) {
+	repo := &stubFarmRepository{current: &FarmType{ID: 1, Name: "Farm A", Timezone: DefaultTimezone}}
+	auditSvc := &stubAuditService{}
+	svc := NewService(repo, auditSvc)
+
+	if err := svc.Update(context.Background(), &FarmPayload{ID: 1, Name: "Farm B"}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(auditSvc.recorded) != 1 || auditSvc.recorded[0].Action != audit.ActionUpdate {
+		t.Fatalf("unexpected recorded audit: %+v", auditSvc.recorded)
+	}
+
+	if before := auditSvc.recorded[0].Before.(*FarmResponse); before.Name != "Farm A" {
+		t.Errorf("unexpected state before update: %+v", before)
+	}
+}
+
+func TestShouldNOTCreateFarmWithPartialCoordinate(t *testing.T) {
+	svc := NewService(&stubFarmRepository{}, &stubAuditService{})
+
+	lat := -8.65
+	if err := svc.Create(context.Background(), &FarmPayload{Name: "Farm A", Latitude: &lat}); err != errs.ErrMissingRequiredAttribute {
+		t.Errorf("expected missing attribute, got: %v", err)
+	}
+}
+
+func TestShouldNOTCreateFarmWithUnknownTimezone(t *testing.T) {
+	svc := NewService(&stubFarmRepository{}, &stubAuditService{})
+
+	if err := svc.Create(context.Background(), &FarmPayload{Name: "Farm A", Timezone: "Mars/Olympus"}); err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+}
+
+func TestShouldDeriveFarmAreaFromBoundary(t *testing.T) {
+	repo := &stubFarmRepository{}
+	svc := NewService(repo, &stubAuditService{})
+
+	// roughly 100m x 100m square around the equator
+	boundary := &geo.Polygon{Type: geo.TypePolygon, Coordinates: [][][]float64{{{0, 0}, {0.0009, 0}, {0.0009, 0.0009}, {0, 0.0009}, {0, 0}}}}
+	if err := svc.Create(context.Background(), &FarmPayload{Name: "Farm A", Boundary: boundary}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.stored.Area < 10000 || repo.stored.Area > 10030 {
+		t.Errorf("unexpected derived area: %f", repo.stored.Area)
+	}
+}
+
+func TestShouldNOTCreateFarmWithOpenBoundary(t *testing.T) {
+	svc := NewService(&stubFarmRepository{}, &stubAuditService{})
+
+	boundary := &geo.Polygon{Type: geo.TypePolygon, Coordinates: [][][]float64{{{0, 0}, {0.0009, 0}, {0.0009, 0.0009}, {0, 0.0009}}}}
+	if err := svc.Create(context.Background(), &FarmPayload{Name: "Farm A", Boundary: boundary}); err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+}
+
+func TestShouldRoundUpTotalPageOfSearchResult(t *testing.T) {
+	repo := &stubFarmRepository{farms: []*FarmType{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}}
+	svc := NewService(repo, &stubAuditService{})
+
+	res, err := svc.GetAll(context.Background(), &FarmRequestQuery{Keyword: "tambak", Limit: 2, Page: 3})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if res.Meta.Page != 3 || res.Meta.TotalPage != 3 || res.Meta.Limit != 2 {
+		t.Errorf("unexpected meta: %+v", res.Meta)
+	}
+}
+
+func TestShouldNOTGetFarmNearMalformedCoordinate(t *testing.T) {
+	svc := NewService(&stubFarmRepository{}, &stubAuditService{})
+
+	if _, err := svc.GetAll(context.Background(), &FarmRequestQuery{Near: "-8.65"}); err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+}
+
+func TestShouldNOTRecordFarmDeletionOnDryRun(t *testing.T) {
+	auditSvc := &stubAuditService{}
+	svc := NewService(&stubFarmRepository{current: &FarmType{ID: 1, Name: "Farm A"}}, auditSvc)
+
+	res, err := svc.Delete(context.Background(), &FarmRequestQuery{ID: 1, DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if !res.DryRun || res.Affected["ponds"] != 2 {
+		t.Errorf("unexpected response: %+v", res)
+	}
+
+	if len(auditSvc.recorded) != 0 {
+		t.Errorf("expected nothing recorded, got: %+v", auditSvc.recorded)
+	}
+}
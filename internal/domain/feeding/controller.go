@@ -0,0 +1,39 @@
+package feeding
+
+import "github.com/labstack/echo/v4"
+
+type FeedingController struct {
+	svc FeedingService
+}
+
+func NewController(svc FeedingService) *FeedingController {
+	return &FeedingController{
+		svc: svc,
+	}
+}
+
+const (
+	feedingBasepath = "/farms/:farmID/ponds/:pondID"
+	feedingPath     = "/feedings"
+	feedingIDPath   = "/feedings/:feedingID"
+	fcrPath         = "/stockings/:stockingID/fcr"
+)
+
+func (fc *FeedingController) Route(grp *echo.Group) {
+	subrouter := grp.Group(feedingBasepath)
+
+	subrouter.GET(feedingPath, HandleGetAllFeeding(fc.svc.GetAll))
+	subrouter.OPTIONS(feedingPath, HandleGetAllFeeding(fc.svc.GetAll))
+	subrouter.GET(feedingIDPath, HandleGetOneFeeding(fc.svc.GetOne))
+	subrouter.OPTIONS(feedingIDPath, HandleGetOneFeeding(fc.svc.GetOne))
+	subrouter.POST(feedingPath, HandleCreateFeeding(fc.svc.Create))
+	subrouter.OPTIONS(feedingPath, HandleCreateFeeding(fc.svc.Create))
+	subrouter.PUT(feedingIDPath, HandleUpdateFeeding(fc.svc.Update))
+	subrouter.OPTIONS(feedingIDPath, HandleUpdateFeeding(fc.svc.Update))
+	subrouter.DELETE(feedingIDPath, HandleDeleteFeeding(fc.svc.Delete))
+	subrouter.OPTIONS(feedingIDPath, HandleDeleteFeeding(fc.svc.Delete))
+	subrouter.GET(fcrPath, HandleGetFCR(fc.svc.GetFCR))
+	subrouter.OPTIONS(fcrPath, HandleGetFCR(fc.svc.GetFCR))
+
+	return
+}
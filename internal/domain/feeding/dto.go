@@ -0,0 +1,70 @@
+package feeding
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// FeedingRequestQuery represent query parameters fetch from request
+type FeedingRequestQuery struct {
+	ID         int64     `param:"feedingID" example:"1"`
+	FarmID     int64     `param:"farmID" example:"1"`
+	PondID     int64     `param:"pondID" example:"1"`
+	StockingID int64     `query:"stocking_id" example:"1"`
+	From       time.Time `query:"from" example:"2024-07-01T00:00:00Z"`
+	To         time.Time `query:"to" example:"2024-07-02T00:00:00Z"`
+	Limit      uint64    `query:"limit" example:"100"`
+	Page       uint64    `query:"page" example:"2"`
+}
+
+// FeedingPayload represent payload fetch from request body
+type FeedingPayload struct {
+	ID         int64     `param:"feedingID" json:"-" example:"1"`
+	FarmID     int64     `param:"farmID" json:"-" example:"1"`
+	PondID     int64     `param:"pondID" json:"-" example:"1"`
+	StockingID int64     `json:"stocking_id" example:"1"`
+	FeedType   string    `json:"feed_type" example:"grower"`
+	Brand      string    `json:"brand" example:"Brand A"`
+	Quantity   float64   `json:"quantity" example:"12.5"`
+	FedAt      time.Time `json:"fed_at" example:"2024-07-01T07:00:00Z"`
+}
+
+// FeedingResponse represent domain response for Feeding entity
+type FeedingResponse struct {
+	ID         int64     `json:"id" example:"1"`
+	PondID     int64     `json:"pond_id" example:"1"`
+	StockingID int64     `json:"stocking_id" example:"1"`
+	FeedType   string    `json:"feed_type" example:"grower"`
+	Brand      string    `json:"brand" example:"Brand A"`
+	Quantity   float64   `json:"quantity" example:"12.5"`
+	FedAt      time.Time `json:"fed_at" example:"2024-07-01T07:00:00Z"`
+}
+
+// ListFeedingResponse represent domain response for bulk Feeding entities
+type ListFeedingResponse struct {
+	Feedings []*FeedingResponse     `json:"feedings"`
+	Meta     httpres.ListPagination `json:"meta"`
+}
+
+// FCRRequestQuery represent query parameters fetch from request to compute batch's FCR
+type FCRRequestQuery struct {
+	FarmID     int64   `param:"farmID" example:"1"`
+	PondID     int64   `param:"pondID" example:"1"`
+	StockingID int64   `param:"stockingID" example:"1"`
+	AvgWeight  float64 `query:"avg_weight" example:"12.5"` // average body weight in gram, default to the latest growth sample
+	Headcount  int64   `query:"headcount" example:"90000"` // estimated live fish, default to stocked count minus recorded mortality and harvest
+}
+
+// FCRResponse represent domain response for batch's cumulative Feed Conversion Ratio
+type FCRResponse struct {
+	StockingID       int64   `json:"stocking_id" example:"1"`
+	TotalFeed        float64 `json:"total_feed" example:"1350"`
+	Headcount        int64   `json:"headcount" example:"90000"`
+	AvgWeight        float64 `json:"avg_weight" example:"12.5"`
+	InitialBiomass   float64 `json:"initial_biomass" example:"2"`
+	CurrentBiomass   float64 `json:"current_biomass" example:"1125"`
+	HarvestedBiomass float64 `json:"harvested_biomass" example:"0"`
+	BiomassGain      float64 `json:"biomass_gain" example:"1123"`
+	FCR              float64 `json:"fcr" example:"1.2"`
+}
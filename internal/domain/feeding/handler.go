@@ -0,0 +1,237 @@
+package feeding
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllFeedingHandler func(context.Context, *FeedingRequestQuery) (*ListFeedingResponse, error)
+
+// Get All Feeding godoc
+//
+//	@Summary	get all feeding logs of a pond
+//	@Tags		Feeding
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		pondID		path		int		true	"Pond ID"
+//	@Param		stocking_id	query		int		false	"only return feeding of the batch"
+//	@Param		from		query		string	false	"start of time window (inclusive), RFC3339"
+//	@Param		to			query		string	false	"end of time window (exclusive), RFC3339"
+//	@Param		limit		query		string	false	"number of entity per page"
+//	@Param		page		query		string	false	"n-th page"
+//	@Success	200			{object}	ListFeedingResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"invalid time window"
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/feedings [get]
+func HandleGetAllFeeding(handler GetAllFeedingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &FeedingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneFeedingHandler func(context.Context, *FeedingRequestQuery) (*FeedingResponse, error)
+
+// Get One Feeding godoc
+//
+//	@Summary	get specific feeding log by ID
+//	@Tags		Feeding
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		feedingID	path		int	true	"Feeding ID"
+//	@Success	200			{object}	FeedingResponse
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/feedings/{feedingID} [get]
+func HandleGetOneFeeding(handler GetOneFeedingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &FeedingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateFeedingHandler func(context.Context, *FeedingPayload) error
+
+// CreateFeeding godoc
+//
+//	@Summary	record feed given in a feeding session
+//	@Tags		Feeding
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int				true	"Farm ID"
+//	@Param		pondID	path		int				true	"Pond ID"
+//	@Param		payload	body		FeedingPayload	true	"feeding payload"
+//	@Success	201		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"batch not existed in the pond"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/feedings [post]
+func HandleCreateFeeding(handler CreateFeedingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &FeedingPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateFeedingHandler func(context.Context, *FeedingPayload) error
+
+// Update Feeding godoc
+//
+//	@Summary	update feeding log data
+//	@Tags		Feeding
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int				true	"Farm ID"
+//	@Param		pondID		path		int				true	"Pond ID"
+//	@Param		feedingID	path		int				true	"Feeding ID"
+//	@Param		payload		body		FeedingPayload	true	"feeding payload"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"feeding not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/feedings/{feedingID} [put]
+func HandleUpdateFeeding(handler UpdateFeedingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &FeedingPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteFeedingHandler func(context.Context, *FeedingRequestQuery) error
+
+// DeleteFeeding godoc
+//
+//	@Summary	delete specific feeding log by ID
+//	@Tags		Feeding
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		feedingID	path		int	true	"Feeding ID"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"feeding not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/feedings/{feedingID} [delete]
+func HandleDeleteFeeding(handler DeleteFeedingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &FeedingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetFCRHandler func(context.Context, *FCRRequestQuery) (*FCRResponse, error)
+
+// Get FCR godoc
+//
+//	@Summary	compute cumulative feed conversion ratio of a batch
+//	@Tags		Feeding
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		pondID		path		int		true	"Pond ID"
+//	@Param		stockingID	path		int		true	"Stocking ID"
+//	@Param		avg_weight	query		number	false	"average body weight in gram, default to the latest growth sample"
+//	@Param		headcount	query		int		false	"estimated live fish, default to live headcount from mortality records"
+//	@Success	200			{object}	FCRResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"batch never sampled"
+//	@Failure	404			{object}	httpres.ErrorResponse	"batch not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/stockings/{stockingID}/fcr [get]
+func HandleGetFCR(handler GetFCRHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &FCRRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
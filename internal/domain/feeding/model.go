@@ -0,0 +1,13 @@
+package feeding
+
+import "time"
+
+type FeedingType struct {
+	ID         int64     `db:"id"`
+	PondID     int64     `db:"pond_id"`
+	StockingID int64     `db:"stocking_id"`
+	FeedType   string    `db:"feed_type"`
+	Brand      string    `db:"brand"`
+	Quantity   float64   `db:"quantity"`
+	FedAt      time.Time `db:"fed_at"`
+}
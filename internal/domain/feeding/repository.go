@@ -0,0 +1,313 @@
+package feeding
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// FeedingRepository contain contract that defined all necessary public function available to be interact with
+type FeedingRepository interface {
+	GetAll(context.Context, *feedingQuery) ([]*FeedingType, error)
+	Count(context.Context, *feedingQuery) (uint64, error)
+	GetOne(context.Context, *feedingQuery) (*FeedingType, error)
+	SumQuantity(context.Context, *feedingQuery) (float64, error)
+	SumHarvestWeight(context.Context, *feedingQuery) (float64, error)
+	Store(context.Context, int64, *FeedingType) error
+	Update(context.Context, int64, *FeedingType) error
+	Delete(context.Context, *feedingQuery) error
+}
+
+type feedingRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of feedingRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) FeedingRepository {
+	return &feedingRepository{db: db}
+}
+
+type feedingQuery struct {
+	ID, FarmID, PondID, StockingID int64
+	From, To                       time.Time
+	Limit, Page                    uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var feedingColumns = []string{"fd.id", "fd.pond_id", "fd.stocking_id", "fd.feed_type", "fd.brand", "fd.quantity", "fd.fed_at"}
+
+func (params *feedingQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"fd.pond_id": params.PondID},
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"fd.deleted_at": nil},
+	}
+
+	if params.StockingID != 0 {
+		cond = append(cond, squirrel.Eq{"fd.stocking_id": params.StockingID})
+	}
+
+	if !params.From.IsZero() {
+		cond = append(cond, squirrel.GtOrEq{"fd.fed_at": params.From})
+	}
+
+	if !params.To.IsZero() {
+		cond = append(cond, squirrel.Lt{"fd.fed_at": params.To})
+	}
+
+	return cond
+}
+
+func (repo *feedingRepository) GetAll(ctx context.Context, params *feedingQuery) (res []*FeedingType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(feedingColumns...).From("feedings fd").
+		Join("ponds p on fd.pond_id = p.id").
+		Where(params.filter()).
+		OrderBy("fd.fed_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*FeedingType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &FeedingType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *feedingRepository) Count(ctx context.Context, params *feedingQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("feedings fd").
+		Join("ponds p on fd.pond_id = p.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *feedingRepository) GetOne(ctx context.Context, params *feedingQuery) (res *FeedingType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(feedingColumns...).From("feedings fd").
+		Join("ponds p on fd.pond_id = p.id").
+		Where(append(params.filter(), squirrel.Eq{"fd.id": params.ID})).ToSql()
+
+	res = &FeedingType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// SumQuantity return total feed (in kg) given matching the query
+func (repo *feedingRepository) SumQuantity(ctx context.Context, params *feedingQuery) (res float64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("coalesce(sum(fd.quantity), 0)").From("feedings fd").
+		Join("ponds p on fd.pond_id = p.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+// SumHarvestWeight return total weight (in kg) harvested out of the queried batch
+func (repo *feedingRepository) SumHarvestWeight(ctx context.Context, params *feedingQuery) (res float64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("coalesce(sum(h.weight), 0)").From("harvests h").
+		Join("ponds p on h.pond_id = p.id").
+		Where(squirrel.And{
+			squirrel.Eq{"h.pond_id": params.PondID},
+			squirrel.Eq{"p.farm_id": params.FarmID},
+			squirrel.Eq{"h.stocking_id": params.StockingID},
+			squirrel.Eq{"p.deleted_at": nil},
+			squirrel.Eq{"h.deleted_at": nil},
+		}).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+// validate make sure the fed batch is stocked in a pond within the farm
+func (repo *feedingRepository) validate(ctx context.Context, tx *sqlx.Tx, farmID int64, payload *FeedingType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var count int64
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("stockings s").
+		Join("ponds p on s.pond_id = p.id").
+		Where(squirrel.And{
+			squirrel.Eq{"s.id": payload.StockingID},
+			squirrel.Eq{"s.pond_id": payload.PondID},
+			squirrel.Eq{"p.farm_id": farmID},
+			squirrel.Eq{"p.deleted_at": nil},
+			squirrel.Eq{"s.deleted_at": nil},
+		}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate stocking data existence")
+		return
+	}
+
+	// if selected batch doesn't exists in the pond, bail out from here
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+func (repo *feedingRepository) Store(ctx context.Context, farmID int64, payload *FeedingType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("feedings").
+		Columns("pond_id", "stocking_id", "feed_type", "brand", "quantity", "fed_at").
+		Values(payload.PondID, payload.StockingID, payload.FeedType, payload.Brand, payload.Quantity, payload.FedAt).ToSql()
+
+	_, err = tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *feedingRepository) Update(ctx context.Context, farmID int64, payload *FeedingType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("feedings").SetMap(map[string]interface{}{
+		"stocking_id": payload.StockingID,
+		"feed_type":   payload.FeedType,
+		"brand":       payload.Brand,
+		"quantity":    payload.Quantity,
+		"fed_at":      payload.FedAt,
+		"updated_at":  squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"pond_id": payload.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *feedingRepository) Delete(ctx context.Context, params *feedingQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("feedings").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"pond_id": params.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("feeding doesn't exists")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,128 @@
+package feeding
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestShouldGetFeedingWithResult(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	feedingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "pond_id", "stocking_id", "feed_type", "brand", "quantity", "fed_at"}).
+		AddRow(1, 1, 1, "grower", "Brand A", 12.5, time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT fd.id, fd.pond_id, fd.stocking_id, fd.feed_type, fd.brand, fd.quantity, fd.fed_at FROM feedings fd JOIN ponds p on fd.pond_id = p.id WHERE (fd.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND fd.deleted_at IS NULL AND fd.stocking_id = $3) ORDER BY fd.fed_at desc LIMIT 100 OFFSET 0")).
+		WithArgs(1, 1, 1).
+		WillReturnRows(rows)
+
+	feedingRepo.GetAll(context.Background(), &feedingQuery{FarmID: 1, PondID: 1, StockingID: 1, Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldSumFeedingQuantityOfBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	feedingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT coalesce(sum(fd.quantity), 0) FROM feedings fd JOIN ponds p on fd.pond_id = p.id WHERE (fd.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND fd.deleted_at IS NULL AND fd.stocking_id = $3)")).
+		WithArgs(1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1350.5))
+
+	res, err := feedingRepo.SumQuantity(context.Background(), &feedingQuery{FarmID: 1, PondID: 1, StockingID: 1})
+	if err != nil || res != 1350.5 {
+		t.Errorf("expected total feed of 1350.5, got %f, err: %s", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldSumHarvestWeightOfBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	feedingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT coalesce(sum(h.weight), 0) FROM harvests h JOIN ponds p on h.pond_id = p.id WHERE (h.pond_id = $1 AND p.farm_id = $2 AND h.stocking_id = $3 AND p.deleted_at IS NULL AND h.deleted_at IS NULL)")).
+		WithArgs(1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(488.5))
+
+	res, err := feedingRepo.SumHarvestWeight(context.Background(), &feedingQuery{FarmID: 1, PondID: 1, StockingID: 1})
+	if err != nil || res != 488.5 {
+		t.Errorf("expected harvested weight of 488.5, got %f, err: %s", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreFeeding(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	feedingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	fedAt := time.Date(2024, 7, 1, 7, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.id = $1 AND s.pond_id = $2 AND p.farm_id = $3 AND p.deleted_at IS NULL AND s.deleted_at IS NULL)")).WithArgs(1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO feedings (pond_id,stocking_id,feed_type,brand,quantity,fed_at) VALUES ($1,$2,$3,$4,$5,$6)")).
+		WithArgs(1, 1, "grower", "Brand A", 12.5, fedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	feedingRepo.Store(context.Background(), 1, &FeedingType{PondID: 1, StockingID: 1, FeedType: "grower", Brand: "Brand A", Quantity: 12.5, FedAt: fedAt})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreFeedingDueBatchNotExisted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	feedingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.id = $1 AND s.pond_id = $2 AND p.farm_id = $3 AND p.deleted_at IS NULL AND s.deleted_at IS NULL)")).WithArgs(2, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectRollback()
+
+	feedingRepo.Store(context.Background(), 1, &FeedingType{PondID: 1, StockingID: 2, FeedType: "grower", Quantity: 12.5, FedAt: time.Now()})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
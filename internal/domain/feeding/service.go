@@ -0,0 +1,307 @@
+package feeding
+
+import (
+	"context"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/domain/growth"
+	"github.com/nmluci/da-farm-be/internal/domain/mortality"
+	"github.com/nmluci/da-farm-be/internal/domain/stocking"
+	"github.com/rs/zerolog"
+)
+
+// FeedingService contains public API available to be interacted with
+type FeedingService interface {
+	GetAll(context.Context, *FeedingRequestQuery) (*ListFeedingResponse, error)
+	GetOne(context.Context, *FeedingRequestQuery) (*FeedingResponse, error)
+	Create(context.Context, *FeedingPayload) error
+	Update(context.Context, *FeedingPayload) error
+	Delete(context.Context, *FeedingRequestQuery) error
+	GetFCR(context.Context, *FCRRequestQuery) (*FCRResponse, error)
+}
+
+type feedingService struct {
+	repo         FeedingRepository
+	stockingSvc  stocking.StockingService
+	mortalitySvc mortality.MortalityService
+	growthSvc    growth.GrowthService
+}
+
+// NewService return an instance of FeedingService containing available usecases
+func NewService(repo FeedingRepository, stockingSvc stocking.StockingService, mortalitySvc mortality.MortalityService, growthSvc growth.GrowthService) FeedingService {
+	return &feedingService{repo: repo, stockingSvc: stockingSvc, mortalitySvc: mortalitySvc, growthSvc: growthSvc}
+}
+
+func (svc *feedingService) GetAll(ctx context.Context, params *FeedingRequestQuery) (res *ListFeedingResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &feedingQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: params.StockingID,
+		From:       params.From,
+		To:         params.To,
+		Limit:      params.Limit,
+		Page:       params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &ListFeedingResponse{
+		Feedings: []*FeedingResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	feedings, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, feeding := range feedings {
+		res.Feedings = append(res.Feedings, toFeedingResponse(feeding))
+	}
+
+	return
+}
+
+func (svc *feedingService) GetOne(ctx context.Context, params *FeedingRequestQuery) (res *FeedingResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &feedingQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	feeding, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if feeding == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toFeedingResponse(feeding), nil
+}
+
+func (svc *feedingService) Create(ctx context.Context, payload *FeedingPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toFeedingType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Store(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *feedingService) Update(ctx context.Context, payload *FeedingPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toFeedingType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Update(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *feedingService) Delete(ctx context.Context, params *FeedingRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &feedingQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	// make sure the feeding belongs to requested farm and pond
+	feeding, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if feeding == nil {
+		return errs.ErrNotFound
+	}
+
+	err = svc.repo.Delete(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// GetFCR compute cumulative Feed Conversion Ratio of a batch, which is total feed given
+// divided by biomass gained since stocking. Biomass is estimated from sampled average
+// body weight (in gram) multiplied by the batch's live headcount, plus the biomass already harvested.
+func (svc *feedingService) GetFCR(ctx context.Context, params *FCRRequestQuery) (res *FCRResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	batch, err := svc.stockingSvc.GetOne(ctx, &stocking.StockingRequestQuery{
+		ID:     params.StockingID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	totalFeed, err := svc.repo.SumQuantity(ctx, &feedingQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: params.StockingID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	// unless overridden, average body weight is taken from the latest growth sample
+	avgWeight := params.AvgWeight
+	if avgWeight <= 0 {
+		estimate, err := svc.growthSvc.GetEstimate(ctx, &growth.EstimateRequestQuery{
+			FarmID:     params.FarmID,
+			PondID:     params.PondID,
+			StockingID: params.StockingID,
+		})
+		if err == errs.ErrNotFound {
+			return nil, errs.ErrMissingRequiredAttribute
+		} else if err != nil {
+			logger.Error().Err(err).Send()
+			return nil, err
+		}
+
+		avgWeight = estimate.AvgWeight
+	}
+
+	// unless overridden, live headcount is derived from recorded mortality and harvest
+	headcount := params.Headcount
+	if headcount <= 0 {
+		survival, err := svc.mortalitySvc.GetBatchSurvival(ctx, &mortality.SurvivalRequestQuery{
+			FarmID:     params.FarmID,
+			PondID:     params.PondID,
+			StockingID: params.StockingID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Send()
+			return nil, err
+		}
+
+		headcount = survival.Headcount
+	}
+
+	// biomass harvested out of the pond is still grown by the feed given
+	harvested, err := svc.repo.SumHarvestWeight(ctx, &feedingQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: params.StockingID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	res = &FCRResponse{
+		StockingID:       batch.ID,
+		TotalFeed:        totalFeed,
+		Headcount:        headcount,
+		AvgWeight:        avgWeight,
+		InitialBiomass:   float64(batch.InitialCount) * batch.AvgWeight / 1000,
+		CurrentBiomass:   float64(headcount) * avgWeight / 1000,
+		HarvestedBiomass: harvested,
+	}
+	res.BiomassGain = res.CurrentBiomass + res.HarvestedBiomass - res.InitialBiomass
+
+	// FCR is undefined until the batch gain any weight
+	if res.BiomassGain > 0 {
+		res.FCR = res.TotalFeed / res.BiomassGain
+	}
+
+	return
+}
+
+func toFeedingType(payload *FeedingPayload) (res *FeedingType, err error) {
+	if payload.StockingID == 0 || payload.FeedType == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if payload.Quantity <= 0 {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &FeedingType{
+		ID:         payload.ID,
+		PondID:     payload.PondID,
+		StockingID: payload.StockingID,
+		FeedType:   payload.FeedType,
+		Brand:      payload.Brand,
+		Quantity:   payload.Quantity,
+		FedAt:      payload.FedAt,
+	}
+
+	// feeding without explicit timestamp is assumed to be given right now
+	if res.FedAt.IsZero() {
+		res.FedAt = time.Now()
+	}
+
+	return
+}
+
+func toFeedingResponse(feeding *FeedingType) *FeedingResponse {
+	return &FeedingResponse{
+		ID:         feeding.ID,
+		PondID:     feeding.PondID,
+		StockingID: feeding.StockingID,
+		FeedType:   feeding.FeedType,
+		Brand:      feeding.Brand,
+		Quantity:   feeding.Quantity,
+		FedAt:      feeding.FedAt,
+	}
+}
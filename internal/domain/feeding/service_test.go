@@ -0,0 +1,121 @@
+package feeding
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/domain/growth"
+	"github.com/nmluci/da-farm-be/internal/domain/mortality"
+	"github.com/nmluci/da-farm-be/internal/domain/stocking"
+)
+
+type stubFeedingRepository struct {
+	FeedingRepository
+	totalFeed float64
+	harvested float64
+}
+
+func (repo *stubFeedingRepository) SumQuantity(context.Context, *feedingQuery) (float64, error) {
+	return repo.totalFeed, nil
+}
+
+func (repo *stubFeedingRepository) SumHarvestWeight(context.Context, *feedingQuery) (float64, error) {
+	return repo.harvested, nil
+}
+
+type stubStockingService struct {
+	stocking.StockingService
+	batch *stocking.StockingResponse
+}
+
+func (svc *stubStockingService) GetOne(context.Context, *stocking.StockingRequestQuery) (*stocking.StockingResponse, error) {
+	return svc.batch, nil
+}
+
+type stubMortalityService struct {
+	mortality.MortalityService
+	headcount int64
+}
+
+func (svc *stubMortalityService) GetBatchSurvival(context.Context, *mortality.SurvivalRequestQuery) (*mortality.BatchSurvivalResponse, error) {
+	return &mortality.BatchSurvivalResponse{Headcount: svc.headcount}, nil
+}
+
+type stubGrowthService struct {
+	growth.GrowthService
+	estimate *growth.EstimateResponse
+}
+
+func (svc *stubGrowthService) GetEstimate(context.Context, *growth.EstimateRequestQuery) (*growth.EstimateResponse, error) {
+	if svc.estimate == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return svc.estimate, nil
+}
+
+func TestShouldComputeFCR(t *testing.T) {
+	svc := NewService(
+		&stubFeedingRepository{totalFeed: 1320},
+		&stubStockingService{batch: &stocking.StockingResponse{ID: 1, InitialCount: 100000, AvgWeight: 0.2}},
+		&stubMortalityService{},
+		&stubGrowthService{},
+	)
+
+	res, err := svc.GetFCR(context.Background(), &FCRRequestQuery{FarmID: 1, PondID: 1, StockingID: 1, AvgWeight: 12.2, Headcount: 90000})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	// biomass gain = (90000 * 12.2 - 100000 * 0.2) / 1000 = 1078 kg
+	if math.Abs(res.BiomassGain-1078) > 1e-6 || math.Abs(res.FCR-1320.0/1078) > 1e-6 {
+		t.Errorf("unexpected FCR result: %+v", res)
+	}
+}
+
+func TestShouldNOTComputeFCRWithoutSampledWeight(t *testing.T) {
+	svc := NewService(&stubFeedingRepository{}, &stubStockingService{batch: &stocking.StockingResponse{ID: 1}}, &stubMortalityService{}, &stubGrowthService{})
+
+	if _, err := svc.GetFCR(context.Background(), &FCRRequestQuery{FarmID: 1, PondID: 1, StockingID: 1}); err == nil {
+		t.Errorf("expected err due batch never sampled")
+	}
+}
+
+func TestShouldComputeFCRFromLatestSampleAndLiveHeadcount(t *testing.T) {
+	svc := NewService(
+		&stubFeedingRepository{totalFeed: 1320},
+		&stubStockingService{batch: &stocking.StockingResponse{ID: 1, InitialCount: 100000, AvgWeight: 0.2}},
+		&stubMortalityService{headcount: 90000},
+		&stubGrowthService{estimate: &growth.EstimateResponse{AvgWeight: 12.2}},
+	)
+
+	res, err := svc.GetFCR(context.Background(), &FCRRequestQuery{FarmID: 1, PondID: 1, StockingID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if res.Headcount != 90000 || math.Abs(res.BiomassGain-1078) > 1e-6 {
+		t.Errorf("unexpected FCR result: %+v", res)
+	}
+}
+
+func TestShouldComputeFCRAlongWithHarvestedBiomass(t *testing.T) {
+	svc := NewService(
+		&stubFeedingRepository{totalFeed: 1320, harvested: 488},
+		&stubStockingService{batch: &stocking.StockingResponse{ID: 1, InitialCount: 100000, AvgWeight: 0.2}},
+		&stubMortalityService{headcount: 50000},
+		&stubGrowthService{estimate: &growth.EstimateResponse{AvgWeight: 12.2}},
+	)
+
+	res, err := svc.GetFCR(context.Background(), &FCRRequestQuery{FarmID: 1, PondID: 1, StockingID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	// 40000 fish weighing 488 kg were harvested, the feed still grew them
+	if math.Abs(res.CurrentBiomass-610) > 1e-6 || math.Abs(res.BiomassGain-1078) > 1e-6 || math.Abs(res.FCR-1320.0/1078) > 1e-6 {
+		t.Errorf("unexpected FCR result: %+v", res)
+	}
+}
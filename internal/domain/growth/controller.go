@@ -0,0 +1,39 @@
+package growth
+
+import "github.com/labstack/echo/v4"
+
+type GrowthController struct {
+	svc GrowthService
+}
+
+func NewController(svc GrowthService) *GrowthController {
+	return &GrowthController{
+		svc: svc,
+	}
+}
+
+const (
+	growthBasepath = "/farms/:farmID/ponds/:pondID"
+	samplePath     = "/samples"
+	sampleIDPath   = "/samples/:sampleID"
+	estimatePath   = "/growth"
+)
+
+func (gc *GrowthController) Route(grp *echo.Group) {
+	subrouter := grp.Group(growthBasepath)
+
+	subrouter.GET(samplePath, HandleGetAllSample(gc.svc.GetAll))
+	subrouter.OPTIONS(samplePath, HandleGetAllSample(gc.svc.GetAll))
+	subrouter.GET(sampleIDPath, HandleGetOneSample(gc.svc.GetOne))
+	subrouter.OPTIONS(sampleIDPath, HandleGetOneSample(gc.svc.GetOne))
+	subrouter.POST(samplePath, HandleCreateSample(gc.svc.Create))
+	subrouter.OPTIONS(samplePath, HandleCreateSample(gc.svc.Create))
+	subrouter.PUT(sampleIDPath, HandleUpdateSample(gc.svc.Update))
+	subrouter.OPTIONS(sampleIDPath, HandleUpdateSample(gc.svc.Update))
+	subrouter.DELETE(sampleIDPath, HandleDeleteSample(gc.svc.Delete))
+	subrouter.OPTIONS(sampleIDPath, HandleDeleteSample(gc.svc.Delete))
+	subrouter.GET(estimatePath, HandleGetEstimate(gc.svc.GetEstimate))
+	subrouter.OPTIONS(estimatePath, HandleGetEstimate(gc.svc.GetEstimate))
+
+	return
+}
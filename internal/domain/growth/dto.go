@@ -0,0 +1,71 @@
+package growth
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// SampleRequestQuery represent query parameters fetch from request
+type SampleRequestQuery struct {
+	ID         int64     `param:"sampleID" example:"1"`
+	FarmID     int64     `param:"farmID" example:"1"`
+	PondID     int64     `param:"pondID" example:"1"`
+	StockingID int64     `query:"stocking_id" example:"1"`
+	From       time.Time `query:"from" example:"2024-07-01T00:00:00Z"`
+	To         time.Time `query:"to" example:"2024-08-01T00:00:00Z"`
+	Limit      uint64    `query:"limit" example:"100"`
+	Page       uint64    `query:"page" example:"2"`
+}
+
+// SamplePayload represent payload fetch from request body
+type SamplePayload struct {
+	ID          int64     `param:"sampleID" json:"-" example:"1"`
+	FarmID      int64     `param:"farmID" json:"-" example:"1"`
+	PondID      int64     `param:"pondID" json:"-" example:"1"`
+	StockingID  int64     `json:"stocking_id" example:"1"`
+	SampleSize  int64     `json:"sample_size" example:"50"`
+	TotalWeight float64   `json:"total_weight" example:"610"`       // in gram
+	Lengths     []float64 `json:"lengths" example:"10.5,11.2,10.8"` // in cm, optional
+	SampledAt   time.Time `json:"sampled_at" example:"2024-08-01T00:00:00Z"`
+}
+
+// SampleResponse represent domain response for Growth Sample entity
+type SampleResponse struct {
+	ID          int64     `json:"id" example:"1"`
+	PondID      int64     `json:"pond_id" example:"1"`
+	StockingID  int64     `json:"stocking_id" example:"1"`
+	SampleSize  int64     `json:"sample_size" example:"50"`
+	TotalWeight float64   `json:"total_weight" example:"610"`
+	AvgWeight   float64   `json:"avg_weight" example:"12.2"`
+	Lengths     []float64 `json:"lengths" example:"10.5,11.2,10.8"`
+	AvgLength   float64   `json:"avg_length" example:"10.83"`
+	SampledAt   time.Time `json:"sampled_at" example:"2024-08-01T00:00:00Z"`
+}
+
+// ListSampleResponse represent domain response for bulk Growth Sample entities
+type ListSampleResponse struct {
+	Samples []*SampleResponse      `json:"samples"`
+	Meta    httpres.ListPagination `json:"meta"`
+}
+
+// EstimateRequestQuery represent query parameters fetch from request to estimate batch growth
+type EstimateRequestQuery struct {
+	FarmID     int64 `param:"farmID" example:"1"`
+	PondID     int64 `param:"pondID" example:"1"`
+	StockingID int64 `query:"stocking_id" example:"1"` // default to the latest active batch in the pond
+}
+
+// EstimateResponse represent current growth estimate of a batch
+type EstimateResponse struct {
+	StockingID         int64     `json:"stocking_id" example:"1"`
+	BatchName          string    `json:"batch_name" example:"2024-A"`
+	SampledAt          time.Time `json:"sampled_at" example:"2024-08-01T00:00:00Z"`
+	DaysOfCulture      int64     `json:"days_of_culture" example:"31"`
+	AvgWeight          float64   `json:"avg_weight" example:"12.2"`
+	AvgLength          float64   `json:"avg_length" example:"10.83"`
+	Headcount          int64     `json:"headcount" example:"90000"`            // net of mortality and harvest
+	Biomass            float64   `json:"biomass" example:"1098"`               // in kg
+	DailyGrowthRate    float64   `json:"daily_growth_rate" example:"0.39"`     // in gram/day
+	SpecificGrowthRate float64   `json:"specific_growth_rate" example:"21.46"` // in %/day
+}
@@ -0,0 +1,234 @@
+package growth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllSampleHandler func(context.Context, *SampleRequestQuery) (*ListSampleResponse, error)
+
+// Get All Sample godoc
+//
+//	@Summary	get all growth samples of a pond
+//	@Tags		Growth
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		pondID		path		int		true	"Pond ID"
+//	@Param		stocking_id	query		int		false	"only return sample of the batch"
+//	@Param		from		query		string	false	"start of date window (inclusive), RFC3339"
+//	@Param		to			query		string	false	"end of date window (exclusive), RFC3339"
+//	@Param		limit		query		string	false	"number of entity per page"
+//	@Param		page		query		string	false	"n-th page"
+//	@Success	200			{object}	ListSampleResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"invalid date window"
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/samples [get]
+func HandleGetAllSample(handler GetAllSampleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SampleRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneSampleHandler func(context.Context, *SampleRequestQuery) (*SampleResponse, error)
+
+// Get One Sample godoc
+//
+//	@Summary	get specific growth sample by ID
+//	@Tags		Growth
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		sampleID	path		int	true	"Sample ID"
+//	@Success	200			{object}	SampleResponse
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/samples/{sampleID} [get]
+func HandleGetOneSample(handler GetOneSampleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SampleRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateSampleHandler func(context.Context, *SamplePayload) error
+
+// CreateSample godoc
+//
+//	@Summary	record growth sample of a batch
+//	@Tags		Growth
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int				true	"Farm ID"
+//	@Param		pondID	path		int				true	"Pond ID"
+//	@Param		payload	body		SamplePayload	true	"sample payload"
+//	@Success	201		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"batch not existed in the pond"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/samples [post]
+func HandleCreateSample(handler CreateSampleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SamplePayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateSampleHandler func(context.Context, *SamplePayload) error
+
+// Update Sample godoc
+//
+//	@Summary	update growth sample data
+//	@Tags		Growth
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int				true	"Farm ID"
+//	@Param		pondID		path		int				true	"Pond ID"
+//	@Param		sampleID	path		int				true	"Sample ID"
+//	@Param		payload		body		SamplePayload	true	"sample payload"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"sample not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/samples/{sampleID} [put]
+func HandleUpdateSample(handler UpdateSampleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SamplePayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteSampleHandler func(context.Context, *SampleRequestQuery) error
+
+// DeleteSample godoc
+//
+//	@Summary	delete specific growth sample by ID
+//	@Tags		Growth
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		sampleID	path		int	true	"Sample ID"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"sample not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/samples/{sampleID} [delete]
+func HandleDeleteSample(handler DeleteSampleHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SampleRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetEstimateHandler func(context.Context, *EstimateRequestQuery) (*EstimateResponse, error)
+
+// Get Growth Estimate godoc
+//
+//	@Summary	estimate current body weight, biomass and growth rate of a batch
+//	@Tags		Growth
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		stocking_id	query		int	false	"batch to estimate, default to latest active batch in the pond"
+//	@Success	200			{object}	EstimateResponse
+//	@Failure	404			{object}	httpres.ErrorResponse	"batch not existed or never sampled"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/growth [get]
+func HandleGetEstimate(handler GetEstimateHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &EstimateRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
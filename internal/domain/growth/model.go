@@ -0,0 +1,45 @@
+package growth
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type SampleType struct {
+	ID          int64       `db:"id"`
+	PondID      int64       `db:"pond_id"`
+	StockingID  int64       `db:"stocking_id"`
+	SampleSize  int64       `db:"sample_size"`
+	TotalWeight float64     `db:"total_weight"`
+	Lengths     Float64List `db:"lengths"`
+	SampledAt   time.Time   `db:"sampled_at"`
+}
+
+// Float64List represent list of number stored as jsonb array
+type Float64List []float64
+
+// Value implements driver.Valuer
+func (l Float64List) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner
+func (l *Float64List) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*l = Float64List{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	}
+
+	return errors.New("unsupported type for Float64List")
+}
@@ -0,0 +1,267 @@
+package growth
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// SampleRepository contain contract that defined all necessary public function available to be interact with
+type SampleRepository interface {
+	GetAll(context.Context, *sampleQuery) ([]*SampleType, error)
+	Count(context.Context, *sampleQuery) (uint64, error)
+	GetOne(context.Context, *sampleQuery) (*SampleType, error)
+	Store(context.Context, int64, *SampleType) error
+	Update(context.Context, int64, *SampleType) error
+	Delete(context.Context, *sampleQuery) error
+}
+
+type sampleRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of sampleRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) SampleRepository {
+	return &sampleRepository{db: db}
+}
+
+type sampleQuery struct {
+	ID, FarmID, PondID, StockingID int64
+	From, To                       time.Time
+	Limit, Page                    uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var sampleColumns = []string{"gs.id", "gs.pond_id", "gs.stocking_id", "gs.sample_size", "gs.total_weight", "gs.lengths", "gs.sampled_at"}
+
+func (params *sampleQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"gs.pond_id": params.PondID},
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"gs.deleted_at": nil},
+	}
+
+	if params.StockingID != 0 {
+		cond = append(cond, squirrel.Eq{"gs.stocking_id": params.StockingID})
+	}
+
+	if !params.From.IsZero() {
+		cond = append(cond, squirrel.GtOrEq{"gs.sampled_at": params.From})
+	}
+
+	if !params.To.IsZero() {
+		cond = append(cond, squirrel.Lt{"gs.sampled_at": params.To})
+	}
+
+	return cond
+}
+
+func (repo *sampleRepository) GetAll(ctx context.Context, params *sampleQuery) (res []*SampleType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(sampleColumns...).From("growth_samples gs").
+		Join("ponds p on gs.pond_id = p.id").
+		Where(params.filter()).
+		OrderBy("gs.sampled_at desc", "gs.id desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*SampleType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &SampleType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *sampleRepository) Count(ctx context.Context, params *sampleQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("growth_samples gs").
+		Join("ponds p on gs.pond_id = p.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *sampleRepository) GetOne(ctx context.Context, params *sampleQuery) (res *SampleType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(sampleColumns...).From("growth_samples gs").
+		Join("ponds p on gs.pond_id = p.id").
+		Where(append(params.filter(), squirrel.Eq{"gs.id": params.ID})).ToSql()
+
+	res = &SampleType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure the batch is stocked in a pond within the farm
+func (repo *sampleRepository) validate(ctx context.Context, tx *sqlx.Tx, farmID int64, payload *SampleType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var count int64
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("stockings s").
+		Join("ponds p on s.pond_id = p.id").
+		Where(squirrel.And{
+			squirrel.Eq{"s.id": payload.StockingID},
+			squirrel.Eq{"s.pond_id": payload.PondID},
+			squirrel.Eq{"p.farm_id": farmID},
+			squirrel.Eq{"p.deleted_at": nil},
+			squirrel.Eq{"s.deleted_at": nil},
+		}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate stocking data existence")
+		return
+	}
+
+	// if selected batch doesn't exists in the pond, bail out from here
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+func (repo *sampleRepository) Store(ctx context.Context, farmID int64, payload *SampleType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("growth_samples").
+		Columns("pond_id", "stocking_id", "sample_size", "total_weight", "lengths", "sampled_at").
+		Values(payload.PondID, payload.StockingID, payload.SampleSize, payload.TotalWeight, payload.Lengths, payload.SampledAt).ToSql()
+
+	_, err = tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *sampleRepository) Update(ctx context.Context, farmID int64, payload *SampleType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("growth_samples").SetMap(map[string]interface{}{
+		"stocking_id":  payload.StockingID,
+		"sample_size":  payload.SampleSize,
+		"total_weight": payload.TotalWeight,
+		"lengths":      payload.Lengths,
+		"sampled_at":   payload.SampledAt,
+		"updated_at":   squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"pond_id": payload.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *sampleRepository) Delete(ctx context.Context, params *sampleQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("growth_samples").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"pond_id": params.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("growth sample doesn't exists")
+		return
+	}
+
+	return
+}
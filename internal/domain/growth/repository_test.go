@@ -0,0 +1,95 @@
+package growth
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldGetSampleWithResult(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	sampleRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "pond_id", "stocking_id", "sample_size", "total_weight", "lengths", "sampled_at"}).
+		AddRow(1, 1, 1, 50, 610, []byte("[10.5,11.2]"), time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT gs.id, gs.pond_id, gs.stocking_id, gs.sample_size, gs.total_weight, gs.lengths, gs.sampled_at FROM growth_samples gs JOIN ponds p on gs.pond_id = p.id WHERE (gs.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND gs.deleted_at IS NULL AND gs.stocking_id = $3) ORDER BY gs.sampled_at desc, gs.id desc LIMIT 2 OFFSET 0")).
+		WithArgs(1, 1, 1).
+		WillReturnRows(rows)
+
+	res, err := sampleRepo.GetAll(context.Background(), &sampleQuery{FarmID: 1, PondID: 1, StockingID: 1, Limit: 2, Page: 1})
+	if err != nil || len(res) != 1 || len(res[0].Lengths) != 2 {
+		t.Errorf("unexpected samples: %+v, err: %s", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreSample(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	sampleRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	sampledAt := time.Now()
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.id = $1 AND s.pond_id = $2 AND p.farm_id = $3 AND p.deleted_at IS NULL AND s.deleted_at IS NULL)")).
+		WithArgs(1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO growth_samples (pond_id,stocking_id,sample_size,total_weight,lengths,sampled_at) VALUES ($1,$2,$3,$4,$5,$6)")).
+		WithArgs(1, 1, 50, 610.0, []byte("[10.5,11.2]"), sampledAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = sampleRepo.Store(context.Background(), 1, &SampleType{PondID: 1, StockingID: 1, SampleSize: 50, TotalWeight: 610, Lengths: Float64List{10.5, 11.2}, SampledAt: sampledAt})
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreSampleDueBatchNotExisted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	sampleRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.id = $1 AND s.pond_id = $2 AND p.farm_id = $3 AND p.deleted_at IS NULL AND s.deleted_at IS NULL)")).
+		WithArgs(1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectRollback()
+
+	err = sampleRepo.Store(context.Background(), 1, &SampleType{PondID: 1, StockingID: 1, SampleSize: 50, TotalWeight: 610, SampledAt: time.Now()})
+	if err != errs.ErrNotFound {
+		t.Errorf("expected not found, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -0,0 +1,340 @@
+package growth
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/domain/mortality"
+	"github.com/nmluci/da-farm-be/internal/domain/stocking"
+	"github.com/rs/zerolog"
+)
+
+// GrowthService contains public API available to be interacted with
+type GrowthService interface {
+	GetAll(context.Context, *SampleRequestQuery) (*ListSampleResponse, error)
+	GetOne(context.Context, *SampleRequestQuery) (*SampleResponse, error)
+	Create(context.Context, *SamplePayload) error
+	Update(context.Context, *SamplePayload) error
+	Delete(context.Context, *SampleRequestQuery) error
+	GetEstimate(context.Context, *EstimateRequestQuery) (*EstimateResponse, error)
+}
+
+type growthService struct {
+	repo         SampleRepository
+	stockingSvc  stocking.StockingService
+	mortalitySvc mortality.MortalityService
+}
+
+// NewService return an instance of GrowthService containing available usecases
+func NewService(repo SampleRepository, stockingSvc stocking.StockingService, mortalitySvc mortality.MortalityService) GrowthService {
+	return &growthService{repo: repo, stockingSvc: stockingSvc, mortalitySvc: mortalitySvc}
+}
+
+func (svc *growthService) GetAll(ctx context.Context, params *SampleRequestQuery) (res *ListSampleResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &sampleQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: params.StockingID,
+		From:       params.From,
+		To:         params.To,
+		Limit:      params.Limit,
+		Page:       params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &ListSampleResponse{
+		Samples: []*SampleResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	samples, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, sample := range samples {
+		res.Samples = append(res.Samples, toSampleResponse(sample))
+	}
+
+	return
+}
+
+func (svc *growthService) GetOne(ctx context.Context, params *SampleRequestQuery) (res *SampleResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &sampleQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	sample, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if sample == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toSampleResponse(sample), nil
+}
+
+func (svc *growthService) Create(ctx context.Context, payload *SamplePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toSampleType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Store(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *growthService) Update(ctx context.Context, payload *SamplePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toSampleType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Update(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *growthService) Delete(ctx context.Context, params *SampleRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &sampleQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	// make sure the sample belongs to requested farm and pond
+	sample, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if sample == nil {
+		return errs.ErrNotFound
+	}
+
+	err = svc.repo.Delete(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// GetEstimate estimate current average body weight and biomass of a batch from its latest
+// sample and live headcount. Growth rate is measured against the previous sample, or
+// against stocking weight when the batch only sampled once.
+func (svc *growthService) GetEstimate(ctx context.Context, params *EstimateRequestQuery) (res *EstimateResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	batch, err := svc.getBatch(ctx, params)
+	if err != nil {
+		return
+	}
+
+	samples, err := svc.repo.GetAll(ctx, &sampleQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: batch.ID,
+		Limit:      2,
+		Page:       1,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if len(samples) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	survival, err := svc.mortalitySvc.GetBatchSurvival(ctx, &mortality.SurvivalRequestQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: batch.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	latest := toSampleResponse(samples[0])
+	res = &EstimateResponse{
+		StockingID:    batch.ID,
+		BatchName:     batch.BatchName,
+		SampledAt:     latest.SampledAt,
+		DaysOfCulture: daysBetween(batch.StockedAt, time.Now()),
+		AvgWeight:     latest.AvgWeight,
+		AvgLength:     latest.AvgLength,
+		Headcount:     survival.Headcount,
+		Biomass:       float64(survival.Headcount) * latest.AvgWeight / 1000,
+	}
+
+	prevWeight, prevDate := batch.AvgWeight, batch.StockedAt
+	if len(samples) > 1 {
+		prev := toSampleResponse(samples[1])
+		prevWeight, prevDate = prev.AvgWeight, prev.SampledAt
+	}
+
+	if days := daysBetween(prevDate, latest.SampledAt); days > 0 {
+		res.DailyGrowthRate = (latest.AvgWeight - prevWeight) / float64(days)
+
+		if prevWeight > 0 {
+			res.SpecificGrowthRate = (math.Log(latest.AvgWeight) - math.Log(prevWeight)) / float64(days) * 100
+		}
+	}
+
+	return
+}
+
+// getBatch return the requested batch, or the latest active batch in the pond if none is requested
+func (svc *growthService) getBatch(ctx context.Context, params *EstimateRequestQuery) (res *stocking.StockingResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if params.StockingID != 0 {
+		res, err = svc.stockingSvc.GetOne(ctx, &stocking.StockingRequestQuery{
+			ID:     params.StockingID,
+			FarmID: params.FarmID,
+			PondID: params.PondID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Send()
+		}
+
+		return
+	}
+
+	batches, err := svc.stockingSvc.GetAll(ctx, &stocking.StockingRequestQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		Active: true,
+		Limit:  1,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return batches.Stockings[0], nil
+}
+
+// daysBetween return number of whole days elapsed between two dates
+func daysBetween(from, to time.Time) int64 {
+	return int64(to.Sub(from).Hours() / 24)
+}
+
+func toSampleType(payload *SamplePayload) (res *SampleType, err error) {
+	if payload.StockingID == 0 {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if payload.SampleSize <= 0 || payload.TotalWeight <= 0 || int64(len(payload.Lengths)) > payload.SampleSize {
+		return nil, errs.ErrBadRequest
+	}
+
+	for _, length := range payload.Lengths {
+		if length <= 0 {
+			return nil, errs.ErrBadRequest
+		}
+	}
+
+	res = &SampleType{
+		ID:          payload.ID,
+		PondID:      payload.PondID,
+		StockingID:  payload.StockingID,
+		SampleSize:  payload.SampleSize,
+		TotalWeight: payload.TotalWeight,
+		Lengths:     Float64List(payload.Lengths),
+		SampledAt:   payload.SampledAt,
+	}
+
+	// sample without explicit date is assumed to be taken today
+	if res.SampledAt.IsZero() {
+		res.SampledAt = time.Now()
+	}
+
+	return
+}
+
+func toSampleResponse(sample *SampleType) *SampleResponse {
+	res := &SampleResponse{
+		ID:          sample.ID,
+		PondID:      sample.PondID,
+		StockingID:  sample.StockingID,
+		SampleSize:  sample.SampleSize,
+		TotalWeight: sample.TotalWeight,
+		AvgWeight:   sample.TotalWeight / float64(sample.SampleSize),
+		Lengths:     []float64(sample.Lengths),
+		SampledAt:   sample.SampledAt,
+	}
+
+	if res.Lengths == nil {
+		res.Lengths = []float64{}
+	}
+
+	if len(res.Lengths) != 0 {
+		var total float64
+		for _, length := range res.Lengths {
+			total += length
+		}
+
+		res.AvgLength = total / float64(len(res.Lengths))
+	}
+
+	return res
+}
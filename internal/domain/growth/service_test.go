@@ -0,0 +1,117 @@
+package growth
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/domain/mortality"
+	"github.com/nmluci/da-farm-be/internal/domain/stocking"
+)
+
+type stubSampleRepository struct {
+	SampleRepository
+	samples []*SampleType
+}
+
+func (repo *stubSampleRepository) GetAll(context.Context, *sampleQuery) ([]*SampleType, error) {
+	return repo.samples, nil
+}
+
+type stubStockingService struct {
+	stocking.StockingService
+	batch *stocking.StockingResponse
+}
+
+func (svc *stubStockingService) GetAll(context.Context, *stocking.StockingRequestQuery) (*stocking.ListStockingResponse, error) {
+	return &stocking.ListStockingResponse{Stockings: []*stocking.StockingResponse{svc.batch}}, nil
+}
+
+type stubMortalityService struct {
+	mortality.MortalityService
+	survival mortality.BatchSurvivalResponse
+}
+
+func (svc *stubMortalityService) GetBatchSurvival(context.Context, *mortality.SurvivalRequestQuery) (*mortality.BatchSurvivalResponse, error) {
+	return &svc.survival, nil
+}
+
+func TestShouldEstimateGrowthFromLatestSamples(t *testing.T) {
+	stockedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	svc := NewService(
+		&stubSampleRepository{samples: []*SampleType{
+			{SampleSize: 50, TotalWeight: 600, Lengths: Float64List{10, 11}, SampledAt: stockedAt.AddDate(0, 0, 40)},
+			{SampleSize: 50, TotalWeight: 300, SampledAt: stockedAt.AddDate(0, 0, 30)},
+		}},
+		&stubStockingService{batch: &stocking.StockingResponse{ID: 1, AvgWeight: 0.02, StockedAt: stockedAt}},
+		&stubMortalityService{survival: mortality.BatchSurvivalResponse{Headcount: 90000}},
+	)
+
+	res, err := svc.GetEstimate(context.Background(), &EstimateRequestQuery{FarmID: 1, PondID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	// ABW grow from 6 to 12 gram within 10 days
+	if res.AvgWeight != 12 || res.AvgLength != 10.5 || math.Abs(res.Biomass-1080) > 1e-6 {
+		t.Errorf("unexpected estimate: %+v", res)
+	}
+
+	if math.Abs(res.DailyGrowthRate-0.6) > 1e-6 || math.Abs(res.SpecificGrowthRate-math.Log(2)*10) > 1e-6 {
+		t.Errorf("unexpected growth rate: %+v", res)
+	}
+}
+
+func TestShouldEstimateGrowthAgainstStockingWeight(t *testing.T) {
+	stockedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	svc := NewService(
+		&stubSampleRepository{samples: []*SampleType{
+			{SampleSize: 50, TotalWeight: 101, SampledAt: stockedAt.AddDate(0, 0, 20)},
+		}},
+		&stubStockingService{batch: &stocking.StockingResponse{ID: 1, AvgWeight: 0.02, StockedAt: stockedAt}},
+		&stubMortalityService{survival: mortality.BatchSurvivalResponse{Headcount: 90000}},
+	)
+
+	res, err := svc.GetEstimate(context.Background(), &EstimateRequestQuery{FarmID: 1, PondID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if math.Abs(res.DailyGrowthRate-(2.02-0.02)/20) > 1e-6 {
+		t.Errorf("unexpected growth rate: %+v", res)
+	}
+}
+
+func TestShouldNOTEstimateGrowthOfUnsampledBatch(t *testing.T) {
+	svc := NewService(
+		&stubSampleRepository{},
+		&stubStockingService{batch: &stocking.StockingResponse{ID: 1}},
+		&stubMortalityService{},
+	)
+
+	if _, err := svc.GetEstimate(context.Background(), &EstimateRequestQuery{FarmID: 1, PondID: 1}); err == nil {
+		t.Errorf("expected err due batch never sampled")
+	}
+}
+
+func TestShouldEstimateBiomassOfPartiallyHarvestedBatch(t *testing.T) {
+	stockedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	svc := NewService(
+		&stubSampleRepository{samples: []*SampleType{
+			{SampleSize: 50, TotalWeight: 600, SampledAt: stockedAt.AddDate(0, 0, 40)},
+		}},
+		&stubStockingService{batch: &stocking.StockingResponse{ID: 1, AvgWeight: 0.02, StockedAt: stockedAt}},
+		&stubMortalityService{survival: mortality.BatchSurvivalResponse{InitialCount: 100000, TotalMortality: 10000, TotalHarvested: 40000, Headcount: 50000}},
+	)
+
+	res, err := svc.GetEstimate(context.Background(), &EstimateRequestQuery{FarmID: 1, PondID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	// harvested fish no longer weigh in the pond
+	if res.Headcount != 50000 || math.Abs(res.Biomass-600) > 1e-6 {
+		t.Errorf("unexpected estimate: %+v", res)
+	}
+}
@@ -0,0 +1,42 @@
+package harvest
+
+import "github.com/labstack/echo/v4"
+
+type HarvestController struct {
+	svc HarvestService
+}
+
+func NewController(svc HarvestService) *HarvestController {
+	return &HarvestController{
+		svc: svc,
+	}
+}
+
+const (
+	harvestBasepath = "/farms/:farmID"
+	harvestPath     = "/ponds/:pondID/harvests"
+	harvestIDPath   = "/ponds/:pondID/harvests/:harvestID"
+	yieldPath       = "/yield"
+	seasonYieldPath = "/yield/seasons"
+)
+
+func (hc *HarvestController) Route(grp *echo.Group) {
+	subrouter := grp.Group(harvestBasepath)
+
+	subrouter.GET(harvestPath, HandleGetAllHarvest(hc.svc.GetAll))
+	subrouter.OPTIONS(harvestPath, HandleGetAllHarvest(hc.svc.GetAll))
+	subrouter.GET(harvestIDPath, HandleGetOneHarvest(hc.svc.GetOne))
+	subrouter.OPTIONS(harvestIDPath, HandleGetOneHarvest(hc.svc.GetOne))
+	subrouter.POST(harvestPath, HandleCreateHarvest(hc.svc.Create))
+	subrouter.OPTIONS(harvestPath, HandleCreateHarvest(hc.svc.Create))
+	subrouter.PUT(harvestIDPath, HandleUpdateHarvest(hc.svc.Update))
+	subrouter.OPTIONS(harvestIDPath, HandleUpdateHarvest(hc.svc.Update))
+	subrouter.DELETE(harvestIDPath, HandleDeleteHarvest(hc.svc.Delete))
+	subrouter.OPTIONS(harvestIDPath, HandleDeleteHarvest(hc.svc.Delete))
+	subrouter.GET(yieldPath, HandleGetFarmYield(hc.svc.GetFarmYield))
+	subrouter.OPTIONS(yieldPath, HandleGetFarmYield(hc.svc.GetFarmYield))
+	subrouter.GET(seasonYieldPath, HandleGetSeasonYield(hc.svc.GetSeasonYield))
+	subrouter.OPTIONS(seasonYieldPath, HandleGetSeasonYield(hc.svc.GetSeasonYield))
+
+	return
+}
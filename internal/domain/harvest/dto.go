@@ -0,0 +1,101 @@
+package harvest
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// HarvestRequestQuery represent query parameters fetch from request
+type HarvestRequestQuery struct {
+	ID         int64     `param:"harvestID" example:"1"`
+	FarmID     int64     `param:"farmID" example:"1"`
+	PondID     int64     `param:"pondID" example:"1"`
+	StockingID int64     `query:"stocking_id" example:"1"`
+	From       time.Time `query:"from" example:"2024-07-01T00:00:00Z"`
+	To         time.Time `query:"to" example:"2024-08-01T00:00:00Z"`
+	Limit      uint64    `query:"limit" example:"100"`
+	Page       uint64    `query:"page" example:"2"`
+}
+
+// HarvestPayload represent payload fetch from request body
+type HarvestPayload struct {
+	ID          int64     `param:"harvestID" json:"-" example:"1"`
+	FarmID      int64     `param:"farmID" json:"-" example:"1"`
+	PondID      int64     `param:"pondID" json:"-" example:"1"`
+	StockingID  int64     `json:"stocking_id" example:"1"`
+	Type        string    `json:"type" example:"partial" enums:"partial,total"`
+	Weight      float64   `json:"weight" example:"850.5"` // in kg
+	Count       int64     `json:"count" example:"42000"`
+	SizeGrade   string    `json:"size_grade" example:"50"`
+	Buyer       string    `json:"buyer" example:"PT Mina Sejahtera"`
+	PricePerKg  float64   `json:"price_per_kg" example:"65000"`
+	HarvestedOn time.Time `json:"harvested_on" example:"2024-10-01T00:00:00Z"`
+}
+
+// HarvestResponse represent domain response for Harvest entity
+type HarvestResponse struct {
+	ID          int64     `json:"id" example:"1"`
+	PondID      int64     `json:"pond_id" example:"1"`
+	StockingID  int64     `json:"stocking_id" example:"1"`
+	Type        string    `json:"type" example:"partial"`
+	Weight      float64   `json:"weight" example:"850.5"`
+	Count       int64     `json:"count" example:"42000"`
+	SizeGrade   string    `json:"size_grade" example:"50"`
+	Buyer       string    `json:"buyer" example:"PT Mina Sejahtera"`
+	PricePerKg  float64   `json:"price_per_kg" example:"65000"`
+	Revenue     float64   `json:"revenue" example:"55282500"`
+	HarvestedOn time.Time `json:"harvested_on" example:"2024-10-01T00:00:00Z"`
+}
+
+// ListHarvestResponse represent domain response for bulk Harvest entities
+type ListHarvestResponse struct {
+	Harvests []*HarvestResponse     `json:"harvests"`
+	Meta     httpres.ListPagination `json:"meta"`
+}
+
+// YieldRequestQuery represent query parameters fetch from request to build yield report
+type YieldRequestQuery struct {
+	FarmID int64     `param:"farmID" example:"1"`
+	From   time.Time `query:"from" example:"2024-01-01T00:00:00Z"`
+	To     time.Time `query:"to" example:"2025-01-01T00:00:00Z"`
+	Season string    `query:"season" example:"quarter" enums:"month,quarter,year"` // length of a season, default to quarter
+}
+
+// PondYieldResponse represent harvest yield of a pond
+type PondYieldResponse struct {
+	PondID   int64   `json:"pond_id" example:"1"`
+	Harvests int64   `json:"harvests" example:"3"`
+	Weight   float64 `json:"weight" example:"2450.5"`
+	Count    int64   `json:"count" example:"120000"`
+	Revenue  float64 `json:"revenue" example:"159282500"`
+	AvgPrice float64 `json:"avg_price" example:"65000"`
+}
+
+// FarmYieldResponse represent harvest yield of a farm broken down per pond
+type FarmYieldResponse struct {
+	FarmID   int64                `json:"farm_id" example:"1"`
+	Harvests int64                `json:"harvests" example:"3"`
+	Weight   float64              `json:"weight" example:"2450.5"`
+	Count    int64                `json:"count" example:"120000"`
+	Revenue  float64              `json:"revenue" example:"159282500"`
+	AvgPrice float64              `json:"avg_price" example:"65000"`
+	Ponds    []*PondYieldResponse `json:"ponds"`
+}
+
+// SeasonYieldResponse represent harvest yield of a farm within a season
+type SeasonYieldResponse struct {
+	Season   time.Time `json:"season" example:"2024-07-01T00:00:00Z"` // start of the season
+	Harvests int64     `json:"harvests" example:"3"`
+	Weight   float64   `json:"weight" example:"2450.5"`
+	Count    int64     `json:"count" example:"120000"`
+	Revenue  float64   `json:"revenue" example:"159282500"`
+	AvgPrice float64   `json:"avg_price" example:"65000"`
+}
+
+// ListSeasonYieldResponse represent harvest yield of a farm for every season
+type ListSeasonYieldResponse struct {
+	FarmID  int64                  `json:"farm_id" example:"1"`
+	Season  string                 `json:"season" example:"quarter"`
+	Seasons []*SeasonYieldResponse `json:"seasons"`
+}
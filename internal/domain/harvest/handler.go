@@ -0,0 +1,275 @@
+package harvest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllHarvestHandler func(context.Context, *HarvestRequestQuery) (*ListHarvestResponse, error)
+
+// Get All Harvest godoc
+//
+//	@Summary	get all harvests of a pond
+//	@Tags		Harvest
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		pondID		path		int		true	"Pond ID"
+//	@Param		stocking_id	query		int		false	"only return harvest of the batch"
+//	@Param		from		query		string	false	"start of date window (inclusive), RFC3339"
+//	@Param		to			query		string	false	"end of date window (exclusive), RFC3339"
+//	@Param		limit		query		string	false	"number of entity per page"
+//	@Param		page		query		string	false	"n-th page"
+//	@Success	200			{object}	ListHarvestResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"invalid date window"
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/harvests [get]
+func HandleGetAllHarvest(handler GetAllHarvestHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &HarvestRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneHarvestHandler func(context.Context, *HarvestRequestQuery) (*HarvestResponse, error)
+
+// Get One Harvest godoc
+//
+//	@Summary	get specific harvest by ID
+//	@Tags		Harvest
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		harvestID	path		int	true	"Harvest ID"
+//	@Success	200			{object}	HarvestResponse
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/harvests/{harvestID} [get]
+func HandleGetOneHarvest(handler GetOneHarvestHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &HarvestRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateHarvestHandler func(context.Context, *HarvestPayload) error
+
+// CreateHarvest godoc
+//
+//	@Summary	record partial or total harvest of a batch, total harvest close out the batch
+//	@Tags		Harvest
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int				true	"Farm ID"
+//	@Param		pondID	path		int				true	"Pond ID"
+//	@Param		payload	body		HarvestPayload	true	"harvest payload"
+//	@Success	201		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"batch not existed in the pond"
+//	@Failure	409		{object}	httpres.ErrorResponse	"batch already closed by total harvest"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/harvests [post]
+func HandleCreateHarvest(handler CreateHarvestHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &HarvestPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateHarvestHandler func(context.Context, *HarvestPayload) error
+
+// Update Harvest godoc
+//
+//	@Summary	update harvest data
+//	@Tags		Harvest
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int				true	"Farm ID"
+//	@Param		pondID		path		int				true	"Pond ID"
+//	@Param		harvestID	path		int				true	"Harvest ID"
+//	@Param		payload		body		HarvestPayload	true	"harvest payload"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"harvest not existed"
+//	@Failure	409			{object}	httpres.ErrorResponse	"batch already closed by other total harvest"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/harvests/{harvestID} [put]
+func HandleUpdateHarvest(handler UpdateHarvestHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &HarvestPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteHarvestHandler func(context.Context, *HarvestRequestQuery) error
+
+// DeleteHarvest godoc
+//
+//	@Summary	delete specific harvest by ID, deleting total harvest reopen the batch
+//	@Tags		Harvest
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		harvestID	path		int	true	"Harvest ID"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"harvest not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/harvests/{harvestID} [delete]
+func HandleDeleteHarvest(handler DeleteHarvestHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &HarvestRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetFarmYieldHandler func(context.Context, *YieldRequestQuery) (*FarmYieldResponse, error)
+
+// Get Farm Yield godoc
+//
+//	@Summary	get harvest yield and revenue of a farm per pond
+//	@Tags		Harvest
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		from	query		string	false	"start of date window (inclusive), RFC3339"
+//	@Param		to		query		string	false	"end of date window (exclusive), RFC3339"
+//	@Success	200		{object}	FarmYieldResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid date window"
+//	@Failure	404		{object}	httpres.ErrorResponse	"no harvest recorded"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/yield [get]
+func HandleGetFarmYield(handler GetFarmYieldHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &YieldRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetSeasonYieldHandler func(context.Context, *YieldRequestQuery) (*ListSeasonYieldResponse, error)
+
+// Get Season Yield godoc
+//
+//	@Summary	get harvest yield and revenue of a farm per season
+//	@Tags		Harvest
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		season	query		string	false	"length of a season, default to quarter"	Enums(month, quarter, year)
+//	@Param		from	query		string	false	"start of date window (inclusive), RFC3339"
+//	@Param		to		query		string	false	"end of date window (exclusive), RFC3339"
+//	@Success	200		{object}	ListSeasonYieldResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid season or date window"
+//	@Failure	404		{object}	httpres.ErrorResponse	"no harvest recorded"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/yield/seasons [get]
+func HandleGetSeasonYield(handler GetSeasonYieldHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &YieldRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
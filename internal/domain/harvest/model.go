@@ -0,0 +1,31 @@
+package harvest
+
+import "time"
+
+const (
+	TypePartial = "partial"
+	TypeTotal   = "total"
+)
+
+type HarvestType struct {
+	ID          int64     `db:"id"`
+	PondID      int64     `db:"pond_id"`
+	StockingID  int64     `db:"stocking_id"`
+	Type        string    `db:"type"`
+	Weight      float64   `db:"weight"`
+	Count       int64     `db:"count"`
+	SizeGrade   string    `db:"size_grade"`
+	Buyer       string    `db:"buyer"`
+	PricePerKg  float64   `db:"price_per_kg"`
+	HarvestedOn time.Time `db:"harvested_on"`
+}
+
+// YieldType represent aggregated harvest of a pond or a season
+type YieldType struct {
+	PondID   int64     `db:"pond_id"`
+	Season   time.Time `db:"season"`
+	Harvests int64     `db:"harvests"`
+	Weight   float64   `db:"weight"`
+	Count    int64     `db:"count"`
+	Revenue  float64   `db:"revenue"`
+}
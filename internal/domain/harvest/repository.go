@@ -0,0 +1,425 @@
+package harvest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// HarvestRepository contain contract that defined all necessary public function available to be interact with
+type HarvestRepository interface {
+	GetAll(context.Context, *harvestQuery) ([]*HarvestType, error)
+	Count(context.Context, *harvestQuery) (uint64, error)
+	GetOne(context.Context, *harvestQuery) (*HarvestType, error)
+	Store(context.Context, int64, *HarvestType) error
+	Update(context.Context, int64, *HarvestType) error
+	Delete(context.Context, *harvestQuery) error
+
+	GetPondYield(context.Context, *yieldQuery) ([]*YieldType, error)
+	GetSeasonYield(context.Context, *yieldQuery) ([]*YieldType, error)
+}
+
+type harvestRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of harvestRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) HarvestRepository {
+	return &harvestRepository{db: db}
+}
+
+type harvestQuery struct {
+	ID, FarmID, PondID, StockingID int64
+	From, To                       time.Time
+	Limit, Page                    uint64
+}
+
+// yieldQuery scope yield report into a farm within a time window, Season is
+// a valid postgres' date_trunc field used to group harvests
+type yieldQuery struct {
+	FarmID   int64
+	From, To time.Time
+	Season   string
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var harvestColumns = []string{
+	"h.id", "h.pond_id", "h.stocking_id", "h.type", "h.weight", "h.count", "h.size_grade", "h.buyer", "h.price_per_kg", "h.harvested_on",
+}
+
+var yieldColumns = []string{
+	"count(h.id) harvests", "coalesce(sum(h.weight), 0) weight", "coalesce(sum(h.count), 0) count", "coalesce(sum(h.weight * h.price_per_kg), 0) revenue",
+}
+
+func (params *harvestQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"h.pond_id": params.PondID},
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"h.deleted_at": nil},
+	}
+
+	if params.StockingID != 0 {
+		cond = append(cond, squirrel.Eq{"h.stocking_id": params.StockingID})
+	}
+
+	if !params.From.IsZero() {
+		cond = append(cond, squirrel.GtOrEq{"h.harvested_on": params.From})
+	}
+
+	if !params.To.IsZero() {
+		cond = append(cond, squirrel.Lt{"h.harvested_on": params.To})
+	}
+
+	return cond
+}
+
+func (params *yieldQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"h.deleted_at": nil},
+	}
+
+	if !params.From.IsZero() {
+		cond = append(cond, squirrel.GtOrEq{"h.harvested_on": params.From})
+	}
+
+	if !params.To.IsZero() {
+		cond = append(cond, squirrel.Lt{"h.harvested_on": params.To})
+	}
+
+	return cond
+}
+
+func (repo *harvestRepository) GetAll(ctx context.Context, params *harvestQuery) (res []*HarvestType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(harvestColumns...).From("harvests h").
+		Join("ponds p on h.pond_id = p.id").
+		Where(params.filter()).
+		OrderBy("h.harvested_on desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*HarvestType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &HarvestType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *harvestRepository) Count(ctx context.Context, params *harvestQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("harvests h").
+		Join("ponds p on h.pond_id = p.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *harvestRepository) GetOne(ctx context.Context, params *harvestQuery) (res *HarvestType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(harvestColumns...).From("harvests h").
+		Join("ponds p on h.pond_id = p.id").
+		Where(append(params.filter(), squirrel.Eq{"h.id": params.ID})).ToSql()
+
+	res = &HarvestType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure the batch is stocked in a pond within the farm and hasn't been closed
+// by other total harvest
+func (repo *harvestRepository) validate(ctx context.Context, tx *sqlx.Tx, farmID int64, payload *HarvestType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var closing sql.NullInt64
+
+	stmt, args, _ := pgSquirrel.Select("count(h.id)").From("stockings s").
+		Join("ponds p on s.pond_id = p.id").
+		LeftJoin("harvests h on h.stocking_id = s.id and h.type = ? and h.deleted_at is null and h.id <> ?", TypeTotal, payload.ID).
+		Where(squirrel.And{
+			squirrel.Eq{"s.id": payload.StockingID},
+			squirrel.Eq{"s.pond_id": payload.PondID},
+			squirrel.Eq{"p.farm_id": farmID},
+			squirrel.Eq{"p.deleted_at": nil},
+			squirrel.Eq{"s.deleted_at": nil},
+		}).
+		GroupBy("s.id").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&closing); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate stocking data existence")
+		return
+	}
+
+	// if selected batch doesn't exists in the pond, bail out from here
+	if !closing.Valid {
+		return errs.ErrNotFound
+	}
+
+	// batch already closed out by a total harvest can't be harvested anymore
+	if closing.Int64 != 0 {
+		return errs.ErrInvalidStateTransition
+	}
+
+	return nil
+}
+
+// syncBatch close out the batch on its total harvest date, or reopen it when no total harvest left
+func (repo *harvestRepository) syncBatch(ctx context.Context, tx *sqlx.Tx, stockingID int64) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("stockings").SetMap(map[string]interface{}{
+		"closed_at":  squirrel.Expr("(select max(harvested_on) from harvests where stocking_id = ? and type = ? and deleted_at is null)", stockingID, TypeTotal),
+		"updated_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.Eq{"id": stockingID}).ToSql()
+
+	if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to update stocking batch state")
+		return
+	}
+
+	return
+}
+
+func (repo *harvestRepository) Store(ctx context.Context, farmID int64, payload *HarvestType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("harvests").
+		Columns("pond_id", "stocking_id", "type", "weight", "count", "size_grade", "buyer", "price_per_kg", "harvested_on").
+		Values(payload.PondID, payload.StockingID, payload.Type, payload.Weight, payload.Count, payload.SizeGrade, payload.Buyer, payload.PricePerKg, payload.HarvestedOn).ToSql()
+
+	_, err = tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = repo.syncBatch(ctx, tx, payload.StockingID); err != nil {
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// Update modify harvest data, but the harvest can't be moved into other batch
+func (repo *harvestRepository) Update(ctx context.Context, farmID int64, payload *HarvestType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("harvests").SetMap(map[string]interface{}{
+		"type":         payload.Type,
+		"weight":       payload.Weight,
+		"count":        payload.Count,
+		"size_grade":   payload.SizeGrade,
+		"buyer":        payload.Buyer,
+		"price_per_kg": payload.PricePerKg,
+		"harvested_on": payload.HarvestedOn,
+		"updated_at":   squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"pond_id": payload.PondID},
+		squirrel.Eq{"stocking_id": payload.StockingID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = repo.syncBatch(ctx, tx, payload.StockingID); err != nil {
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *harvestRepository) Delete(ctx context.Context, params *harvestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var stockingID int64
+
+	stmt, args, _ := pgSquirrel.Update("harvests").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"pond_id": params.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).Suffix("RETURNING stocking_id").ToSql()
+
+	err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&stockingID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	} else if err == sql.ErrNoRows {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("harvest doesn't exists")
+		return
+	}
+
+	// deleting a total harvest reopen the batch
+	if err = repo.syncBatch(ctx, tx, stockingID); err != nil {
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// GetPondYield return harvest yield of every pond in the farm
+func (repo *harvestRepository) GetPondYield(ctx context.Context, params *yieldQuery) (res []*YieldType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(append([]string{"h.pond_id"}, yieldColumns...)...).
+		From("harvests h").
+		Join("ponds p on h.pond_id = p.id").
+		Where(params.filter()).
+		GroupBy("h.pond_id").
+		OrderBy("h.pond_id").ToSql()
+
+	res = []*YieldType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &YieldType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+// GetSeasonYield return harvest yield of the farm grouped per season
+func (repo *harvestRepository) GetSeasonYield(ctx context.Context, params *yieldQuery) (res []*YieldType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(yieldColumns...).
+		Column(squirrel.Expr("date_trunc(?, h.harvested_on) season", params.Season)).
+		From("harvests h").
+		Join("ponds p on h.pond_id = p.id").
+		Where(params.filter()).
+		GroupBy("season").
+		OrderBy("season").ToSql()
+
+	res = []*YieldType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &YieldType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
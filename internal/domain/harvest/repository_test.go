@@ -0,0 +1,124 @@
+package harvest
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldGetHarvestWithResult(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	harvestRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "pond_id", "stocking_id", "type", "weight", "count", "size_grade", "buyer", "price_per_kg", "harvested_on"}).
+		AddRow(1, 1, 1, TypePartial, 850.5, 42000, "50", "PT Mina Sejahtera", 65000, time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT h.id, h.pond_id, h.stocking_id, h.type, h.weight, h.count, h.size_grade, h.buyer, h.price_per_kg, h.harvested_on FROM harvests h JOIN ponds p on h.pond_id = p.id WHERE (h.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND h.deleted_at IS NULL) ORDER BY h.harvested_on desc LIMIT 100 OFFSET 0")).
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	harvestRepo.GetAll(context.Background(), &harvestQuery{FarmID: 1, PondID: 1, Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreTotalHarvestAndCloseBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	harvestRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	harvestedOn := time.Now()
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(h.id) FROM stockings s JOIN ponds p on s.pond_id = p.id LEFT JOIN harvests h on h.stocking_id = s.id and h.type = $1 and h.deleted_at is null and h.id <> $2 WHERE (s.id = $3 AND s.pond_id = $4 AND p.farm_id = $5 AND p.deleted_at IS NULL AND s.deleted_at IS NULL) GROUP BY s.id")).
+		WithArgs(TypeTotal, 0, 1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO harvests (pond_id,stocking_id,type,weight,count,size_grade,buyer,price_per_kg,harvested_on) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)")).
+		WithArgs(1, 1, TypeTotal, 1600.0, 80000, "40", "PT Mina Sejahtera", 70000.0, harvestedOn).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stockings SET closed_at = (select max(harvested_on) from harvests where stocking_id = $1 and type = $2 and deleted_at is null), updated_at = NOW() WHERE id = $3")).
+		WithArgs(1, TypeTotal, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = harvestRepo.Store(context.Background(), 1, &HarvestType{
+		PondID: 1, StockingID: 1, Type: TypeTotal, Weight: 1600, Count: 80000, SizeGrade: "40", Buyer: "PT Mina Sejahtera", PricePerKg: 70000, HarvestedOn: harvestedOn,
+	})
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreHarvestOfClosedBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	harvestRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(h.id) FROM stockings s JOIN ponds p on s.pond_id = p.id LEFT JOIN harvests h on h.stocking_id = s.id and h.type = $1 and h.deleted_at is null and h.id <> $2 WHERE (s.id = $3 AND s.pond_id = $4 AND p.farm_id = $5 AND p.deleted_at IS NULL AND s.deleted_at IS NULL) GROUP BY s.id")).
+		WithArgs(TypeTotal, 0, 1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	err = harvestRepo.Store(context.Background(), 1, &HarvestType{PondID: 1, StockingID: 1, Type: TypePartial, Weight: 100, HarvestedOn: time.Now()})
+	if err != errs.ErrInvalidStateTransition {
+		t.Errorf("expected invalid state transition, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldGetSeasonYield(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	harvestRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"harvests", "weight", "count", "revenue", "season"}).
+		AddRow(2, 2450.5, 122000, 167282500, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(h.id) harvests, coalesce(sum(h.weight), 0) weight, coalesce(sum(h.count), 0) count, coalesce(sum(h.weight * h.price_per_kg), 0) revenue, date_trunc($1, h.harvested_on) season FROM harvests h JOIN ponds p on h.pond_id = p.id WHERE (p.farm_id = $2 AND p.deleted_at IS NULL AND h.deleted_at IS NULL) GROUP BY season ORDER BY season")).
+		WithArgs("quarter", 1).
+		WillReturnRows(rows)
+
+	res, err := harvestRepo.GetSeasonYield(context.Background(), &yieldQuery{FarmID: 1, Season: "quarter"})
+	if err != nil || len(res) != 1 || res[0].Harvests != 2 {
+		t.Errorf("unexpected season yield: %+v, err: %s", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -0,0 +1,328 @@
+package harvest
+
+import (
+	"context"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// HarvestService contains public API available to be interacted with
+type HarvestService interface {
+	GetAll(context.Context, *HarvestRequestQuery) (*ListHarvestResponse, error)
+	GetOne(context.Context, *HarvestRequestQuery) (*HarvestResponse, error)
+	Create(context.Context, *HarvestPayload) error
+	Update(context.Context, *HarvestPayload) error
+	Delete(context.Context, *HarvestRequestQuery) error
+	GetFarmYield(context.Context, *YieldRequestQuery) (*FarmYieldResponse, error)
+	GetSeasonYield(context.Context, *YieldRequestQuery) (*ListSeasonYieldResponse, error)
+}
+
+type harvestService struct {
+	repo HarvestRepository
+}
+
+// NewService return an instance of HarvestService containing available usecases
+func NewService(repo HarvestRepository) HarvestService {
+	return &harvestService{repo: repo}
+}
+
+// seasons map supported season length into its date_trunc field
+var seasons = map[string]string{
+	"month":   "month",
+	"quarter": "quarter",
+	"year":    "year",
+}
+
+func (svc *harvestService) GetAll(ctx context.Context, params *HarvestRequestQuery) (res *ListHarvestResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &harvestQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: params.StockingID,
+		From:       params.From,
+		To:         params.To,
+		Limit:      params.Limit,
+		Page:       params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &ListHarvestResponse{
+		Harvests: []*HarvestResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	harvests, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, harvest := range harvests {
+		res.Harvests = append(res.Harvests, toHarvestResponse(harvest))
+	}
+
+	return
+}
+
+func (svc *harvestService) GetOne(ctx context.Context, params *HarvestRequestQuery) (res *HarvestResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &harvestQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	harvest, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if harvest == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toHarvestResponse(harvest), nil
+}
+
+func (svc *harvestService) Create(ctx context.Context, payload *HarvestPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toHarvestType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Store(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *harvestService) Update(ctx context.Context, payload *HarvestPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toHarvestType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Update(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *harvestService) Delete(ctx context.Context, params *HarvestRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &harvestQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	// make sure the harvest belongs to requested farm and pond
+	harvest, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if harvest == nil {
+		return errs.ErrNotFound
+	}
+
+	err = svc.repo.Delete(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// GetFarmYield summarize harvested biomass and revenue of a farm, broken down per pond
+func (svc *harvestService) GetFarmYield(ctx context.Context, params *YieldRequestQuery) (res *FarmYieldResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	yields, err := svc.repo.GetPondYield(ctx, &yieldQuery{
+		FarmID: params.FarmID,
+		From:   params.From,
+		To:     params.To,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if len(yields) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	res = &FarmYieldResponse{FarmID: params.FarmID, Ponds: []*PondYieldResponse{}}
+	for _, yield := range yields {
+		res.Harvests += yield.Harvests
+		res.Weight += yield.Weight
+		res.Count += yield.Count
+		res.Revenue += yield.Revenue
+		res.Ponds = append(res.Ponds, &PondYieldResponse{
+			PondID:   yield.PondID,
+			Harvests: yield.Harvests,
+			Weight:   yield.Weight,
+			Count:    yield.Count,
+			Revenue:  yield.Revenue,
+			AvgPrice: avgPrice(yield.Revenue, yield.Weight),
+		})
+	}
+	res.AvgPrice = avgPrice(res.Revenue, res.Weight)
+
+	return
+}
+
+// GetSeasonYield summarize harvested biomass and revenue of a farm for every season
+func (svc *harvestService) GetSeasonYield(ctx context.Context, params *YieldRequestQuery) (res *ListSeasonYieldResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if params.Season == "" {
+		params.Season = "quarter"
+	}
+
+	season, ok := seasons[params.Season]
+	if !ok {
+		return nil, errs.ErrBadRequest
+	}
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	yields, err := svc.repo.GetSeasonYield(ctx, &yieldQuery{
+		FarmID: params.FarmID,
+		From:   params.From,
+		To:     params.To,
+		Season: season,
+	})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if len(yields) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	res = &ListSeasonYieldResponse{FarmID: params.FarmID, Season: params.Season, Seasons: []*SeasonYieldResponse{}}
+	for _, yield := range yields {
+		res.Seasons = append(res.Seasons, &SeasonYieldResponse{
+			Season:   yield.Season,
+			Harvests: yield.Harvests,
+			Weight:   yield.Weight,
+			Count:    yield.Count,
+			Revenue:  yield.Revenue,
+			AvgPrice: avgPrice(yield.Revenue, yield.Weight),
+		})
+	}
+
+	return
+}
+
+// avgPrice return weighted average selling price per kg
+func avgPrice(revenue, weight float64) float64 {
+	if weight <= 0 {
+		return 0
+	}
+
+	return revenue / weight
+}
+
+func toHarvestType(payload *HarvestPayload) (res *HarvestType, err error) {
+	if payload.StockingID == 0 || payload.Type == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if payload.Type != TypePartial && payload.Type != TypeTotal {
+		return nil, errs.ErrBadRequest
+	}
+
+	if payload.Weight <= 0 || payload.Count < 0 || payload.PricePerKg < 0 {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &HarvestType{
+		ID:          payload.ID,
+		PondID:      payload.PondID,
+		StockingID:  payload.StockingID,
+		Type:        payload.Type,
+		Weight:      payload.Weight,
+		Count:       payload.Count,
+		SizeGrade:   payload.SizeGrade,
+		Buyer:       payload.Buyer,
+		PricePerKg:  payload.PricePerKg,
+		HarvestedOn: payload.HarvestedOn,
+	}
+
+	// harvest without explicit date is assumed to be done today
+	if res.HarvestedOn.IsZero() {
+		res.HarvestedOn = time.Now()
+	}
+
+	return
+}
+
+func toHarvestResponse(harvest *HarvestType) *HarvestResponse {
+	return &HarvestResponse{
+		ID:          harvest.ID,
+		PondID:      harvest.PondID,
+		StockingID:  harvest.StockingID,
+		Type:        harvest.Type,
+		Weight:      harvest.Weight,
+		Count:       harvest.Count,
+		SizeGrade:   harvest.SizeGrade,
+		Buyer:       harvest.Buyer,
+		PricePerKg:  harvest.PricePerKg,
+		Revenue:     harvest.Weight * harvest.PricePerKg,
+		HarvestedOn: harvest.HarvestedOn,
+	}
+}
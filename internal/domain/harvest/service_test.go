@@ -0,0 +1,55 @@
+package harvest
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+type stubHarvestRepository struct {
+	HarvestRepository
+	yields []*YieldType
+}
+
+func (repo *stubHarvestRepository) GetPondYield(context.Context, *yieldQuery) ([]*YieldType, error) {
+	return repo.yields, nil
+}
+
+func TestShouldRollUpYieldPerFarm(t *testing.T) {
+	svc := NewService(&stubHarvestRepository{
+		yields: []*YieldType{
+			{PondID: 1, Harvests: 2, Weight: 1000, Count: 50000, Revenue: 60000000},
+			{PondID: 2, Harvests: 1, Weight: 500, Count: 20000, Revenue: 36000000},
+		},
+	})
+
+	res, err := svc.GetFarmYield(context.Background(), &YieldRequestQuery{FarmID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(res.Ponds) != 2 || math.Abs(res.Ponds[0].AvgPrice-60000) > 1e-6 {
+		t.Fatalf("unexpected pond yield: %+v", res.Ponds)
+	}
+
+	// weighted average price = 96000000 / 1500 kg
+	if res.Harvests != 3 || res.Weight != 1500 || math.Abs(res.AvgPrice-64000) > 1e-6 {
+		t.Errorf("unexpected farm yield: %+v", res)
+	}
+}
+
+func TestShouldNOTGetSeasonYieldWithUnknownSeason(t *testing.T) {
+	svc := NewService(&stubHarvestRepository{})
+
+	if _, err := svc.GetSeasonYield(context.Background(), &YieldRequestQuery{FarmID: 1, Season: "fortnight"}); err == nil {
+		t.Errorf("expected err due unknown season length")
+	}
+}
+
+func TestShouldNOTCreateHarvestWithUnknownType(t *testing.T) {
+	svc := NewService(&stubHarvestRepository{})
+
+	if err := svc.Create(context.Background(), &HarvestPayload{FarmID: 1, PondID: 1, StockingID: 1, Type: "final", Weight: 100}); err == nil {
+		t.Errorf("expected err due unknown harvest type")
+	}
+}
@@ -0,0 +1,38 @@
+package maintenance
+
+import "github.com/labstack/echo/v4"
+
+type WorkOrderController struct {
+	svc WorkOrderService
+}
+
+func NewController(svc WorkOrderService) *WorkOrderController {
+	return &WorkOrderController{
+		svc: svc,
+	}
+}
+
+const (
+	workOrderBasepath   = "/farms/:farmID/work-orders"
+	workOrderIDPath     = "/:workOrderID"
+	workOrderStatusPath = "/:workOrderID/status"
+)
+
+func (wc *WorkOrderController) Route(grp *echo.Group) {
+	subrouter := grp.Group(workOrderBasepath)
+
+	subrouter.GET("", HandleGetAllWorkOrder(wc.svc.GetAll))
+	subrouter.OPTIONS("", HandleGetAllWorkOrder(wc.svc.GetAll))
+	subrouter.GET(workOrderIDPath, HandleGetOneWorkOrder(wc.svc.GetOne))
+	subrouter.OPTIONS(workOrderIDPath, HandleGetOneWorkOrder(wc.svc.GetOne))
+	subrouter.POST("", HandleCreateWorkOrder(wc.svc.Create))
+	subrouter.OPTIONS("", HandleCreateWorkOrder(wc.svc.Create))
+	subrouter.PUT(workOrderIDPath, HandleUpdateWorkOrder(wc.svc.Update))
+	subrouter.OPTIONS(workOrderIDPath, HandleUpdateWorkOrder(wc.svc.Update))
+	subrouter.PUT(workOrderStatusPath, HandleUpdateWorkOrderStatus(wc.svc.UpdateStatus))
+	subrouter.OPTIONS(workOrderStatusPath, HandleUpdateWorkOrderStatus(wc.svc.UpdateStatus))
+	subrouter.DELETE(workOrderIDPath, HandleDeleteWorkOrder(wc.svc.Delete))
+	subrouter.OPTIONS(workOrderIDPath, HandleDeleteWorkOrder(wc.svc.Delete))
+
+	return
+}
@@ -0,0 +1,73 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// WorkOrderRequestQuery represent query parameters fetch from request
+type WorkOrderRequestQuery struct {
+	ID          int64     `param:"workOrderID" example:"1"`
+	FarmID      int64     `param:"farmID" example:"1"`
+	PondID      int64     `query:"pond_id" example:"1"`
+	EquipmentID int64     `query:"equipment_id" example:"1"`
+	Type        string    `query:"type" example:"liming"`
+	Status      string    `query:"status" example:"open"`
+	Assignee    string    `query:"assignee" example:"budi"`
+	DueFrom     time.Time `query:"due_from" example:"2024-08-01T00:00:00Z"`
+	DueTo       time.Time `query:"due_to" example:"2024-09-01T00:00:00Z"`
+	Overdue     bool      `query:"overdue" example:"true"`
+	Limit       uint64    `query:"limit" example:"100"`
+	Page        uint64    `query:"page" example:"2"`
+}
+
+// WorkOrderPayload represent payload fetch from request body
+type WorkOrderPayload struct {
+	ID          int64      `param:"workOrderID" json:"-" example:"1"`
+	FarmID      int64      `param:"farmID" json:"-" example:"1"`
+	PondID      int64      `json:"pond_id" example:"1"`
+	EquipmentID int64      `json:"equipment_id" example:"1"`
+	Title       string     `json:"title" example:"Lime pond bottom"`
+	Description string     `json:"description" example:"Spread 200kg of agricultural lime"`
+	Type        string     `json:"type" example:"liming" enums:"drying,liming,liner_repair,servicing,inspection,other"`
+	Blocking    bool       `json:"blocking" example:"true"` // pond is moved into maintenance while it's unfinished
+	Assignee    string     `json:"assignee" example:"budi"`
+	Recurrence  string     `json:"recurrence" example:"FREQ=MONTHLY;INTERVAL=3"` // requires due_at
+	DueAt       *time.Time `json:"due_at" example:"2024-08-10T00:00:00Z"`        // work order due in the future starts scheduled
+}
+
+// WorkOrderStatusPayload represent status transition of a work order
+type WorkOrderStatusPayload struct {
+	ID     int64  `param:"workOrderID" json:"-" example:"1"`
+	FarmID int64  `param:"farmID" json:"-" example:"1"`
+	Status string `json:"status" example:"done" enums:"open,in_progress,on_hold,done,cancelled"`
+	Note   string `json:"note" example:"done, 180kg used"`
+}
+
+// WorkOrderResponse represent domain response for Work Order entity
+type WorkOrderResponse struct {
+	ID          int64      `json:"id" example:"1"`
+	FarmID      int64      `json:"farm_id" example:"1"`
+	PondID      *int64     `json:"pond_id" example:"1"`
+	EquipmentID *int64     `json:"equipment_id" example:"1"`
+	ParentID    *int64     `json:"parent_id" example:"1"`
+	Title       string     `json:"title" example:"Lime pond bottom"`
+	Description string     `json:"description" example:"Spread 200kg of agricultural lime"`
+	Type        string     `json:"type" example:"liming"`
+	Blocking    bool       `json:"blocking" example:"true"`
+	Assignee    string     `json:"assignee" example:"budi"`
+	Recurrence  string     `json:"recurrence" example:"FREQ=MONTHLY;INTERVAL=3"`
+	Status      string     `json:"status" example:"open"`
+	Note        string     `json:"note" example:"done, 180kg used"`
+	DueAt       *time.Time `json:"due_at" example:"2024-08-10T00:00:00Z"`
+	StartedAt   *time.Time `json:"started_at" example:"2024-08-10T01:00:00Z"`
+	CompletedAt *time.Time `json:"completed_at" example:"2024-08-10T05:00:00Z"`
+	Overdue     bool       `json:"overdue" example:"false"`
+}
+
+// ListWorkOrderResponse represent domain response for bulk Work Order entities
+type ListWorkOrderResponse struct {
+	WorkOrders []*WorkOrderResponse   `json:"work_orders"`
+	Meta       httpres.ListPagination `json:"meta"`
+}
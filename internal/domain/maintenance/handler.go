@@ -0,0 +1,237 @@
+package maintenance
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllWorkOrderHandler func(context.Context, *WorkOrderRequestQuery) (*ListWorkOrderResponse, error)
+
+// Get All Work Order godoc
+//
+//	@Summary	get work orders of a farm ordered by due date
+//	@Tags		Maintenance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID			path		int		true	"Farm ID"
+//	@Param		pond_id			query		int		false	"only return work order of the pond"
+//	@Param		equipment_id	query		int		false	"only return work order of the equipment"
+//	@Param		type			query		string	false	"only return work order of the type"
+//	@Param		status			query		string	false	"only return work order with the status"
+//	@Param		assignee		query		string	false	"only return work order assigned to the person"
+//	@Param		due_from		query		string	false	"only return work order due at or after, RFC3339"
+//	@Param		due_to			query		string	false	"only return work order due before, RFC3339"
+//	@Param		overdue			query		bool	false	"only return unfinished work order past its due date"
+//	@Param		limit			query		string	false	"number of entity per page"
+//	@Param		page			query		string	false	"n-th page"
+//	@Success	200				{object}	ListWorkOrderResponse
+//	@Failure	400				{object}	httpres.ErrorResponse	"invalid due date window"
+//	@Failure	404				{object}	httpres.ErrorResponse
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/work-orders [get]
+func HandleGetAllWorkOrder(handler GetAllWorkOrderHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &WorkOrderRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneWorkOrderHandler func(context.Context, *WorkOrderRequestQuery) (*WorkOrderResponse, error)
+
+// Get One Work Order godoc
+//
+//	@Summary	get specific work order by ID
+//	@Tags		Maintenance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		workOrderID	path		int	true	"Work Order ID"
+//	@Success	200				{object}	WorkOrderResponse
+//	@Failure	404				{object}	httpres.ErrorResponse
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/work-orders/{workOrderID} [get]
+func HandleGetOneWorkOrder(handler GetOneWorkOrderHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &WorkOrderRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateWorkOrderHandler func(context.Context, *WorkOrderPayload) error
+
+// CreateWorkOrder godoc
+//
+//	@Summary	create a scheduled or ad-hoc work order against a pond or equipment, open blocking work order moves the pond into maintenance
+//	@Tags		Maintenance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID	path		int					true	"Farm ID"
+//	@Param		payload	body		WorkOrderPayload	true	"work order payload"
+//	@Success	201		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"unknown type or invalid recurrence"
+//	@Failure	404		{object}	httpres.ErrorResponse	"pond or equipment not existed in the farm"
+//	@Failure	409		{object}	httpres.ErrorResponse	"pond can't be moved into maintenance"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/work-orders [post]
+func HandleCreateWorkOrder(handler CreateWorkOrderHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &WorkOrderPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateWorkOrderHandler func(context.Context, *WorkOrderPayload) error
+
+// Update Work Order godoc
+//
+//	@Summary	update work order details, use the status endpoint to move it
+//	@Tags		Maintenance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int					true	"Farm ID"
+//	@Param		workOrderID	path		int					true	"Work Order ID"
+//	@Param		payload		body		WorkOrderPayload	true	"work order payload"
+//	@Success	200				{object}	string
+//	@Failure	400				{object}	httpres.ErrorResponse	"unknown type or invalid recurrence"
+//	@Failure	404				{object}	httpres.ErrorResponse	"work order, pond or equipment not existed"
+//	@Failure	409				{object}	httpres.ErrorResponse	"pond can't be moved into maintenance"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/work-orders/{workOrderID} [put]
+func HandleUpdateWorkOrder(handler UpdateWorkOrderHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &WorkOrderPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type UpdateWorkOrderStatusHandler func(context.Context, *WorkOrderStatusPayload) error
+
+// Update Work Order Status godoc
+//
+//	@Summary	move work order into the next status, finishing a recurring one schedules its next occurrence
+//	@Tags		Maintenance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int						true	"Farm ID"
+//	@Param		workOrderID	path		int						true	"Work Order ID"
+//	@Param		payload		body		WorkOrderStatusPayload	true	"status transition"
+//	@Success	200				{object}	string
+//	@Failure	400				{object}	httpres.ErrorResponse	"unknown status"
+//	@Failure	404				{object}	httpres.ErrorResponse	"work order not existed"
+//	@Failure	409				{object}	httpres.ErrorResponse	"status can't be moved into the requested one"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/work-orders/{workOrderID}/status [put]
+func HandleUpdateWorkOrderStatus(handler UpdateWorkOrderStatusHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &WorkOrderStatusPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteWorkOrderHandler func(context.Context, *WorkOrderRequestQuery) error
+
+// DeleteWorkOrder godoc
+//
+//	@Summary	delete specific work order by ID
+//	@Tags		Maintenance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		workOrderID	path		int	true	"Work Order ID"
+//	@Success	200				{object}	string
+//	@Failure	404				{object}	httpres.ErrorResponse	"work order not existed"
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/work-orders/{workOrderID} [delete]
+func HandleDeleteWorkOrder(handler DeleteWorkOrderHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &WorkOrderRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
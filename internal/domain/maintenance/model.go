@@ -0,0 +1,123 @@
+package maintenance
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+const (
+	TypeDrying      = "drying"
+	TypeLiming      = "liming"
+	TypeLinerRepair = "liner_repair"
+	TypeServicing   = "servicing"
+	TypeInspection  = "inspection"
+	TypeOther       = "other"
+)
+
+const (
+	StatusScheduled  = "scheduled"
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusOnHold     = "on_hold"
+	StatusDone       = "done"
+	StatusCancelled  = "cancelled"
+)
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// workOrderTypes list every supported kind of work
+var workOrderTypes = []string{TypeDrying, TypeLiming, TypeLinerRepair, TypeServicing, TypeInspection, TypeOther}
+
+// statusTransitions map every status into statuses it may move into, done and cancelled are final
+var statusTransitions = map[string][]string{
+	StatusScheduled:  {StatusOpen, StatusInProgress, StatusCancelled},
+	StatusOpen:       {StatusInProgress, StatusOnHold, StatusDone, StatusCancelled},
+	StatusInProgress: {StatusOnHold, StatusDone, StatusCancelled},
+	StatusOnHold:     {StatusInProgress, StatusCancelled},
+	StatusDone:       {},
+	StatusCancelled:  {},
+}
+
+// activeStatuses list statuses of work order that is due and unfinished, blocking work order in such status
+// keeps its pond in maintenance
+var activeStatuses = []string{StatusOpen, StatusInProgress, StatusOnHold}
+
+type WorkOrderType struct {
+	ID          int64         `db:"id"`
+	FarmID      int64         `db:"farm_id"`
+	PondID      sql.NullInt64 `db:"pond_id"`
+	EquipmentID sql.NullInt64 `db:"equipment_id"`
+	ParentID    sql.NullInt64 `db:"parent_id"`
+	Title       string        `db:"title"`
+	Description string        `db:"description"`
+	Type        string        `db:"type"`
+	Blocking    bool          `db:"blocking"`
+	Assignee    string        `db:"assignee"`
+	Recurrence  string        `db:"recurrence"`
+	Status      string        `db:"status"`
+	Note        string        `db:"note"`
+	DueAt       sql.NullTime  `db:"due_at"`
+	StartedAt   sql.NullTime  `db:"started_at"`
+	CompletedAt sql.NullTime  `db:"completed_at"`
+}
+
+// Recurrence represent a subset of RFC 5545 recurrence rule, ex: FREQ=WEEKLY;INTERVAL=2
+type Recurrence struct {
+	Freq     string
+	Interval int
+}
+
+// ParseRecurrence parse FREQ (DAILY, WEEKLY or MONTHLY) and optional INTERVAL parts of a recurrence rule
+func ParseRecurrence(rule string) (res *Recurrence, err error) {
+	res = &Recurrence{Interval: 1}
+
+	for _, part := range strings.Split(strings.ToUpper(rule), ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, errs.ErrBadRequest
+		}
+
+		switch key {
+		case "FREQ":
+			res.Freq = value
+		case "INTERVAL":
+			if res.Interval, err = strconv.Atoi(value); err != nil || res.Interval <= 0 {
+				return nil, errs.ErrBadRequest
+			}
+		default:
+			return nil, errs.ErrBadRequest
+		}
+	}
+
+	if res.Freq != FreqDaily && res.Freq != FreqWeekly && res.Freq != FreqMonthly {
+		return nil, errs.ErrBadRequest
+	}
+
+	return
+}
+
+// Next return the first occurrence after t
+func (r *Recurrence) Next(t time.Time) time.Time {
+	switch r.Freq {
+	case FreqWeekly:
+		return t.AddDate(0, 0, 7*r.Interval)
+	case FreqMonthly:
+		return t.AddDate(0, r.Interval, 0)
+	default:
+		return t.AddDate(0, 0, r.Interval)
+	}
+}
+
+// String return the normalized rule
+func (r *Recurrence) String() string {
+	return fmt.Sprintf("FREQ=%s;INTERVAL=%d", r.Freq, r.Interval)
+}
@@ -0,0 +1,32 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldParseRecurrence(t *testing.T) {
+	recurrence, err := ParseRecurrence("freq=monthly;interval=3")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if recurrence.String() != "FREQ=MONTHLY;INTERVAL=3" {
+		t.Errorf("unexpected recurrence: %s", recurrence)
+	}
+
+	due := time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC)
+	if next := recurrence.Next(due); !next.Equal(time.Date(2024, 11, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected next occurrence: %s", next)
+	}
+}
+
+func TestShouldNOTParseUnsupportedRecurrence(t *testing.T) {
+	for _, rule := range []string{"", "FREQ=HOURLY", "FREQ=DAILY;INTERVAL=0", "FREQ=WEEKLY;BYDAY=MO"} {
+		if _, err := ParseRecurrence(rule); err != errs.ErrBadRequest {
+			t.Errorf("expected bad request for %q, got: %v", rule, err)
+		}
+	}
+}
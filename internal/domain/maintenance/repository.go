@@ -0,0 +1,393 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// WorkOrderRepository contain contract that defined all necessary public function available to be interact with
+type WorkOrderRepository interface {
+	GetAll(context.Context, *workOrderQuery) ([]*WorkOrderType, error)
+	Count(context.Context, *workOrderQuery) (uint64, error)
+	GetOne(context.Context, *workOrderQuery) (*WorkOrderType, error)
+	Store(context.Context, *WorkOrderType) error
+	Update(context.Context, *WorkOrderType) error
+	UpdateStatus(context.Context, *WorkOrderType) error
+	Delete(context.Context, *workOrderQuery) error
+	OpenDue(context.Context, time.Time) ([]*WorkOrderType, error)
+}
+
+type workOrderRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of workOrderRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) WorkOrderRepository {
+	return &workOrderRepository{db: db}
+}
+
+type workOrderQuery struct {
+	ID, FarmID, PondID, EquipmentID int64
+	Type, Status, Assignee          string
+	DueFrom, DueTo                  time.Time
+	Overdue, Blocking, Active       bool
+	Limit, Page                     uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var workOrderColumns = []string{
+	"w.id", "w.farm_id", "w.pond_id", "w.equipment_id", "w.parent_id", "w.title", "w.description", "w.type", "w.blocking",
+	"w.assignee", "w.recurrence", "w.status", "w.note", "w.due_at", "w.started_at", "w.completed_at",
+}
+
+func (params *workOrderQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"w.farm_id": params.FarmID},
+		squirrel.Eq{"w.deleted_at": nil},
+	}
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"w.id": params.ID})
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"w.pond_id": params.PondID})
+	}
+
+	if params.EquipmentID != 0 {
+		cond = append(cond, squirrel.Eq{"w.equipment_id": params.EquipmentID})
+	}
+
+	if params.Type != "" {
+		cond = append(cond, squirrel.Eq{"w.type": params.Type})
+	}
+
+	if params.Status != "" {
+		cond = append(cond, squirrel.Eq{"w.status": params.Status})
+	}
+
+	if params.Assignee != "" {
+		cond = append(cond, squirrel.Eq{"w.assignee": params.Assignee})
+	}
+
+	if !params.DueFrom.IsZero() {
+		cond = append(cond, squirrel.GtOrEq{"w.due_at": params.DueFrom})
+	}
+
+	if !params.DueTo.IsZero() {
+		cond = append(cond, squirrel.Lt{"w.due_at": params.DueTo})
+	}
+
+	if params.Overdue {
+		cond = append(cond, squirrel.Expr("w.due_at < NOW()"), squirrel.NotEq{"w.status": []string{StatusDone, StatusCancelled}})
+	}
+
+	if params.Blocking {
+		cond = append(cond, squirrel.Eq{"w.blocking": true})
+	}
+
+	if params.Active {
+		cond = append(cond, squirrel.Eq{"w.status": activeStatuses})
+	}
+
+	return cond
+}
+
+func (repo *workOrderRepository) GetAll(ctx context.Context, params *workOrderQuery) (res []*WorkOrderType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(workOrderColumns...).From("work_orders w").
+		Where(params.filter()).
+		OrderBy("w.due_at nulls last", "w.id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*WorkOrderType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &WorkOrderType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *workOrderRepository) Count(ctx context.Context, params *workOrderQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("work_orders w").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *workOrderRepository) GetOne(ctx context.Context, params *workOrderQuery) (res *WorkOrderType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(workOrderColumns...).From("work_orders w").
+		Where(params.filter()).ToSql()
+
+	res = &WorkOrderType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure the targeted pond and equipment belong to the farm
+func (repo *workOrderRepository) validate(ctx context.Context, tx *sqlx.Tx, payload *WorkOrderType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	targets := map[string]sql.NullInt64{"ponds": payload.PondID, "equipments": payload.EquipmentID}
+	for _, table := range []string{"ponds", "equipments"} {
+		if !targets[table].Valid {
+			continue
+		}
+
+		var count int64
+
+		stmt, args, _ := pgSquirrel.Select("count(*)").From(table).Where(squirrel.And{
+			squirrel.Eq{"id": targets[table].Int64},
+			squirrel.Eq{"farm_id": payload.FarmID},
+			squirrel.Eq{"deleted_at": nil},
+		}).ToSql()
+
+		if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+			logger.Error().Err(err).Msgf("failed to validate %s data existence", table)
+			return
+		}
+
+		// if target doesn't exists within the farm, bail out from here
+		if count == 0 {
+			return errs.ErrNotFound
+		}
+	}
+
+	return
+}
+
+// Store save a new work order, the generated ID will be assigned back into payload
+func (repo *workOrderRepository) Store(ctx context.Context, payload *WorkOrderType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("work_orders").
+		Columns("farm_id", "pond_id", "equipment_id", "parent_id", "title", "description", "type", "blocking", "assignee", "recurrence", "status", "due_at").
+		Values(payload.FarmID, payload.PondID, payload.EquipmentID, payload.ParentID, payload.Title, payload.Description, payload.Type,
+			payload.Blocking, payload.Assignee, payload.Recurrence, payload.Status, payload.DueAt).
+		Suffix("RETURNING id").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// Update change the details of a work order, its status is only changed through UpdateStatus
+func (repo *workOrderRepository) Update(ctx context.Context, payload *WorkOrderType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("work_orders").SetMap(map[string]interface{}{
+		"pond_id":      payload.PondID,
+		"equipment_id": payload.EquipmentID,
+		"title":        payload.Title,
+		"description":  payload.Description,
+		"type":         payload.Type,
+		"blocking":     payload.Blocking,
+		"assignee":     payload.Assignee,
+		"recurrence":   payload.Recurrence,
+		"due_at":       payload.DueAt,
+		"updated_at":   squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// UpdateStatus save status transition of a work order, guarded by its previous status so concurrent transitions
+// don't overwrite each other
+func (repo *workOrderRepository) UpdateStatus(ctx context.Context, payload *WorkOrderType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("work_orders").SetMap(map[string]interface{}{
+		"status":       payload.Status,
+		"note":         payload.Note,
+		"started_at":   payload.StartedAt,
+		"completed_at": payload.CompletedAt,
+		"updated_at":   squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"farm_id": payload.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+		squirrel.Eq{"status": statusTransitionsFrom(payload.Status)},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrInvalidStateTransition
+	}
+
+	return
+}
+
+func (repo *workOrderRepository) Delete(ctx context.Context, params *workOrderQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("work_orders").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"farm_id": params.FarmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("work order doesn't exists")
+		return
+	}
+
+	return
+}
+
+// OpenDue move every scheduled work order whose due date has come into open, return the opened work orders
+func (repo *workOrderRepository) OpenDue(ctx context.Context, now time.Time) (res []*WorkOrderType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("work_orders w").SetMap(map[string]interface{}{
+		"status":     StatusOpen,
+		"updated_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"w.status": StatusScheduled},
+		squirrel.LtOrEq{"w.due_at": now},
+		squirrel.Eq{"w.deleted_at": nil},
+	}).Suffix("RETURNING " + strings.Join(workOrderColumns, ", ")).ToSql()
+
+	res = []*WorkOrderType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &WorkOrderType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+// statusTransitionsFrom return every status allowed to move into the status
+func statusTransitionsFrom(status string) []string {
+	res := []string{}
+	for from, targets := range statusTransitions {
+		if slices.Contains(targets, status) {
+			res = append(res, from)
+		}
+	}
+
+	slices.Sort(res)
+	return res
+}
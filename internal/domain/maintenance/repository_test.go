@@ -0,0 +1,60 @@
+package maintenance
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldCountActiveBlockingWorkOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	workOrderRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM work_orders w WHERE (w.farm_id = $1 AND w.deleted_at IS NULL AND w.pond_id = $2 AND w.blocking = $3 AND w.status IN ($4,$5,$6))")).
+		WithArgs(1, 2, true, StatusOpen, StatusInProgress, StatusOnHold).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	count, err := workOrderRepo.Count(context.Background(), &workOrderQuery{FarmID: 1, PondID: 2, Blocking: true, Active: true})
+	if err != nil || count != 1 {
+		t.Errorf("unexpected count: %d, err: %v", count, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTUpdateStatusConcurrentlyMoved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	workOrderRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE work_orders SET completed_at = $1, note = $2, started_at = $3, status = $4, updated_at = NOW() "+
+		"WHERE (id = $5 AND farm_id = $6 AND deleted_at IS NULL AND status IN ($7,$8))")).
+		WithArgs(nil, "", nil, StatusOnHold, 1, 1, StatusInProgress, StatusOpen).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = workOrderRepo.UpdateStatus(context.Background(), &WorkOrderType{ID: 1, FarmID: 1, Status: StatusOnHold})
+	if err != errs.ErrInvalidStateTransition {
+		t.Errorf("expected invalid state transition, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
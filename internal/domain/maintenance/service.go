@@ -0,0 +1,443 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/domain/ponds"
+	"github.com/rs/zerolog"
+)
+
+// WorkOrderService contains public API available to be interacted with
+type WorkOrderService interface {
+	GetAll(context.Context, *WorkOrderRequestQuery) (*ListWorkOrderResponse, error)
+	GetOne(context.Context, *WorkOrderRequestQuery) (*WorkOrderResponse, error)
+	Create(context.Context, *WorkOrderPayload) error
+	Update(context.Context, *WorkOrderPayload) error
+	UpdateStatus(context.Context, *WorkOrderStatusPayload) error
+	Delete(context.Context, *WorkOrderRequestQuery) error
+	OpenDue(context.Context) error
+}
+
+type workOrderService struct {
+	repo    WorkOrderRepository
+	pondSvc ponds.PondService
+}
+
+// NewService return an instance of WorkOrderService containing available usecases
+func NewService(repo WorkOrderRepository, pondSvc ponds.PondService) WorkOrderService {
+	return &workOrderService{repo: repo, pondSvc: pondSvc}
+}
+
+func (svc *workOrderService) GetAll(ctx context.Context, params *WorkOrderRequestQuery) (res *ListWorkOrderResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &workOrderQuery{
+		FarmID:      params.FarmID,
+		PondID:      params.PondID,
+		EquipmentID: params.EquipmentID,
+		Type:        params.Type,
+		Status:      params.Status,
+		Assignee:    params.Assignee,
+		DueFrom:     params.DueFrom,
+		DueTo:       params.DueTo,
+		Overdue:     params.Overdue,
+		Limit:       params.Limit,
+		Page:        params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if !params.DueFrom.IsZero() && !params.DueTo.IsZero() && !params.DueFrom.Before(params.DueTo) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &ListWorkOrderResponse{
+		WorkOrders: []*WorkOrderResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	workOrders, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	now := time.Now()
+	for _, workOrder := range workOrders {
+		res.WorkOrders = append(res.WorkOrders, toWorkOrderResponse(workOrder, now))
+	}
+
+	return
+}
+
+func (svc *workOrderService) GetOne(ctx context.Context, params *WorkOrderRequestQuery) (res *WorkOrderResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	workOrder, err := svc.repo.GetOne(ctx, &workOrderQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if workOrder == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toWorkOrderResponse(workOrder, time.Now()), nil
+}
+
+// Create save a new work order, it's scheduled when due in the future and open otherwise
+func (svc *workOrderService) Create(ctx context.Context, payload *WorkOrderPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toWorkOrderType(payload)
+	if err != nil {
+		return
+	}
+
+	data.Status = StatusOpen
+	if data.DueAt.Valid && data.DueAt.Time.After(time.Now()) {
+		data.Status = StatusScheduled
+	}
+
+	// move the pond first, so work order isn't saved when the pond can't be blocked
+	if data.Blocking && data.Status == StatusOpen {
+		if err = svc.blockPond(ctx, data.FarmID, data.PondID.Int64, true); err != nil {
+			return
+		}
+	}
+
+	err = svc.repo.Store(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *workOrderService) Update(ctx context.Context, payload *WorkOrderPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toWorkOrderType(payload)
+	if err != nil {
+		return
+	}
+
+	workOrder, err := svc.repo.GetOne(ctx, &workOrderQuery{ID: payload.ID, FarmID: payload.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if workOrder == nil {
+		return errs.ErrNotFound
+	}
+
+	active := slices.Contains(activeStatuses, workOrder.Status)
+	if data.Blocking && active {
+		if err = svc.blockPond(ctx, data.FarmID, data.PondID.Int64, true); err != nil {
+			return
+		}
+	}
+
+	err = svc.repo.Update(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	// previously blocked pond may be released after being unmarked or retargeted
+	if workOrder.Blocking && active {
+		return svc.syncPond(ctx, workOrder.FarmID, workOrder.PondID.Int64)
+	}
+
+	return
+}
+
+// UpdateStatus move work order into the next status. Finishing a recurring work order schedules its next occurrence,
+// and finishing the last blocking work order of a pond releases the pond from maintenance
+func (svc *workOrderService) UpdateStatus(ctx context.Context, payload *WorkOrderStatusPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if _, ok := statusTransitions[payload.Status]; !ok {
+		return errs.ErrBadRequest
+	}
+
+	workOrder, err := svc.repo.GetOne(ctx, &workOrderQuery{ID: payload.ID, FarmID: payload.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if workOrder == nil {
+		return errs.ErrNotFound
+	}
+
+	if !slices.Contains(statusTransitions[workOrder.Status], payload.Status) {
+		return errs.ErrInvalidStateTransition
+	}
+
+	now := time.Now()
+	wasActive := slices.Contains(activeStatuses, workOrder.Status)
+
+	workOrder.Status = payload.Status
+	workOrder.Note = payload.Note
+
+	switch payload.Status {
+	case StatusInProgress:
+		if !workOrder.StartedAt.Valid {
+			workOrder.StartedAt = sql.NullTime{Time: now, Valid: true}
+		}
+	case StatusDone, StatusCancelled:
+		workOrder.CompletedAt = sql.NullTime{Time: now, Valid: true}
+	}
+
+	// scheduled work order started early blocks the pond from now on
+	if workOrder.Blocking && !wasActive && slices.Contains(activeStatuses, workOrder.Status) {
+		if err = svc.blockPond(ctx, workOrder.FarmID, workOrder.PondID.Int64, true); err != nil {
+			return
+		}
+	}
+
+	err = svc.repo.UpdateStatus(ctx, workOrder)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if workOrder.Status == StatusDone && workOrder.Recurrence != "" {
+		if err = svc.scheduleNext(ctx, workOrder, now); err != nil {
+			return
+		}
+	}
+
+	if workOrder.Blocking && wasActive && !slices.Contains(activeStatuses, workOrder.Status) {
+		return svc.syncPond(ctx, workOrder.FarmID, workOrder.PondID.Int64)
+	}
+
+	return
+}
+
+func (svc *workOrderService) Delete(ctx context.Context, params *WorkOrderRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	workOrder, err := svc.repo.GetOne(ctx, &workOrderQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if workOrder == nil {
+		return errs.ErrNotFound
+	}
+
+	err = svc.repo.Delete(ctx, &workOrderQuery{ID: params.ID, FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if workOrder.Blocking && slices.Contains(activeStatuses, workOrder.Status) {
+		return svc.syncPond(ctx, workOrder.FarmID, workOrder.PondID.Int64)
+	}
+
+	return
+}
+
+// OpenDue open every scheduled work order whose due date has come, moving ponds of the blocking ones into maintenance.
+// Pond that can't be moved, ex: being harvested, is left as is and retried on the next run
+func (svc *workOrderService) OpenDue(ctx context.Context) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	workOrders, err := svc.repo.OpenDue(ctx, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, workOrder := range workOrders {
+		if !workOrder.Blocking {
+			continue
+		}
+
+		if err := svc.blockPond(ctx, workOrder.FarmID, workOrder.PondID.Int64, true); err != nil {
+			logger.Warn().Err(err).Int64("work_order_id", workOrder.ID).Msg("failed to move pond into maintenance")
+		}
+	}
+
+	return
+}
+
+// scheduleNext store the first occurrence of a recurring work order due after it's finished
+func (svc *workOrderService) scheduleNext(ctx context.Context, workOrder *WorkOrderType, finishedAt time.Time) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	recurrence, err := ParseRecurrence(workOrder.Recurrence)
+	if err != nil {
+		return
+	}
+
+	// late completion skips the missed occurrences
+	due := recurrence.Next(workOrder.DueAt.Time)
+	for !due.After(finishedAt) {
+		due = recurrence.Next(due)
+	}
+
+	next := *workOrder
+	next.ID = 0
+	next.ParentID = sql.NullInt64{Int64: workOrder.ID, Valid: true}
+	next.Status = StatusScheduled
+	next.Note = ""
+	next.DueAt = sql.NullTime{Time: due, Valid: true}
+	next.StartedAt = sql.NullTime{}
+	next.CompletedAt = sql.NullTime{}
+
+	err = svc.repo.Store(ctx, &next)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// syncPond keep the pond in maintenance as long as it still has any active blocking work order
+func (svc *workOrderService) syncPond(ctx context.Context, farmID, pondID int64) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	count, err := svc.repo.Count(ctx, &workOrderQuery{FarmID: farmID, PondID: pondID, Blocking: true, Active: true})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return svc.blockPond(ctx, farmID, pondID, count > 0)
+}
+
+func (svc *workOrderService) blockPond(ctx context.Context, farmID, pondID int64, blocked bool) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	err = svc.pondSvc.SetMaintenance(ctx, &ponds.PondMaintenancePayload{ID: pondID, FarmID: farmID, Blocked: blocked})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func toWorkOrderType(payload *WorkOrderPayload) (res *WorkOrderType, err error) {
+	if payload.Title == "" || payload.Type == "" || (payload.PondID == 0 && payload.EquipmentID == 0) {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	// only pond can be blocked, and recurrence is counted from the due date
+	if (payload.Blocking && payload.PondID == 0) || (payload.Recurrence != "" && payload.DueAt == nil) {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if !slices.Contains(workOrderTypes, payload.Type) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &WorkOrderType{
+		ID:          payload.ID,
+		FarmID:      payload.FarmID,
+		Title:       payload.Title,
+		Description: payload.Description,
+		Type:        payload.Type,
+		Blocking:    payload.Blocking,
+		Assignee:    payload.Assignee,
+	}
+
+	if payload.Recurrence != "" {
+		recurrence, err := ParseRecurrence(payload.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		res.Recurrence = recurrence.String()
+	}
+
+	if payload.PondID != 0 {
+		res.PondID = sql.NullInt64{Int64: payload.PondID, Valid: true}
+	}
+
+	if payload.EquipmentID != 0 {
+		res.EquipmentID = sql.NullInt64{Int64: payload.EquipmentID, Valid: true}
+	}
+
+	if payload.DueAt != nil {
+		res.DueAt = sql.NullTime{Time: *payload.DueAt, Valid: true}
+	}
+
+	return
+}
+
+func toWorkOrderResponse(workOrder *WorkOrderType, now time.Time) *WorkOrderResponse {
+	res := &WorkOrderResponse{
+		ID:          workOrder.ID,
+		FarmID:      workOrder.FarmID,
+		Title:       workOrder.Title,
+		Description: workOrder.Description,
+		Type:        workOrder.Type,
+		Blocking:    workOrder.Blocking,
+		Assignee:    workOrder.Assignee,
+		Recurrence:  workOrder.Recurrence,
+		Status:      workOrder.Status,
+		Note:        workOrder.Note,
+	}
+
+	if workOrder.PondID.Valid {
+		res.PondID = &workOrder.PondID.Int64
+	}
+
+	if workOrder.EquipmentID.Valid {
+		res.EquipmentID = &workOrder.EquipmentID.Int64
+	}
+
+	if workOrder.ParentID.Valid {
+		res.ParentID = &workOrder.ParentID.Int64
+	}
+
+	if workOrder.DueAt.Valid {
+		res.DueAt = &workOrder.DueAt.Time
+		res.Overdue = workOrder.DueAt.Time.Before(now) && workOrder.Status != StatusDone && workOrder.Status != StatusCancelled
+	}
+
+	if workOrder.StartedAt.Valid {
+		res.StartedAt = &workOrder.StartedAt.Time
+	}
+
+	if workOrder.CompletedAt.Valid {
+		res.CompletedAt = &workOrder.CompletedAt.Time
+	}
+
+	return res
+}
@@ -0,0 +1,132 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/domain/ponds"
+)
+
+type stubWorkOrderRepository struct {
+	WorkOrderRepository
+	workOrder *WorkOrderType
+	count     uint64
+	stored    []*WorkOrderType
+	updated   *WorkOrderType
+}
+
+func (repo *stubWorkOrderRepository) GetOne(context.Context, *workOrderQuery) (*WorkOrderType, error) {
+	return repo.workOrder, nil
+}
+
+func (repo *stubWorkOrderRepository) Count(context.Context, *workOrderQuery) (uint64, error) {
+	return repo.count, nil
+}
+
+func (repo *stubWorkOrderRepository) Store(_ context.Context, payload *WorkOrderType) error {
+	repo.stored = append(repo.stored, payload)
+	return nil
+}
+
+func (repo *stubWorkOrderRepository) UpdateStatus(_ context.Context, payload *WorkOrderType) error {
+	repo.updated = payload
+	return nil
+}
+
+type stubPondService struct {
+	ponds.PondService
+	payloads []*ponds.PondMaintenancePayload
+	err      error
+}
+
+func (svc *stubPondService) SetMaintenance(_ context.Context, payload *ponds.PondMaintenancePayload) error {
+	svc.payloads = append(svc.payloads, payload)
+	return svc.err
+}
+
+func TestShouldBlockPondOnCreatingBlockingWorkOrder(t *testing.T) {
+	repo := &stubWorkOrderRepository{}
+	pondSvc := &stubPondService{}
+	svc := NewService(repo, pondSvc)
+
+	err := svc.Create(context.Background(), &WorkOrderPayload{FarmID: 1, PondID: 2, Title: "Dry pond", Type: TypeDrying, Blocking: true})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if len(repo.stored) != 1 || repo.stored[0].Status != StatusOpen {
+		t.Errorf("unexpected stored work order: %+v", repo.stored)
+	}
+
+	if len(pondSvc.payloads) != 1 || !pondSvc.payloads[0].Blocked || pondSvc.payloads[0].ID != 2 {
+		t.Errorf("expected pond to be blocked, got: %+v", pondSvc.payloads)
+	}
+}
+
+func TestShouldNOTCreateWorkOrderOnUnblockablePond(t *testing.T) {
+	repo := &stubWorkOrderRepository{}
+	svc := NewService(repo, &stubPondService{err: errs.ErrInvalidStateTransition})
+
+	err := svc.Create(context.Background(), &WorkOrderPayload{FarmID: 1, PondID: 2, Title: "Dry pond", Type: TypeDrying, Blocking: true})
+	if err != errs.ErrInvalidStateTransition || len(repo.stored) != 0 {
+		t.Errorf("expected invalid state transition without stored work order, got: %v", err)
+	}
+}
+
+func TestShouldScheduleFutureWorkOrderWithoutBlockingPond(t *testing.T) {
+	repo := &stubWorkOrderRepository{}
+	pondSvc := &stubPondService{}
+	svc := NewService(repo, pondSvc)
+
+	due := time.Now().Add(7 * 24 * time.Hour)
+	err := svc.Create(context.Background(), &WorkOrderPayload{
+		FarmID: 1, PondID: 2, Title: "Lime pond", Type: TypeLiming, Blocking: true, Recurrence: "FREQ=MONTHLY", DueAt: &due,
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.stored[0].Status != StatusScheduled || repo.stored[0].Recurrence != "FREQ=MONTHLY;INTERVAL=1" || len(pondSvc.payloads) != 0 {
+		t.Errorf("unexpected stored work order: %+v", repo.stored[0])
+	}
+}
+
+func TestShouldScheduleNextOccurrenceAndReleasePond(t *testing.T) {
+	due := time.Now().Add(-45 * 24 * time.Hour)
+	repo := &stubWorkOrderRepository{workOrder: &WorkOrderType{
+		ID: 1, FarmID: 1, PondID: sql.NullInt64{Int64: 2, Valid: true}, Type: TypeLiming, Blocking: true,
+		Recurrence: "FREQ=MONTHLY;INTERVAL=1", Status: StatusInProgress, DueAt: sql.NullTime{Time: due, Valid: true},
+	}}
+	pondSvc := &stubPondService{}
+	svc := NewService(repo, pondSvc)
+
+	if err := svc.UpdateStatus(context.Background(), &WorkOrderStatusPayload{ID: 1, FarmID: 1, Status: StatusDone}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if !repo.updated.CompletedAt.Valid {
+		t.Errorf("expected completion time to be recorded")
+	}
+
+	// missed occurrence is skipped
+	if len(repo.stored) != 1 || repo.stored[0].Status != StatusScheduled || repo.stored[0].ParentID.Int64 != 1 ||
+		!repo.stored[0].DueAt.Time.Equal(due.AddDate(0, 2, 0)) {
+		t.Errorf("unexpected next occurrence: %+v", repo.stored)
+	}
+
+	if len(pondSvc.payloads) != 1 || pondSvc.payloads[0].Blocked {
+		t.Errorf("expected pond to be released, got: %+v", pondSvc.payloads)
+	}
+}
+
+func TestShouldNOTReopenFinishedWorkOrder(t *testing.T) {
+	repo := &stubWorkOrderRepository{workOrder: &WorkOrderType{ID: 1, FarmID: 1, Status: StatusDone}}
+	svc := NewService(repo, &stubPondService{})
+
+	if err := svc.UpdateStatus(context.Background(), &WorkOrderStatusPayload{ID: 1, FarmID: 1, Status: StatusOpen}); err != errs.ErrInvalidStateTransition {
+		t.Errorf("expected invalid state transition, got: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package mortality
+
+import "github.com/labstack/echo/v4"
+
+type MortalityController struct {
+	svc MortalityService
+}
+
+func NewController(svc MortalityService) *MortalityController {
+	return &MortalityController{
+		svc: svc,
+	}
+}
+
+const (
+	mortalityBasepath = "/farms/:farmID"
+	mortalityPath     = "/ponds/:pondID/mortalities"
+	mortalityIDPath   = "/ponds/:pondID/mortalities/:mortalityID"
+	batchSurvivalPath = "/ponds/:pondID/stockings/:stockingID/survival"
+	pondSurvivalPath  = "/ponds/:pondID/survival"
+	farmSurvivalPath  = "/survival"
+)
+
+func (mc *MortalityController) Route(grp *echo.Group) {
+	subrouter := grp.Group(mortalityBasepath)
+
+	subrouter.GET(mortalityPath, HandleGetAllMortality(mc.svc.GetAll))
+	subrouter.OPTIONS(mortalityPath, HandleGetAllMortality(mc.svc.GetAll))
+	subrouter.GET(mortalityIDPath, HandleGetOneMortality(mc.svc.GetOne))
+	subrouter.OPTIONS(mortalityIDPath, HandleGetOneMortality(mc.svc.GetOne))
+	subrouter.POST(mortalityPath, HandleCreateMortality(mc.svc.Create))
+	subrouter.OPTIONS(mortalityPath, HandleCreateMortality(mc.svc.Create))
+	subrouter.PUT(mortalityIDPath, HandleUpdateMortality(mc.svc.Update))
+	subrouter.OPTIONS(mortalityIDPath, HandleUpdateMortality(mc.svc.Update))
+	subrouter.DELETE(mortalityIDPath, HandleDeleteMortality(mc.svc.Delete))
+	subrouter.OPTIONS(mortalityIDPath, HandleDeleteMortality(mc.svc.Delete))
+	subrouter.GET(batchSurvivalPath, HandleGetBatchSurvival(mc.svc.GetBatchSurvival))
+	subrouter.OPTIONS(batchSurvivalPath, HandleGetBatchSurvival(mc.svc.GetBatchSurvival))
+	subrouter.GET(pondSurvivalPath, HandleGetPondSurvival(mc.svc.GetPondSurvival))
+	subrouter.OPTIONS(pondSurvivalPath, HandleGetPondSurvival(mc.svc.GetPondSurvival))
+	subrouter.GET(farmSurvivalPath, HandleGetFarmSurvival(mc.svc.GetFarmSurvival))
+	subrouter.OPTIONS(farmSurvivalPath, HandleGetFarmSurvival(mc.svc.GetFarmSurvival))
+
+	return
+}
@@ -0,0 +1,96 @@
+package mortality
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// MortalityRequestQuery represent query parameters fetch from request
+type MortalityRequestQuery struct {
+	ID         int64     `param:"mortalityID" example:"1"`
+	FarmID     int64     `param:"farmID" example:"1"`
+	PondID     int64     `param:"pondID" example:"1"`
+	StockingID int64     `query:"stocking_id" example:"1"`
+	From       time.Time `query:"from" example:"2024-07-01T00:00:00Z"`
+	To         time.Time `query:"to" example:"2024-08-01T00:00:00Z"`
+	Limit      uint64    `query:"limit" example:"100"`
+	Page       uint64    `query:"page" example:"2"`
+}
+
+// MortalityPayload represent payload fetch from request body
+type MortalityPayload struct {
+	ID         int64     `param:"mortalityID" json:"-" example:"1"`
+	FarmID     int64     `param:"farmID" json:"-" example:"1"`
+	PondID     int64     `param:"pondID" json:"-" example:"1"`
+	StockingID int64     `json:"stocking_id" example:"1"`
+	Count      int64     `json:"count" example:"150"`
+	Cause      string    `json:"cause" example:"low DO"`
+	RecordedOn time.Time `json:"recorded_on" example:"2024-07-10T00:00:00Z"`
+}
+
+// MortalityResponse represent domain response for Mortality entity
+type MortalityResponse struct {
+	ID         int64     `json:"id" example:"1"`
+	PondID     int64     `json:"pond_id" example:"1"`
+	StockingID int64     `json:"stocking_id" example:"1"`
+	Count      int64     `json:"count" example:"150"`
+	Cause      string    `json:"cause" example:"low DO"`
+	RecordedOn time.Time `json:"recorded_on" example:"2024-07-10T00:00:00Z"`
+}
+
+// ListMortalityResponse represent domain response for bulk Mortality entities
+type ListMortalityResponse struct {
+	Mortalities []*MortalityResponse   `json:"mortalities"`
+	Meta        httpres.ListPagination `json:"meta"`
+}
+
+// SurvivalRequestQuery represent query parameters fetch from request to derive survival report
+type SurvivalRequestQuery struct {
+	FarmID     int64 `param:"farmID" example:"1"`
+	PondID     int64 `param:"pondID" example:"1"`
+	StockingID int64 `param:"stockingID" example:"1"`
+}
+
+// SurvivalPointResponse represent batch's survival at the end of a day with recorded mortality
+type SurvivalPointResponse struct {
+	Date         time.Time `json:"date" example:"2024-07-10T00:00:00Z"`
+	Mortality    int64     `json:"mortality" example:"150"`
+	Headcount    int64     `json:"headcount" example:"99850"`
+	SurvivalRate float64   `json:"survival_rate" example:"99.85"`
+}
+
+// BatchSurvivalResponse represent survival report of a stocking batch
+type BatchSurvivalResponse struct {
+	StockingID     int64                    `json:"stocking_id" example:"1"`
+	BatchName      string                   `json:"batch_name" example:"2024-A"`
+	InitialCount   int64                    `json:"initial_count" example:"100000"`
+	TotalMortality int64                    `json:"total_mortality" example:"150"`
+	TotalHarvested int64                    `json:"total_harvested" example:"0"`
+	Headcount      int64                    `json:"headcount" example:"99850"`     // live fish left in the pond, none once the batch is closed
+	SurvivalRate   float64                  `json:"survival_rate" example:"99.85"` // harvested fish are counted as survived
+	Closed         bool                     `json:"closed" example:"false"`        // totally harvested
+	Timeline       []*SurvivalPointResponse `json:"timeline,omitempty"`
+}
+
+// PondSurvivalResponse represent survival report of every batch in a pond
+type PondSurvivalResponse struct {
+	PondID         int64                    `json:"pond_id" example:"1"`
+	InitialCount   int64                    `json:"initial_count" example:"100000"`
+	TotalMortality int64                    `json:"total_mortality" example:"150"`
+	TotalHarvested int64                    `json:"total_harvested" example:"0"`
+	Headcount      int64                    `json:"headcount" example:"99850"`
+	SurvivalRate   float64                  `json:"survival_rate" example:"99.85"`
+	Batches        []*BatchSurvivalResponse `json:"batches"`
+}
+
+// FarmSurvivalResponse represent survival report rolled up from every pond in a farm
+type FarmSurvivalResponse struct {
+	FarmID         int64                   `json:"farm_id" example:"1"`
+	InitialCount   int64                   `json:"initial_count" example:"100000"`
+	TotalMortality int64                   `json:"total_mortality" example:"150"`
+	TotalHarvested int64                   `json:"total_harvested" example:"0"`
+	Headcount      int64                   `json:"headcount" example:"99850"`
+	SurvivalRate   float64                 `json:"survival_rate" example:"99.85"`
+	Ponds          []*PondSurvivalResponse `json:"ponds"`
+}
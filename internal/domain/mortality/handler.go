@@ -0,0 +1,305 @@
+package mortality
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllMortalityHandler func(context.Context, *MortalityRequestQuery) (*ListMortalityResponse, error)
+
+// Get All Mortality godoc
+//
+//	@Summary	get all mortality records of a pond
+//	@Tags		Mortality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int		true	"Farm ID"
+//	@Param		pondID		path		int		true	"Pond ID"
+//	@Param		stocking_id	query		int		false	"only return mortality of the batch"
+//	@Param		from		query		string	false	"start of date window (inclusive), RFC3339"
+//	@Param		to			query		string	false	"end of date window (exclusive), RFC3339"
+//	@Param		limit		query		string	false	"number of entity per page"
+//	@Param		page		query		string	false	"n-th page"
+//	@Success	200			{object}	ListMortalityResponse
+//	@Failure	400			{object}	httpres.ErrorResponse	"invalid date window"
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/mortalities [get]
+func HandleGetAllMortality(handler GetAllMortalityHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &MortalityRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneMortalityHandler func(context.Context, *MortalityRequestQuery) (*MortalityResponse, error)
+
+// Get One Mortality godoc
+//
+//	@Summary	get specific mortality record by ID
+//	@Tags		Mortality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		mortalityID	path		int	true	"Mortality ID"
+//	@Success	200			{object}	MortalityResponse
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/mortalities/{mortalityID} [get]
+func HandleGetOneMortality(handler GetOneMortalityHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &MortalityRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateMortalityHandler func(context.Context, *MortalityPayload) error
+
+// CreateMortality godoc
+//
+//	@Summary	record dead fish found in a pond
+//	@Tags		Mortality
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int					true	"Farm ID"
+//	@Param		pondID	path		int					true	"Pond ID"
+//	@Param		payload	body		MortalityPayload	true	"mortality payload"
+//	@Success	201		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"count exceed live headcount"
+//	@Failure	404		{object}	httpres.ErrorResponse	"batch not existed in the pond"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/mortalities [post]
+func HandleCreateMortality(handler CreateMortalityHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &MortalityPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateMortalityHandler func(context.Context, *MortalityPayload) error
+
+// Update Mortality godoc
+//
+//	@Summary	update mortality record data
+//	@Tags		Mortality
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int					true	"Farm ID"
+//	@Param		pondID		path		int					true	"Pond ID"
+//	@Param		mortalityID	path		int					true	"Mortality ID"
+//	@Param		payload		body		MortalityPayload	true	"mortality payload"
+//	@Success	200			{object}	string
+//	@Failure	400			{object}	httpres.ErrorResponse	"count exceed live headcount"
+//	@Failure	404			{object}	httpres.ErrorResponse	"mortality not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/mortalities/{mortalityID} [put]
+func HandleUpdateMortality(handler UpdateMortalityHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &MortalityPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteMortalityHandler func(context.Context, *MortalityRequestQuery) error
+
+// DeleteMortality godoc
+//
+//	@Summary	delete specific mortality record by ID
+//	@Tags		Mortality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		mortalityID	path		int	true	"Mortality ID"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"mortality not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/mortalities/{mortalityID} [delete]
+func HandleDeleteMortality(handler DeleteMortalityHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &MortalityRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetBatchSurvivalHandler func(context.Context, *SurvivalRequestQuery) (*BatchSurvivalResponse, error)
+
+// Get Batch Survival godoc
+//
+//	@Summary	get live headcount and survival rate over time of a batch
+//	@Tags		Mortality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		stockingID	path		int	true	"Stocking ID"
+//	@Success	200			{object}	BatchSurvivalResponse
+//	@Failure	404			{object}	httpres.ErrorResponse	"batch not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/stockings/{stockingID}/survival [get]
+func HandleGetBatchSurvival(handler GetBatchSurvivalHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SurvivalRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetPondSurvivalHandler func(context.Context, *SurvivalRequestQuery) (*PondSurvivalResponse, error)
+
+// Get Pond Survival godoc
+//
+//	@Summary	get live headcount and survival rate of every batch in a pond
+//	@Tags		Mortality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Param		pondID	path		int	true	"Pond ID"
+//	@Success	200		{object}	PondSurvivalResponse
+//	@Failure	404		{object}	httpres.ErrorResponse	"no batch stocked in the pond"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/survival [get]
+func HandleGetPondSurvival(handler GetPondSurvivalHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SurvivalRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetFarmSurvivalHandler func(context.Context, *SurvivalRequestQuery) (*FarmSurvivalResponse, error)
+
+// Get Farm Survival godoc
+//
+//	@Summary	get live headcount and survival rate rolled up per farm
+//	@Tags		Mortality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Success	200		{object}	FarmSurvivalResponse
+//	@Failure	404		{object}	httpres.ErrorResponse	"no batch stocked in the farm"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/survival [get]
+func HandleGetFarmSurvival(handler GetFarmSurvivalHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &SurvivalRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
@@ -0,0 +1,34 @@
+package mortality
+
+import (
+	"database/sql"
+	"time"
+)
+
+type MortalityType struct {
+	ID         int64     `db:"id"`
+	PondID     int64     `db:"pond_id"`
+	StockingID int64     `db:"stocking_id"`
+	Count      int64     `db:"count"`
+	Cause      string    `db:"cause"`
+	RecordedOn time.Time `db:"recorded_on"`
+}
+
+// BatchSurvivalType represent a stocking batch along with its cumulative mortality and harvested count
+type BatchSurvivalType struct {
+	StockingID   int64        `db:"stocking_id"`
+	PondID       int64        `db:"pond_id"`
+	BatchName    string       `db:"batch_name"`
+	InitialCount int64        `db:"initial_count"`
+	StockedAt    time.Time    `db:"stocked_at"`
+	ClosedAt     sql.NullTime `db:"closed_at"` // set once the batch is totally harvested
+	Mortality    int64        `db:"mortality"`
+	Harvested    int64        `db:"harvested"`
+}
+
+// DailyMortalityType represent total mortality of a batch recorded on a day
+type DailyMortalityType struct {
+	StockingID int64     `db:"stocking_id"`
+	RecordedOn time.Time `db:"recorded_on"`
+	Count      int64     `db:"count"`
+}
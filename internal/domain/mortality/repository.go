@@ -0,0 +1,372 @@
+package mortality
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// MortalityRepository contain contract that defined all necessary public function available to be interact with
+type MortalityRepository interface {
+	GetAll(context.Context, *mortalityQuery) ([]*MortalityType, error)
+	Count(context.Context, *mortalityQuery) (uint64, error)
+	GetOne(context.Context, *mortalityQuery) (*MortalityType, error)
+	Store(context.Context, int64, *MortalityType) error
+	Update(context.Context, int64, *MortalityType) error
+	Delete(context.Context, *mortalityQuery) error
+
+	GetBatchSurvival(context.Context, *survivalQuery) ([]*BatchSurvivalType, error)
+	GetDailyMortality(context.Context, *survivalQuery) ([]*DailyMortalityType, error)
+}
+
+type mortalityRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of mortalityRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) MortalityRepository {
+	return &mortalityRepository{db: db}
+}
+
+type mortalityQuery struct {
+	ID, FarmID, PondID, StockingID int64
+	From, To                       time.Time
+	Limit, Page                    uint64
+}
+
+// survivalQuery scope survival report into a farm, and optionally into a pond or a batch
+type survivalQuery struct {
+	FarmID, PondID, StockingID int64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var mortalityColumns = []string{"m.id", "m.pond_id", "m.stocking_id", "m.count", "m.cause", "m.recorded_on"}
+
+func (params *mortalityQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"m.pond_id": params.PondID},
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"m.deleted_at": nil},
+	}
+
+	if params.StockingID != 0 {
+		cond = append(cond, squirrel.Eq{"m.stocking_id": params.StockingID})
+	}
+
+	if !params.From.IsZero() {
+		cond = append(cond, squirrel.GtOrEq{"m.recorded_on": params.From})
+	}
+
+	if !params.To.IsZero() {
+		cond = append(cond, squirrel.Lt{"m.recorded_on": params.To})
+	}
+
+	return cond
+}
+
+func (params *survivalQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"s.deleted_at": nil},
+	}
+
+	if params.PondID != 0 {
+		cond = append(cond, squirrel.Eq{"s.pond_id": params.PondID})
+	}
+
+	if params.StockingID != 0 {
+		cond = append(cond, squirrel.Eq{"s.id": params.StockingID})
+	}
+
+	return cond
+}
+
+func (repo *mortalityRepository) GetAll(ctx context.Context, params *mortalityQuery) (res []*MortalityType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(mortalityColumns...).From("mortalities m").
+		Join("ponds p on m.pond_id = p.id").
+		Where(params.filter()).
+		OrderBy("m.recorded_on desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*MortalityType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &MortalityType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *mortalityRepository) Count(ctx context.Context, params *mortalityQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("mortalities m").
+		Join("ponds p on m.pond_id = p.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *mortalityRepository) GetOne(ctx context.Context, params *mortalityQuery) (res *MortalityType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(mortalityColumns...).From("mortalities m").
+		Join("ponds p on m.pond_id = p.id").
+		Where(append(params.filter(), squirrel.Eq{"m.id": params.ID})).ToSql()
+
+	res = &MortalityType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure the batch is stocked in a pond within the farm, and the recorded
+// mortality won't exceed number of fish stocked
+func (repo *mortalityRepository) validate(ctx context.Context, tx *sqlx.Tx, farmID int64, payload *MortalityType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var remaining sql.NullInt64
+
+	stmt, args, _ := pgSquirrel.Select("s.initial_count - coalesce(sum(m.count), 0)").From("stockings s").
+		Join("ponds p on s.pond_id = p.id").
+		LeftJoin("mortalities m on m.stocking_id = s.id and m.deleted_at is null and m.id <> ?", payload.ID).
+		Where(squirrel.And{
+			squirrel.Eq{"s.id": payload.StockingID},
+			squirrel.Eq{"s.pond_id": payload.PondID},
+			squirrel.Eq{"p.farm_id": farmID},
+			squirrel.Eq{"p.deleted_at": nil},
+			squirrel.Eq{"s.deleted_at": nil},
+		}).
+		GroupBy("s.id").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&remaining); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate stocking data existence")
+		return
+	}
+
+	// if selected batch doesn't exists in the pond, bail out from here
+	if !remaining.Valid {
+		return errs.ErrNotFound
+	}
+
+	if payload.Count > remaining.Int64 {
+		return errs.ErrBadRequest
+	}
+
+	return nil
+}
+
+func (repo *mortalityRepository) Store(ctx context.Context, farmID int64, payload *MortalityType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("mortalities").
+		Columns("pond_id", "stocking_id", "count", "cause", "recorded_on").
+		Values(payload.PondID, payload.StockingID, payload.Count, payload.Cause, payload.RecordedOn).ToSql()
+
+	_, err = tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *mortalityRepository) Update(ctx context.Context, farmID int64, payload *MortalityType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("mortalities").SetMap(map[string]interface{}{
+		"stocking_id": payload.StockingID,
+		"count":       payload.Count,
+		"cause":       payload.Cause,
+		"recorded_on": payload.RecordedOn,
+		"updated_at":  squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"pond_id": payload.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *mortalityRepository) Delete(ctx context.Context, params *mortalityQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("mortalities").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"pond_id": params.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("mortality doesn't exists")
+		return
+	}
+
+	return
+}
+
+// GetBatchSurvival return every stocking batch matching the query along with its cumulative mortality
+func (repo *mortalityRepository) GetBatchSurvival(ctx context.Context, params *survivalQuery) (res []*BatchSurvivalType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	// harvest is summed in a subquery, joining it along with mortalities would multiply both sums
+	stmt, args, _ := pgSquirrel.Select("s.id stocking_id", "s.pond_id", "s.batch_name", "s.initial_count", "s.stocked_at", "s.closed_at",
+		"coalesce(sum(m.count), 0) mortality",
+		"coalesce((select sum(h.count) from harvests h where h.stocking_id = s.id and h.deleted_at is null), 0) harvested").
+		From("stockings s").
+		Join("ponds p on s.pond_id = p.id").
+		LeftJoin("mortalities m on m.stocking_id = s.id and m.deleted_at is null").
+		Where(params.filter()).
+		GroupBy("s.id").
+		OrderBy("s.pond_id", "s.stocked_at").ToSql()
+
+	res = []*BatchSurvivalType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &BatchSurvivalType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+// GetDailyMortality return total mortality per day of every batch matching the query
+func (repo *mortalityRepository) GetDailyMortality(ctx context.Context, params *survivalQuery) (res []*DailyMortalityType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("m.stocking_id", "m.recorded_on", "sum(m.count) count").
+		From("mortalities m").
+		Join("stockings s on m.stocking_id = s.id").
+		Join("ponds p on s.pond_id = p.id").
+		Where(append(params.filter(), squirrel.Eq{"m.deleted_at": nil})).
+		GroupBy("m.stocking_id", "m.recorded_on").
+		OrderBy("m.stocking_id", "m.recorded_on").ToSql()
+
+	res = []*DailyMortalityType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &DailyMortalityType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
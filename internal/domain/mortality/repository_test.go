@@ -0,0 +1,95 @@
+package mortality
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldGetBatchSurvivalOfPond(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	mortalityRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"stocking_id", "pond_id", "batch_name", "initial_count", "stocked_at", "mortality"}).
+		AddRow(1, 1, "2024-A", 100000, time.Now(), 150)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT s.id stocking_id, s.pond_id, s.batch_name, s.initial_count, s.stocked_at, s.closed_at, coalesce(sum(m.count), 0) mortality, coalesce((select sum(h.count) from harvests h where h.stocking_id = s.id and h.deleted_at is null), 0) harvested FROM stockings s JOIN ponds p on s.pond_id = p.id LEFT JOIN mortalities m on m.stocking_id = s.id and m.deleted_at is null WHERE (p.farm_id = $1 AND p.deleted_at IS NULL AND s.deleted_at IS NULL AND s.pond_id = $2) GROUP BY s.id ORDER BY s.pond_id, s.stocked_at")).
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	res, err := mortalityRepo.GetBatchSurvival(context.Background(), &survivalQuery{FarmID: 1, PondID: 1})
+	if err != nil || len(res) != 1 || res[0].Mortality != 150 {
+		t.Errorf("unexpected batch survival: %+v, err: %s", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreMortality(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	mortalityRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	recordedOn := time.Now()
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT s.initial_count - coalesce(sum(m.count), 0) FROM stockings s JOIN ponds p on s.pond_id = p.id LEFT JOIN mortalities m on m.stocking_id = s.id and m.deleted_at is null and m.id <> $1 WHERE (s.id = $2 AND s.pond_id = $3 AND p.farm_id = $4 AND p.deleted_at IS NULL AND s.deleted_at IS NULL) GROUP BY s.id")).
+		WithArgs(0, 1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"remaining"}).AddRow(99850))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO mortalities (pond_id,stocking_id,count,cause,recorded_on) VALUES ($1,$2,$3,$4,$5)")).
+		WithArgs(1, 1, 150, "low DO", recordedOn).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = mortalityRepo.Store(context.Background(), 1, &MortalityType{PondID: 1, StockingID: 1, Count: 150, Cause: "low DO", RecordedOn: recordedOn})
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreMortalityExceedingHeadcount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	mortalityRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT s.initial_count - coalesce(sum(m.count), 0) FROM stockings s JOIN ponds p on s.pond_id = p.id LEFT JOIN mortalities m on m.stocking_id = s.id and m.deleted_at is null and m.id <> $1 WHERE (s.id = $2 AND s.pond_id = $3 AND p.farm_id = $4 AND p.deleted_at IS NULL AND s.deleted_at IS NULL) GROUP BY s.id")).
+		WithArgs(0, 1, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"remaining"}).AddRow(100))
+	mock.ExpectRollback()
+
+	err = mortalityRepo.Store(context.Background(), 1, &MortalityType{PondID: 1, StockingID: 1, Count: 150, RecordedOn: time.Now()})
+	if err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -0,0 +1,371 @@
+package mortality
+
+import (
+	"context"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// MortalityService contains public API available to be interacted with
+type MortalityService interface {
+	GetAll(context.Context, *MortalityRequestQuery) (*ListMortalityResponse, error)
+	GetOne(context.Context, *MortalityRequestQuery) (*MortalityResponse, error)
+	Create(context.Context, *MortalityPayload) error
+	Update(context.Context, *MortalityPayload) error
+	Delete(context.Context, *MortalityRequestQuery) error
+	GetBatchSurvival(context.Context, *SurvivalRequestQuery) (*BatchSurvivalResponse, error)
+	GetPondSurvival(context.Context, *SurvivalRequestQuery) (*PondSurvivalResponse, error)
+	GetFarmSurvival(context.Context, *SurvivalRequestQuery) (*FarmSurvivalResponse, error)
+}
+
+type mortalityService struct {
+	repo MortalityRepository
+}
+
+// NewService return an instance of MortalityService containing available usecases
+func NewService(repo MortalityRepository) MortalityService {
+	return &mortalityService{repo: repo}
+}
+
+func (svc *mortalityService) GetAll(ctx context.Context, params *MortalityRequestQuery) (res *ListMortalityResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &mortalityQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: params.StockingID,
+		From:       params.From,
+		To:         params.To,
+		Limit:      params.Limit,
+		Page:       params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &ListMortalityResponse{
+		Mortalities: []*MortalityResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	mortalities, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, mortality := range mortalities {
+		res.Mortalities = append(res.Mortalities, toMortalityResponse(mortality))
+	}
+
+	return
+}
+
+func (svc *mortalityService) GetOne(ctx context.Context, params *MortalityRequestQuery) (res *MortalityResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &mortalityQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	mortality, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if mortality == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toMortalityResponse(mortality), nil
+}
+
+func (svc *mortalityService) Create(ctx context.Context, payload *MortalityPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toMortalityType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Store(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *mortalityService) Update(ctx context.Context, payload *MortalityPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toMortalityType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Update(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *mortalityService) Delete(ctx context.Context, params *MortalityRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &mortalityQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	// make sure the record belongs to requested farm and pond
+	mortality, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if mortality == nil {
+		return errs.ErrNotFound
+	}
+
+	err = svc.repo.Delete(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// GetBatchSurvival derive live headcount and survival rate of a single stocking batch
+func (svc *mortalityService) GetBatchSurvival(ctx context.Context, params *SurvivalRequestQuery) (res *BatchSurvivalResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &survivalQuery{
+		FarmID:     params.FarmID,
+		PondID:     params.PondID,
+		StockingID: params.StockingID,
+	}
+
+	batches, err := svc.repo.GetBatchSurvival(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if len(batches) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	daily, err := svc.repo.GetDailyMortality(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return toBatchSurvivalResponse(batches[0], daily), nil
+}
+
+// GetPondSurvival derive live headcount and survival rate over time of every batch stocked in a pond
+func (svc *mortalityService) GetPondSurvival(ctx context.Context, params *SurvivalRequestQuery) (res *PondSurvivalResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &survivalQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	batches, err := svc.repo.GetBatchSurvival(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if len(batches) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	daily, err := svc.repo.GetDailyMortality(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	res = &PondSurvivalResponse{PondID: params.PondID, Batches: []*BatchSurvivalResponse{}}
+	for _, batch := range batches {
+		summary := toBatchSurvivalResponse(batch, daily)
+
+		res.InitialCount += summary.InitialCount
+		res.TotalMortality += summary.TotalMortality
+		res.TotalHarvested += summary.TotalHarvested
+		res.Headcount += summary.Headcount
+		res.Batches = append(res.Batches, summary)
+	}
+	res.SurvivalRate = survivalRate(res.InitialCount-res.TotalMortality, res.InitialCount)
+
+	return
+}
+
+// GetFarmSurvival roll up survival of every batch into its pond, then into the farm.
+// Daily timeline is omitted to keep farm-wide report compact.
+func (svc *mortalityService) GetFarmSurvival(ctx context.Context, params *SurvivalRequestQuery) (res *FarmSurvivalResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	batches, err := svc.repo.GetBatchSurvival(ctx, &survivalQuery{FarmID: params.FarmID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if len(batches) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	res = &FarmSurvivalResponse{FarmID: params.FarmID, Ponds: []*PondSurvivalResponse{}}
+
+	// batches are ordered by pond, so a new pond summary starts whenever pond changes
+	var pond *PondSurvivalResponse
+	for _, batch := range batches {
+		if pond == nil || pond.PondID != batch.PondID {
+			pond = &PondSurvivalResponse{PondID: batch.PondID, Batches: []*BatchSurvivalResponse{}}
+			res.Ponds = append(res.Ponds, pond)
+		}
+
+		summary := toBatchSurvivalResponse(batch, nil)
+
+		pond.InitialCount += summary.InitialCount
+		pond.TotalMortality += summary.TotalMortality
+		pond.TotalHarvested += summary.TotalHarvested
+		pond.Headcount += summary.Headcount
+		pond.Batches = append(pond.Batches, summary)
+	}
+
+	for _, pond := range res.Ponds {
+		pond.SurvivalRate = survivalRate(pond.InitialCount-pond.TotalMortality, pond.InitialCount)
+
+		res.InitialCount += pond.InitialCount
+		res.TotalMortality += pond.TotalMortality
+		res.TotalHarvested += pond.TotalHarvested
+		res.Headcount += pond.Headcount
+	}
+	res.SurvivalRate = survivalRate(res.InitialCount-res.TotalMortality, res.InitialCount)
+
+	return
+}
+
+// survivalRate return percentage of live fish from stocked fish
+func survivalRate(headcount, initial int64) float64 {
+	if initial <= 0 {
+		return 0
+	}
+
+	return float64(headcount) / float64(initial) * 100
+}
+
+func toBatchSurvivalResponse(batch *BatchSurvivalType, daily []*DailyMortalityType) *BatchSurvivalResponse {
+	res := &BatchSurvivalResponse{
+		StockingID:     batch.StockingID,
+		BatchName:      batch.BatchName,
+		InitialCount:   batch.InitialCount,
+		TotalMortality: batch.Mortality,
+		TotalHarvested: batch.Harvested,
+		Closed:         batch.ClosedAt.Valid,
+	}
+	res.SurvivalRate = survivalRate(batch.InitialCount-batch.Mortality, batch.InitialCount)
+
+	// harvested fish leave the pond, while total harvest leaves nothing even when the count isn't recorded
+	if !res.Closed {
+		res.Headcount = max(batch.InitialCount-batch.Mortality-batch.Harvested, 0)
+	}
+
+	// daily mortality is ordered by date, accumulate it to trace survivors over time
+	headcount := batch.InitialCount
+	for _, day := range daily {
+		if day.StockingID != batch.StockingID {
+			continue
+		}
+
+		headcount -= day.Count
+		res.Timeline = append(res.Timeline, &SurvivalPointResponse{
+			Date:         day.RecordedOn,
+			Mortality:    day.Count,
+			Headcount:    headcount,
+			SurvivalRate: survivalRate(headcount, batch.InitialCount),
+		})
+	}
+
+	return res
+}
+
+func toMortalityType(payload *MortalityPayload) (res *MortalityType, err error) {
+	if payload.StockingID == 0 {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if payload.Count <= 0 {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &MortalityType{
+		ID:         payload.ID,
+		PondID:     payload.PondID,
+		StockingID: payload.StockingID,
+		Count:      payload.Count,
+		Cause:      payload.Cause,
+		RecordedOn: payload.RecordedOn,
+	}
+
+	// mortality without explicit date is assumed to be found today
+	if res.RecordedOn.IsZero() {
+		res.RecordedOn = time.Now()
+	}
+
+	return
+}
+
+func toMortalityResponse(mortality *MortalityType) *MortalityResponse {
+	return &MortalityResponse{
+		ID:         mortality.ID,
+		PondID:     mortality.PondID,
+		StockingID: mortality.StockingID,
+		Count:      mortality.Count,
+		Cause:      mortality.Cause,
+		RecordedOn: mortality.RecordedOn,
+	}
+}
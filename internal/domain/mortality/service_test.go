@@ -0,0 +1,114 @@
+package mortality
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+)
+
+type stubMortalityRepository struct {
+	MortalityRepository
+	batches []*BatchSurvivalType
+	daily   []*DailyMortalityType
+}
+
+func (repo *stubMortalityRepository) GetBatchSurvival(context.Context, *survivalQuery) ([]*BatchSurvivalType, error) {
+	return repo.batches, nil
+}
+
+func (repo *stubMortalityRepository) GetDailyMortality(context.Context, *survivalQuery) ([]*DailyMortalityType, error) {
+	return repo.daily, nil
+}
+
+func TestShouldTraceBatchSurvivalOverTime(t *testing.T) {
+	day := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+	svc := NewService(&stubMortalityRepository{
+		batches: []*BatchSurvivalType{{StockingID: 1, PondID: 1, InitialCount: 1000, Mortality: 150}},
+		daily: []*DailyMortalityType{
+			{StockingID: 1, RecordedOn: day, Count: 100},
+			{StockingID: 1, RecordedOn: day.AddDate(0, 0, 1), Count: 50},
+		},
+	})
+
+	res, err := svc.GetPondSurvival(context.Background(), &SurvivalRequestQuery{FarmID: 1, PondID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	batch := res.Batches[0]
+	if batch.Headcount != 850 || math.Abs(batch.SurvivalRate-85) > 1e-6 || len(batch.Timeline) != 2 {
+		t.Fatalf("unexpected batch survival: %+v", batch)
+	}
+
+	if batch.Timeline[0].Headcount != 900 || batch.Timeline[1].Headcount != 850 {
+		t.Errorf("unexpected survival timeline: %+v, %+v", batch.Timeline[0], batch.Timeline[1])
+	}
+}
+
+func TestShouldRollUpSurvivalPerFarm(t *testing.T) {
+	svc := NewService(&stubMortalityRepository{
+		batches: []*BatchSurvivalType{
+			{StockingID: 1, PondID: 1, InitialCount: 1000, Mortality: 100},
+			{StockingID: 2, PondID: 1, InitialCount: 1000, Mortality: 300},
+			{StockingID: 3, PondID: 2, InitialCount: 2000, Mortality: 0},
+		},
+	})
+
+	res, err := svc.GetFarmSurvival(context.Background(), &SurvivalRequestQuery{FarmID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(res.Ponds) != 2 || res.Ponds[0].Headcount != 1600 || res.Ponds[1].Headcount != 2000 {
+		t.Fatalf("unexpected pond rollup: %+v", res.Ponds)
+	}
+
+	if res.Headcount != 3600 || math.Abs(res.SurvivalRate-90) > 1e-6 {
+		t.Errorf("unexpected farm rollup: %+v", res)
+	}
+}
+
+func TestShouldDeductHarvestFromHeadcount(t *testing.T) {
+	svc := NewService(&stubMortalityRepository{
+		batches: []*BatchSurvivalType{{StockingID: 1, PondID: 1, InitialCount: 1000, Mortality: 100, Harvested: 400}},
+	})
+
+	res, err := svc.GetBatchSurvival(context.Background(), &SurvivalRequestQuery{FarmID: 1, PondID: 1, StockingID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	// partially harvested fish are gone from the pond, yet they did survive
+	if res.Headcount != 500 || res.TotalHarvested != 400 || math.Abs(res.SurvivalRate-90) > 1e-6 {
+		t.Errorf("unexpected batch survival: %+v", res)
+	}
+}
+
+func TestShouldNOTCountClosedBatchInLiveHeadcount(t *testing.T) {
+	svc := NewService(&stubMortalityRepository{
+		batches: []*BatchSurvivalType{
+			{StockingID: 1, PondID: 1, InitialCount: 1000, Mortality: 100, Harvested: 850, ClosedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+			{StockingID: 2, PondID: 1, InitialCount: 1000, Mortality: 100},
+			{StockingID: 3, PondID: 2, InitialCount: 2000, Mortality: 0, Harvested: 500},
+		},
+	})
+
+	res, err := svc.GetFarmSurvival(context.Background(), &SurvivalRequestQuery{FarmID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(res.Ponds) != 2 || res.Ponds[0].Headcount != 900 || res.Ponds[1].Headcount != 1500 {
+		t.Fatalf("unexpected pond rollup: %+v", res.Ponds)
+	}
+
+	if closed := res.Ponds[0].Batches[0]; !closed.Closed || closed.Headcount != 0 {
+		t.Errorf("unexpected closed batch: %+v", closed)
+	}
+
+	if res.Headcount != 2400 || res.TotalHarvested != 1350 || math.Abs(res.SurvivalRate-95) > 1e-6 {
+		t.Errorf("unexpected farm rollup: %+v", res)
+	}
+}
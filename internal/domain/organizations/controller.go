@@ -0,0 +1,31 @@
+package organizations
+
+import "github.com/labstack/echo/v4"
+
+type OrganizationController struct {
+	svc OrganizationService
+}
+
+func NewController(svc OrganizationService) *OrganizationController {
+	return &OrganizationController{
+		svc: svc,
+	}
+}
+
+const (
+	organizationBasepath = "/organizations"
+	organizationIDPath   = "/:organizationID"
+)
+
+func (oc *OrganizationController) Route(grp *echo.Group) {
+	subrouter := grp.Group(organizationBasepath)
+
+	subrouter.GET("", HandleGetAllOrganization(oc.svc.GetAll))
+	subrouter.OPTIONS("", HandleGetAllOrganization(oc.svc.GetAll))
+	subrouter.GET(organizationIDPath, HandleGetOneOrganization(oc.svc.GetOne))
+	subrouter.OPTIONS(organizationIDPath, HandleGetOneOrganization(oc.svc.GetOne))
+	subrouter.POST("", HandleCreateOrganization(oc.svc.Create))
+	subrouter.OPTIONS("", HandleCreateOrganization(oc.svc.Create))
+
+	return
+}
@@ -0,0 +1,32 @@
+package organizations
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// OrganizationRequestQuery represent query parameters fetch from request
+type OrganizationRequestQuery struct {
+	ID    int64  `param:"organizationID" example:"1"`
+	Limit uint64 `query:"limit" example:"100"`
+	Page  uint64 `query:"page" example:"2"`
+}
+
+// OrganizationPayload represent payload fetch from request body
+type OrganizationPayload struct {
+	Name string `json:"name" example:"Koperasi Tambak Jembrana"`
+}
+
+// OrganizationResponse represent domain response for Organization entity
+type OrganizationResponse struct {
+	ID        int64     `json:"id" example:"1"`
+	Name      string    `json:"name" example:"Koperasi Tambak Jembrana"`
+	CreatedAt time.Time `json:"created_at" example:"2024-08-20T01:42:15Z"`
+}
+
+// ListOrganizationResponse represent domain response for bulk Organization entities
+type ListOrganizationResponse struct {
+	Organizations []*OrganizationResponse `json:"organizations"`
+	Meta          httpres.ListPagination  `json:"meta"`
+}
@@ -0,0 +1,112 @@
+package organizations
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllOrganizationHandler func(context.Context, *OrganizationRequestQuery) (*ListOrganizationResponse, error)
+
+// Get All Organization godoc
+//
+//	@Summary	get all organizations, non-superadmin only sees its own
+//	@Tags		Organization
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListOrganizationResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/organizations [get]
+func HandleGetAllOrganization(handler GetAllOrganizationHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &OrganizationRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneOrganizationHandler func(context.Context, *OrganizationRequestQuery) (*OrganizationResponse, error)
+
+// Get One Organization godoc
+//
+//	@Summary	get specific organization by ID
+//	@Tags		Organization
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		organizationID	path		int	true	"Organization ID"
+//	@Success	200				{object}	OrganizationResponse
+//	@Failure	404				{object}	httpres.ErrorResponse
+//	@Failure	500				{object}	httpres.ErrorResponse
+//	@Router		/organizations/{organizationID} [get]
+func HandleGetOneOrganization(handler GetOneOrganizationHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &OrganizationRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateOrganizationHandler func(context.Context, *OrganizationPayload) error
+
+// CreateOrganization godoc
+//
+//	@Summary	create a new organization, farms and users of an organization are invisible to another
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		payload	body		OrganizationPayload	true	"organization payload"
+//	@Success	201		{object}	string
+//	@Failure	403		{object}	httpres.ErrorResponse	"non-superadmin user"
+//	@Failure	409		{object}	httpres.ErrorResponse	"name already taken"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/organizations [post]
+func HandleCreateOrganization(handler CreateOrganizationHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &OrganizationPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
@@ -0,0 +1,9 @@
+package organizations
+
+import "time"
+
+type OrganizationType struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
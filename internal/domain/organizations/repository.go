@@ -0,0 +1,164 @@
+package organizations
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// OrganizationRepository contain contract that defined all necessary public function available to be interact with
+type OrganizationRepository interface {
+	GetAll(context.Context, *organizationQuery) ([]*OrganizationType, error)
+	Count(context.Context, *organizationQuery) (uint64, error)
+	GetOne(context.Context, *organizationQuery) (*OrganizationType, error)
+	Store(context.Context, *OrganizationType) error
+}
+
+type organizationRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of organizationRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+type organizationQuery struct {
+	ID          int64
+	Limit, Page uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var organizationColumns = []string{"id", "name", "created_at"}
+
+func (params *organizationQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"deleted_at": nil},
+	}
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"id": params.ID})
+	}
+
+	return cond
+}
+
+func (repo *organizationRepository) GetAll(ctx context.Context, params *organizationQuery) (res []*OrganizationType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(organizationColumns...).From("organizations").
+		Where(params.filter()).
+		OrderBy("id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*OrganizationType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &OrganizationType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *organizationRepository) Count(ctx context.Context, params *organizationQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("organizations").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *organizationRepository) GetOne(ctx context.Context, params *organizationQuery) (res *OrganizationType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(organizationColumns...).From("organizations").
+		Where(params.filter()).ToSql()
+
+	res = &OrganizationType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// Store save a new organization, the generated ID will be assigned back into payload
+func (repo *organizationRepository) Store(ctx context.Context, payload *OrganizationType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var count int64
+
+	// organization name is case-insensitive
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("organizations").Where(squirrel.And{
+		squirrel.Eq{"lower(name)": strings.ToLower(payload.Name)},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
+		return
+	}
+
+	// if active (non-deleted) organization exist with such name, return duplicated err
+	if count != 0 {
+		return errs.ErrDuplicatedResources
+	}
+
+	stmt, args, _ = pgSquirrel.Insert("organizations").
+		Columns("name").
+		Values(payload.Name).
+		Suffix("RETURNING id, created_at").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID, &payload.CreatedAt); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,142 @@
+package organizations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// OrganizationService contains public API available to be interacted with
+type OrganizationService interface {
+	GetAll(context.Context, *OrganizationRequestQuery) (*ListOrganizationResponse, error)
+	GetOne(context.Context, *OrganizationRequestQuery) (*OrganizationResponse, error)
+	Create(context.Context, *OrganizationPayload) error
+}
+
+type organizationService struct {
+	repo OrganizationRepository
+}
+
+// NewService return an instance of OrganizationService containing available usecases
+func NewService(repo OrganizationRepository) OrganizationService {
+	return &organizationService{repo: repo}
+}
+
+// GetAll return every organization to superadmin, while other users only see their own
+func (svc *organizationService) GetAll(ctx context.Context, params *OrganizationRequestQuery) (res *ListOrganizationResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	repoParams := &organizationQuery{
+		Limit: params.Limit,
+		Page:  params.Page,
+	}
+
+	if !identity.Superadmin {
+		repoParams.ID = identity.OrganizationID
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListOrganizationResponse{
+		Organizations: []*OrganizationResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	organizations, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, organization := range organizations {
+		res.Organizations = append(res.Organizations, toOrganizationResponse(organization))
+	}
+
+	return
+}
+
+func (svc *organizationService) GetOne(ctx context.Context, params *OrganizationRequestQuery) (res *OrganizationResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	// another tenant is indistinguishable from a non-existing one
+	if !identity.Superadmin && params.ID != identity.OrganizationID {
+		return nil, errs.ErrNotFound
+	}
+
+	organization, err := svc.repo.GetOne(ctx, &organizationQuery{ID: params.ID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if organization == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toOrganizationResponse(organization), nil
+}
+
+// Create register a new tenant, only superadmin may do so
+func (svc *organizationService) Create(ctx context.Context, payload *OrganizationPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if identity := auth.FromContext(ctx); identity == nil || !identity.Superadmin {
+		return errs.ErrNoAccess
+	}
+
+	payload.Name = strings.TrimSpace(payload.Name)
+	if payload.Name == "" {
+		return errs.ErrMissingRequiredAttribute
+	}
+
+	err = svc.repo.Store(ctx, &OrganizationType{Name: payload.Name})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func toOrganizationResponse(organization *OrganizationType) *OrganizationResponse {
+	return &OrganizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+	}
+}
@@ -0,0 +1,50 @@
+package organizations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+type stubOrganizationRepository struct {
+	OrganizationRepository
+	stored *OrganizationType
+}
+
+func (repo *stubOrganizationRepository) GetOne(_ context.Context, params *organizationQuery) (*OrganizationType, error) {
+	return &OrganizationType{ID: params.ID, Name: "Koperasi"}, nil
+}
+
+func (repo *stubOrganizationRepository) Store(_ context.Context, payload *OrganizationType) error {
+	repo.stored = payload
+	return nil
+}
+
+func TestShouldNOTCreateOrganizationByNonSuperadmin(t *testing.T) {
+	repo := &stubOrganizationRepository{}
+	svc := NewService(repo)
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 2, Admin: true, OrganizationID: 1})
+
+	if err := svc.Create(ctx, &OrganizationPayload{Name: "Koperasi"}); err != errs.ErrNoAccess {
+		t.Errorf("expected ErrNoAccess, got: %v", err)
+	}
+
+	if repo.stored != nil {
+		t.Errorf("expected nothing stored, got: %+v", repo.stored)
+	}
+}
+
+func TestShouldNOTGetAnotherOrganization(t *testing.T) {
+	svc := NewService(&stubOrganizationRepository{})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 2, OrganizationID: 1})
+
+	if _, err := svc.GetOne(ctx, &OrganizationRequestQuery{ID: 1}); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+
+	if _, err := svc.GetOne(ctx, &OrganizationRequestQuery{ID: 2}); err != errs.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
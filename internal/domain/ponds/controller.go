@@ -1,20 +1,28 @@
 package ponds
 
-import "github.com/labstack/echo/v4"
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/middleware"
+)
 
 type PondController struct {
-	svc PondService
+	svc   PondService
+	authz middleware.Authorizer
 }
 
-func NewController(svc PondService) *PondController {
+func NewController(svc PondService, authz middleware.Authorizer) *PondController {
 	return &PondController{
-		svc: svc,
+		svc:   svc,
+		authz: authz,
 	}
 }
 
 const (
 	pondBasepath = "/farms/:farmID/ponds"
 	pondIDPath   = "/:pondID"
+	geoJSONPath  = ".geojson"
+	restorePath  = "/:pondID/restore"
 )
 
 func (pc *PondController) Route(grp *echo.Group) {
@@ -24,12 +32,16 @@ func (pc *PondController) Route(grp *echo.Group) {
 	subrouter.OPTIONS("", HandleGetAllPond(pc.svc.GetAll))
 	subrouter.GET(pondIDPath, HandleGetOnePond(pc.svc.GetOne))
 	subrouter.OPTIONS(pondIDPath, HandleGetOnePond(pc.svc.GetOne))
+	subrouter.GET(geoJSONPath, HandleGetPondGeoJSON(pc.svc.GetGeoJSON))
+	subrouter.OPTIONS(geoJSONPath, HandleGetPondGeoJSON(pc.svc.GetGeoJSON))
 	subrouter.POST("", HandleCreatePond(pc.svc.Create))
 	subrouter.OPTIONS("", HandleCreatePond(pc.svc.Create))
 	subrouter.PUT(pondIDPath, HandleUpdatePond(pc.svc.Update))
 	subrouter.OPTIONS(pondIDPath, HandleUpdatePond(pc.svc.Update))
-	subrouter.DELETE(pondIDPath, HandleDeletePond(pc.svc.Delete))
+	subrouter.DELETE(pondIDPath, HandleDeletePond(pc.svc.Delete), middleware.RequireAction(pc.authz, auth.ActionDeletePond))
 	subrouter.OPTIONS(pondIDPath, HandleDeletePond(pc.svc.Delete))
+	subrouter.POST(restorePath, HandleRestorePond(pc.svc.Restore), middleware.RequireAction(pc.authz, auth.ActionDeletePond))
+	subrouter.OPTIONS(restorePath, HandleRestorePond(pc.svc.Restore))
 
 	return
 }
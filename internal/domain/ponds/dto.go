@@ -1,14 +1,35 @@
 package ponds
 
-import "github.com/nmluci/da-farm-be/internal/core/httpres"
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/geo"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/core/listquery"
+	"github.com/nmluci/da-farm-be/internal/domain/growth"
+)
+
+const (
+	// ExpandGrowth include current growth estimate of the pond's active batch
+	ExpandGrowth = "growth"
+)
 
 // PondRequestQuery represent query parameters fetch from request
 type PondRequestQuery struct {
 	ID      int64  `param:"pondID" example:"1"`
 	FarmID  int64  `param:"farmID" example:"1"`
 	Keyword string `query:"keyword" example:"Pond A"`
+	Expand  string `query:"expand" example:"growth"`
+	Deleted bool   `query:"deleted" example:"false"` // list soft-deleted ponds instead, which can be restored
 	Limit   uint64 `query:"limit" example:"100"`
 	Page    uint64 `query:"page" example:"2"`
+
+	List *listquery.Params // sort, filter and fields, parsed out of query string by handler
+}
+
+// PurgePayload represent parameter of purging soft-deleted ponds
+type PurgePayload struct {
+	Retention time.Duration // ponds deleted longer than this are purged
 }
 
 // PondPayload represent payload fetch from request body
@@ -16,6 +37,21 @@ type PondPayload struct {
 	ID     int64  `param:"pondID" json:"-" example:"1"`
 	FarmID int64  `param:"farmID" json:"-" example:"1"`
 	Name   string `json:"name" example:"Pond 1"`
+
+	Area             float64      `json:"area" example:"1000"`                                                         // in m2, default to area of boundary
+	Depth            float64      `json:"depth" example:"1.2"`                                                         // in m
+	Volume           float64      `json:"volume" example:"1200"`                                                       // in m3, default to area * depth
+	Type             string       `json:"type" example:"earthen" enums:"earthen,concrete,tarpaulin,ras"`               // default to earthen
+	AerationCapacity float64      `json:"aeration_capacity" example:"4"`                                               // in hp
+	Status           string       `json:"status" example:"idle" enums:"idle,preparing,stocked,harvesting,maintenance"` // default to current status
+	Boundary         *geo.Polygon `json:"boundary"`
+}
+
+// PondMaintenancePayload represent whether a pond is blocked by an ongoing maintenance
+type PondMaintenancePayload struct {
+	ID      int64
+	FarmID  int64
+	Blocked bool
 }
 
 // PondResponse represent domain response for Pond entity
@@ -24,6 +60,36 @@ type PondResponse struct {
 	FarmID   int64  `json:"farm_id" example:"1"`
 	FarmName string `json:"farm_name" example:"Farm A"`
 	Name     string `json:"pond_name" example:"Pond A"`
+
+	Area             float64      `json:"area" example:"1000"`
+	Depth            float64      `json:"depth" example:"1.2"`
+	Volume           float64      `json:"volume" example:"1200"`
+	Type             string       `json:"type" example:"earthen"`
+	AerationCapacity float64      `json:"aeration_capacity" example:"4"`
+	Status           string       `json:"status" example:"idle"`
+	Boundary         *geo.Polygon `json:"boundary"`
+
+	Growth *growth.EstimateResponse `json:"growth,omitempty"`
+
+	list *listquery.Params // field selection of the listing, unselected fields are left out
+}
+
+// MarshalJSON serialize pond without fields left out of the listing's selection
+func (res PondResponse) MarshalJSON() ([]byte, error) {
+	type pondResponse PondResponse
+	return res.list.Project(pondResponse(res))
+}
+
+// PondProperties represent attributes attached to each pond feature
+type PondProperties struct {
+	Name             string  `json:"name" example:"Pond A"`
+	FarmID           int64   `json:"farm_id" example:"1"`
+	Area             float64 `json:"area" example:"1000"`
+	Depth            float64 `json:"depth" example:"1.2"`
+	Volume           float64 `json:"volume" example:"1200"`
+	Type             string  `json:"type" example:"earthen"`
+	AerationCapacity float64 `json:"aeration_capacity" example:"4"`
+	Status           string  `json:"status" example:"idle"`
 }
 
 // ListPondResponse represent domain response for bulk Pond entities
@@ -5,7 +5,9 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/geo"
 	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/nmluci/da-farm-be/internal/core/listquery"
 	"github.com/rs/zerolog"
 )
 
@@ -16,11 +18,18 @@ type GetAllPondHandler func(context.Context, *PondRequestQuery) (*ListPondRespon
 //	@Summary	get all pond
 //	@Tags		Pond
 //	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
 //	@Success	200		{object}	ListPondResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"field not allowed to sort, filter or select"
 //	@Failure	404		{object}	httpres.ErrorResponse
 //	@Failure	500		{object}	httpres.ErrorResponse
 //	@Param		farmID	path		int		true	"farm ID"
-//	@Param		keyword	query		string	false	"Keyword to search"
+//	@Param		keyword	query		string	false	"search by name or type, matched by word prefix and tolerating typos, ordered by relevance"
+//	@Param		deleted	query		bool	false	"list soft-deleted ponds instead, which can be restored"
+//	@Param		sort	query		string	false	"comma separated id, name, area, depth, volume, type, aeration_capacity, status, created_at or updated_at, prefixed by - for descending, ex: -created_at,name"
+//	@Param		filter	query		string	false	"filter[name|type|status]=a,b matches any of the values, filter[created_at|updated_at][gt|gte|lt|lte] accepts RFC3339 or YYYY-MM-DD, ex: filter[status]=stocked"
+//	@Param		fields	query		string	false	"comma separated fields to fetch along with id, the rest are omitted from response, ex: id,name"
 //	@Param		limit	query		string	false	"number of entity per page"
 //	@Param		page	query		string	false	"n-th page"
 //	@Router		/farms/{farmID}/ponds [get]
@@ -35,6 +44,10 @@ func HandleGetAllPond(handler GetAllPondHandler) echo.HandlerFunc {
 			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
 		}
 
+		if params.List, err = listquery.Parse(c.QueryParams(), pondFields); err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
 		data, err := handler(ctx, params)
 		if err != nil {
 			return httputil.WriteErrorResponse(c, err)
@@ -51,8 +64,11 @@ type GetOnePondHandler func(context.Context, *PondRequestQuery) (*PondResponse,
 //	@Summary	get specific pond by ID
 //	@Tags		Pond
 //	@Produce	json
-//	@Param		farmID	path		int	true	"Farm ID"
-//	@Param		pondID	path		int	true	"Pond ID"
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pondID	path		int		true	"Pond ID"
+//	@Param		expand	query		string	false	"include optional attribute"	Enums(growth)
 //	@Success	200		{object}	PondResponse
 //	@Failure	404		{object}	httpres.ErrorResponse
 //	@Failure	500		{object}	httpres.ErrorResponse
@@ -77,6 +93,40 @@ func HandleGetOnePond(handler GetOnePondHandler) echo.HandlerFunc {
 	}
 }
 
+type GetPondGeoJSONHandler func(context.Context, *PondRequestQuery) (*geo.FeatureCollection, error)
+
+// Get Pond GeoJSON godoc
+//
+//	@Summary	get boundaries of every pond within a farm as GeoJSON FeatureCollection
+//	@Tags		Pond
+//	@Produce	application/geo+json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Success	200		{object}	geo.FeatureCollection
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds.geojson [get]
+func HandleGetPondGeoJSON(handler GetPondGeoJSONHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &PondRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteGeoJSONResponse(c, http.StatusOK, data)
+	}
+}
+
 type CreatePondHandler func(context.Context, *PondPayload) error
 
 // CreatePond godoc
@@ -85,6 +135,8 @@ type CreatePondHandler func(context.Context, *PondPayload) error
 //	@Tags		Pond
 //	@Accept		json
 //	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
 //	@Param		farmID	path		int			true	"Farm ID"
 //	@Param		payload	body		PondPayload	true	"pond payload"
 //	@Success	201		{object}	string
@@ -119,12 +171,15 @@ type UpdatePondHandler func(context.Context, *PondPayload) error
 //	@Tags		Pond
 //	@Accept		json
 //	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
 //	@Param		farmID	path		int			true	"Farm ID"
 //	@Param		pondID	path		int			true	"Pond ID"
 //	@Param		payload	body		PondPayload	true	"pond payload"
 //	@Success	200		{object}	string
+//	@Failure	403		{object}	httpres.ErrorResponse	"no write access on the farm"
 //	@Failure	404		{object}	httpres.ErrorResponse	"pond not existed"
-//	@Failure	409		{object}	httpres.ErrorResponse	"duplicated pond found"
+//	@Failure	409		{object}	httpres.ErrorResponse	"duplicated pond found or invalid status transition"
 //	@Failure	500		{object}	httpres.ErrorResponse
 //	@Router		/farms/{farmID}/ponds/{pondID} [put]
 func HandleUpdatePond(handler UpdatePondHandler) echo.HandlerFunc {
@@ -152,12 +207,16 @@ type DeletePondHandler func(context.Context, *PondRequestQuery) error
 
 // DeletePond godoc
 //
-//	@Summary	delete specific pond by ID
+//	@Summary	delete specific pond by ID along with its records
 //	@Tags		Pond
 //	@Produce	json
-//	@Param		farmID	path		int	true	"Farm ID"
-//	@Param		pondID	path		int	true	"Pond ID"
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pondID	path		int		true	"Pond ID"
+//	@Param		expand	query		string	false	"include optional attribute"	Enums(growth)
 //	@Success	200		{object}	PondResponse
+//	@Failure	403		{object}	httpres.ErrorResponse	"not an owner or manager of the farm"
 //	@Failure	404		{object}	httpres.ErrorResponse	"pond not existed"
 //	@Failure	500		{object}	httpres.ErrorResponse
 //	@Router		/farms/{farmID}/ponds/{pondID} [delete]
@@ -180,3 +239,40 @@ func HandleDeletePond(handler DeletePondHandler) echo.HandlerFunc {
 		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
 	}
 }
+
+type RestorePondHandler func(context.Context, *PondRequestQuery) error
+
+// RestorePond godoc
+//
+//	@Summary	restore soft-deleted pond by ID along with records deleted together with it
+//	@Tags		Pond
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int	true	"Farm ID"
+//	@Param		pondID	path		int	true	"Pond ID"
+//	@Success	200		{object}	string
+//	@Failure	403		{object}	httpres.ErrorResponse "not permitted deleting pond of the farm"
+//	@Failure	404		{object}	httpres.ErrorResponse "pond not deleted or farm not existed"
+//	@Failure	409		{object}	httpres.ErrorResponse "name taken by an active pond or its sensor registered elsewhere"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/restore [post]
+func HandleRestorePond(handler RestorePondHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &PondRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,57 @@
+package ponds
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestShouldOmitUnselectedFieldsOfPondListing(t *testing.T) {
+	repo := &stubPondRepository{ponds: []*PondFarmType{{PondType: PondType{ID: 1, Name: "Pond A"}}}}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	ec := echo.New()
+	ec.GET("/farms/:farmID/ponds", HandleGetAllPond(svc.GetAll))
+
+	cases := []struct {
+		query string
+		keys  []string
+	}{
+		{"fields=name", []string{"id", "pond_name"}},
+		{"fields=farm_name,status", []string{"farm_name", "id", "status"}},
+		{"", []string{"aeration_capacity", "area", "boundary", "depth", "farm_id", "farm_name", "id", "pond_name", "status", "type", "volume"}},
+	}
+
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		ec.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/farms/1/ponds?"+tc.query, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status on %q: %d", tc.query, rec.Code)
+		}
+
+		body := struct {
+			Data struct {
+				Ponds []map[string]any `json:"ponds"`
+			} `json:"data"`
+		}{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || len(body.Data.Ponds) != 1 {
+			t.Fatalf("unexpected body on %q: %s", tc.query, rec.Body.String())
+		}
+
+		keys := []string{}
+		for key := range body.Data.Ponds[0] {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		if !reflect.DeepEqual(keys, tc.keys) {
+			t.Errorf("unexpected keys on %q: %v", tc.query, keys)
+		}
+	}
+}
@@ -1,9 +1,46 @@
 package ponds
 
+import "github.com/nmluci/da-farm-be/internal/core/geo"
+
+const (
+	TypeEarthen   = "earthen"
+	TypeConcrete  = "concrete"
+	TypeTarpaulin = "tarpaulin"
+	TypeRAS       = "ras"
+)
+
+const (
+	StatusIdle        = "idle"
+	StatusPreparing   = "preparing"
+	StatusStocked     = "stocked"
+	StatusHarvesting  = "harvesting"
+	StatusMaintenance = "maintenance"
+)
+
+// pondTypes list every supported pond construction
+var pondTypes = []string{TypeEarthen, TypeConcrete, TypeTarpaulin, TypeRAS}
+
+// statusTransitions map every status into statuses it may move into
+var statusTransitions = map[string][]string{
+	StatusIdle:        {StatusPreparing, StatusMaintenance},
+	StatusPreparing:   {StatusIdle, StatusStocked, StatusMaintenance},
+	StatusStocked:     {StatusHarvesting, StatusMaintenance},
+	StatusHarvesting:  {StatusStocked, StatusIdle},
+	StatusMaintenance: {StatusIdle},
+}
+
 type PondType struct {
-	ID     int64  `db:"id"`
-	FarmID int64  `db:"farm_id"`
-	Name   string `db:"name"`
+	ID               int64        `db:"id"`
+	FarmID           int64        `db:"farm_id"`
+	Name             string       `db:"name"`
+	Area             float64      `db:"area"`
+	Depth            float64      `db:"depth"`
+	Volume           float64      `db:"volume"`
+	Type             string       `db:"type"`
+	AerationCapacity float64      `db:"aeration_capacity"`
+	Status           string       `db:"status"`
+	Boundary         *geo.Polygon `db:"boundary"`
+	OrganizationID   int64        `db:"-"` // tenant of the farm, validated on save when non-zero
 }
 
 type PondFarmType struct {
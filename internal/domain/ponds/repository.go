@@ -3,10 +3,17 @@ package ponds
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"slices"
+	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/listquery"
+	"github.com/nmluci/da-farm-be/internal/core/search"
 	"github.com/rs/zerolog"
 )
 
@@ -17,6 +24,8 @@ type PondRepository interface {
 	Store(context.Context, *PondType) error
 	Upsert(context.Context, *PondType) error
 	Delete(context.Context, *pondQuery) error
+	Restore(context.Context, *pondQuery) error
+	Purge(context.Context, time.Time) (int64, error)
 }
 
 type pondRepository struct {
@@ -29,25 +38,148 @@ func NewRepository(db *sqlx.DB) PondRepository {
 }
 
 type pondQuery struct {
-	ID, FarmID  int64
-	Keyword     string
-	Limit, Page uint64
+	ID, FarmID     int64
+	Keyword        string
+	OrganizationID int64 // only ponds of the tenant farms, when non-zero
+	Deleted        bool  // soft-deleted ponds instead of active ones
+	List           *listquery.Params
+	Limit, Page    uint64
 }
 
 var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
+// pondOwned and equipmentOwned match records of a pond and of the equipments installed in it respectively
+const (
+	pondOwned      = "pond_id = ?"
+	equipmentOwned = "equipment_id in (select id from equipments where pond_id = ?)"
+)
+
+// purgeable map owner condition of records into the one matching records of ponds deleted before a cutoff
+var purgeable = map[string]string{
+	pondOwned:      "pond_id in (select id from ponds where deleted_at < ?)",
+	equipmentOwned: "equipment_id in (select id from equipments where pond_id in (select id from ponds where deleted_at < ?))",
+}
+
+// pondRecords list soft-deletable records following deletion and restoration of their pond, purged along with it.
+// Farm-wide records, ex: alert rule without pond, are left to the farm
+var pondRecords = []struct {
+	table string
+	owner string
+}{
+	{"water_quality_readings", pondOwned},
+	{"stockings", pondOwned},
+	{"feedings", pondOwned},
+	{"mortalities", pondOwned},
+	{"harvests", pondOwned},
+	{"growth_samples", pondOwned},
+	{"alert_rules", pondOwned},
+	{"alerts", pondOwned},
+	{"devices", pondOwned}, // deleted device is rejected by ingestion
+	{"equipments", pondOwned},
+	{"work_orders", pondOwned},
+	{"work_orders", equipmentOwned}, // work order may target the equipment without its pond
+	{"checklist_templates", pondOwned},
+}
+
+// pondLogs list append-only records of a pond, which have no soft delete thus only removed on purge
+var pondLogs = []struct {
+	table string
+	owner string
+}{
+	{"checklist_tasks", pondOwned},
+	{"equipment_runtimes", equipmentOwned},
+	{"sensor_readings", pondOwned},
+	{"sensor_readings_5m", pondOwned},
+	{"sensor_readings_1h", pondOwned},
+	{"sensor_readings_1d", pondOwned},
+}
+
+var pondColumns = []string{
+	"p.id", "f.id farm_id", "p.name", "f.name farm_name", "p.area", "p.depth", "p.volume", "p.type", "p.aeration_capacity", "p.status", "p.boundary",
+}
+
+// pondFields whitelist fields of pond listing usable by sort, filter and fields
+var pondFields = listquery.Spec{
+	"id":                {Column: "p.id", Select: []string{"p.id"}, Sort: true},
+	"farm_id":           {Select: []string{"f.id farm_id"}},
+	"farm_name":         {Select: []string{"f.name farm_name"}},
+	"name":              {Column: "p.name", Select: []string{"p.name"}, Sort: true, Filter: true, Key: "pond_name"},
+	"area":              {Column: "p.area", Select: []string{"p.area"}, Sort: true},
+	"depth":             {Column: "p.depth", Select: []string{"p.depth"}, Sort: true},
+	"volume":            {Column: "p.volume", Select: []string{"p.volume"}, Sort: true},
+	"type":              {Column: "p.type", Select: []string{"p.type"}, Sort: true, Filter: true},
+	"aeration_capacity": {Column: "p.aeration_capacity", Select: []string{"p.aeration_capacity"}, Sort: true},
+	"status":            {Column: "p.status", Select: []string{"p.status"}, Sort: true, Filter: true},
+	"boundary":          {Select: []string{"p.boundary"}},
+	"created_at":        {Column: "p.created_at", Sort: true, Range: true},
+	"updated_at":        {Column: "p.updated_at", Sort: true, Range: true},
+}
+
+// scope narrow down cond into ponds of the tenant farms, when non-zero. Farm is expected to be joined as f
+func (params *pondQuery) scope(cond squirrel.And) squirrel.And {
+	if params.OrganizationID != 0 {
+		cond = append(cond, squirrel.Eq{"f.organization_id": params.OrganizationID})
+	}
+
+	return cond
+}
+
+// state match active ponds, or soft-deleted ones when asked. Pond is expected to be aliased as p
+func (params *pondQuery) state() squirrel.Sqlizer {
+	if params.Deleted {
+		return squirrel.NotEq{"p.deleted_at": nil}
+	}
+
+	return squirrel.Eq{"p.deleted_at": nil}
+}
+
+// filter return condition shared by listing and counting ponds of a farm
+func (params *pondQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"f.deleted_at": nil},
+		params.state(),
+	}
+
+	if keyword := search.Parse(params.Keyword); keyword != nil {
+		cond = append(cond, keyword.Match("p.search", "p.name"))
+	}
+
+	cond = append(cond, params.List.Where()...)
+
+	return params.scope(cond)
+}
+
+// tenantFarms limit farm_id into farms of the tenant
+func tenantFarms(organizationID int64) squirrel.Sqlizer {
+	return squirrel.Expr("farm_id in (select id from farms where organization_id = ?)", organizationID)
+}
+
 func (repo *pondRepository) GetAll(ctx context.Context, params *pondQuery) (res []*PondFarmType, err error) {
 	logger := zerolog.Ctx(ctx)
 
-	stmt, args, _ := pgSquirrel.Select("p.id", "f.id farm_id", "p.name", "f.name farm_name").From("ponds p").
+	columns := pondColumns
+	if selected := params.List.Columns(); len(selected) > 0 {
+		columns = selected
+	}
+
+	query := pgSquirrel.Select(columns...).From("ponds p").
 		LeftJoin("farms f on p.farm_id = f.id").
-		Where(squirrel.And{
-			squirrel.Eq{"p.farm_id": params.FarmID},
-			squirrel.Eq{"f.deleted_at": nil},
-			squirrel.Eq{"p.deleted_at": nil},
-		}).
-		Limit(params.Limit).
-		Offset((params.Page - 1) * params.Limit).ToSql()
+		Where(params.filter())
+
+	// requested sort takes precedence, otherwise the most relevant pond goes first on keyword search
+	if sort := params.List.Sort(); len(sort) > 0 {
+		query = query.OrderBy(sort...)
+	} else if keyword := search.Parse(params.Keyword); keyword != nil {
+		query = query.OrderByClause(keyword.Rank("p.search", "p.name"))
+	}
+
+	// zero limit means every ponds within the farm
+	if params.Limit > 0 {
+		query = query.Limit(params.Limit).Offset((params.Page - 1) * params.Limit)
+	}
+
+	stmt, args, _ := query.ToSql()
 
 	res = []*PondFarmType{}
 
@@ -76,11 +208,7 @@ func (repo *pondRepository) Count(ctx context.Context, params *pondQuery) (res u
 
 	stmt, args, _ := pgSquirrel.Select("count(*)").From("ponds p").
 		LeftJoin("farms f on p.farm_id = f.id").
-		Where(squirrel.And{
-			squirrel.Eq{"p.farm_id": params.FarmID},
-			squirrel.Eq{"f.deleted_at": nil},
-			squirrel.Eq{"p.deleted_at": nil},
-		}).ToSql()
+		Where(params.filter()).ToSql()
 
 	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
 	if err != nil && err != sql.ErrNoRows {
@@ -96,16 +224,17 @@ func (repo *pondRepository) Count(ctx context.Context, params *pondQuery) (res u
 func (repo *pondRepository) GetOne(ctx context.Context, params *pondQuery) (res *PondFarmType, err error) {
 	logger := zerolog.Ctx(ctx)
 
-	stmt, args, _ := pgSquirrel.Select("p.id", "f.id farm_id", "p.name", "f.name farm_name").From("ponds p").
+	stmt, args, _ := pgSquirrel.Select(pondColumns...).From("ponds p").
 		LeftJoin("farms f on p.farm_id = f.id").
-		Where(squirrel.And{
+		Where(params.scope(squirrel.And{
 			squirrel.Eq{"p.id": params.ID},
 			squirrel.Eq{"p.farm_id": params.FarmID},
 			squirrel.Eq{"f.deleted_at": nil},
 			squirrel.Eq{"p.deleted_at": nil},
-		}).ToSql()
+		})).ToSql()
 
-	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	res = &PondFarmType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
 	if err != nil && err != sql.ErrNoRows {
 		logger.Error().Err(err).Msg("failed to fetch data")
 		return
@@ -130,11 +259,17 @@ func (repo *pondRepository) Store(ctx context.Context, payload *PondType) (err e
 	var args []any
 	var count int64
 
-	// check for farm existence
-	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
+	// check for farm existence, farm of another tenant is treated as non-existing
+	farmCond := squirrel.And{
 		squirrel.Eq{"id": payload.FarmID},
 		squirrel.Eq{"deleted_at": nil},
-	}).ToSql()
+	}
+
+	if payload.OrganizationID != 0 {
+		farmCond = append(farmCond, squirrel.Eq{"organization_id": payload.OrganizationID})
+	}
+
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(farmCond).ToSql()
 
 	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
 		logger.Error().Err(err).Msg("failed to validate farm data existence")
@@ -146,11 +281,17 @@ func (repo *pondRepository) Store(ctx context.Context, payload *PondType) (err e
 		return errs.ErrNotFound
 	}
 
-	// check for duplicated name existence
-	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+	// check for duplicated name existence, within the tenant when known
+	nameCond := squirrel.And{
 		squirrel.Eq{"name": payload.Name},
 		squirrel.Eq{"deleted_at": nil},
-	}).ToSql()
+	}
+
+	if payload.OrganizationID != 0 {
+		nameCond = append(nameCond, tenantFarms(payload.OrganizationID))
+	}
+
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(nameCond).ToSql()
 
 	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
 		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
@@ -162,10 +303,12 @@ func (repo *pondRepository) Store(ctx context.Context, payload *PondType) (err e
 		return errs.ErrDuplicatedResources
 	}
 
-	stmt, args, _ = pgSquirrel.Insert("ponds").Columns("farm_id", "name").Values(payload.FarmID, payload.Name).ToSql()
+	stmt, args, _ = pgSquirrel.Insert("ponds").
+		Columns("farm_id", "name", "area", "depth", "volume", "type", "aeration_capacity", "status", "boundary").
+		Values(payload.FarmID, payload.Name, payload.Area, payload.Depth, payload.Volume, payload.Type, payload.AerationCapacity, payload.Status, payload.Boundary).
+		Suffix("RETURNING id").ToSql()
 
-	_, err = tx.ExecContext(ctx, stmt, args...)
-	if err != nil {
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID); err != nil {
 		logger.Error().Err(err).Msg("failed to save data")
 		return
 	}
@@ -192,11 +335,17 @@ func (repo *pondRepository) Upsert(ctx context.Context, payload *PondType) (err
 	var args []any
 	var count int64
 
-	// check for farm existence
-	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(squirrel.And{
+	// check for farm existence, farm of another tenant is treated as non-existing
+	farmCond := squirrel.And{
 		squirrel.Eq{"id": payload.FarmID},
 		squirrel.Eq{"deleted_at": nil},
-	}).ToSql()
+	}
+
+	if payload.OrganizationID != 0 {
+		farmCond = append(farmCond, squirrel.Eq{"organization_id": payload.OrganizationID})
+	}
+
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("farms").Where(farmCond).ToSql()
 
 	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
 		logger.Error().Err(err).Msg("failed to validate farm data existence")
@@ -208,12 +357,18 @@ func (repo *pondRepository) Upsert(ctx context.Context, payload *PondType) (err
 		return errs.ErrNotFound
 	}
 
-	// check for duplicated name existence
-	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+	// check for duplicated name existence, within the tenant when known
+	nameCond := squirrel.And{
 		squirrel.NotEq{"id": payload.ID},
 		squirrel.Eq{"name": payload.Name},
 		squirrel.Eq{"deleted_at": nil},
-	}).ToSql()
+	}
+
+	if payload.OrganizationID != 0 {
+		nameCond = append(nameCond, tenantFarms(payload.OrganizationID))
+	}
+
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(nameCond).ToSql()
 
 	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
 		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
@@ -225,30 +380,60 @@ func (repo *pondRepository) Upsert(ctx context.Context, payload *PondType) (err
 		return errs.ErrDuplicatedResources
 	}
 
-	// check for ponds existence
-	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+	// check for ponds existence, pond of another tenant is treated as non-existing
+	pondCond := squirrel.And{
 		squirrel.Eq{"id": payload.ID},
 		squirrel.Eq{"deleted_at": nil},
-	}).ToSql()
+	}
 
-	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+	if payload.OrganizationID != 0 {
+		pondCond = append(pondCond, tenantFarms(payload.OrganizationID))
+	}
+
+	stmt, args, _ = pgSquirrel.Select("farm_id").From("ponds").Where(pondCond).ToSql()
+
+	var farmID int64
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&farmID); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
 		logger.Error().Err(err).Msg("failed to validate pond data existence")
 		return
 	}
 
-	switch count {
+	// pond of another farm is never taken over, even within the tenant
+	if farmID != 0 && farmID != payload.FarmID {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("pond doesn't belong to the farm")
+		return
+	}
+
+	switch farmID {
 	case 0:
-		stmt, args, _ = pgSquirrel.Insert("ponds").Columns("farm_id", "name").Values(payload.FarmID, payload.Name).ToSql()
+		stmt, args, _ = pgSquirrel.Insert("ponds").
+			Columns("farm_id", "name", "area", "depth", "volume", "type", "aeration_capacity", "status", "boundary").
+			Values(payload.FarmID, payload.Name, payload.Area, payload.Depth, payload.Volume, payload.Type, payload.AerationCapacity, payload.Status, payload.Boundary).
+			Suffix("RETURNING id").ToSql()
 	default:
 		stmt, args, _ = pgSquirrel.Update("ponds").SetMap(map[string]interface{}{
-			"name":       payload.Name,
-			"updated_at": squirrel.Expr("NOW()"),
+			"name":              payload.Name,
+			"area":              payload.Area,
+			"depth":             payload.Depth,
+			"volume":            payload.Volume,
+			"type":              payload.Type,
+			"aeration_capacity": payload.AerationCapacity,
+			"status":            payload.Status,
+			"boundary":          payload.Boundary,
+			"updated_at":        squirrel.Expr("NOW()"),
 		}).Where(squirrel.And{
 			squirrel.Eq{"id": payload.ID},
+			squirrel.Eq{"farm_id": payload.FarmID},
 		}).ToSql()
 	}
 
-	_, err = tx.ExecContext(ctx, stmt, args...)
+	// inserted pond gets a new ID, keep it for the caller
+	if farmID == 0 {
+		err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID)
+	} else {
+		_, err = tx.ExecContext(ctx, stmt, args...)
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to update data")
 		return
@@ -275,11 +460,18 @@ func (repo *pondRepository) Delete(ctx context.Context, params *pondQuery) (err
 	var stmt string
 	var args []any
 
-	// check for row existence
-	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+	// check for row existence within the farm
+	cond := squirrel.And{
 		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"farm_id": params.FarmID},
 		squirrel.Eq{"deleted_at": nil},
-	}).ToSql()
+	}
+
+	if params.OrganizationID != 0 {
+		cond = append(cond, tenantFarms(params.OrganizationID))
+	}
+
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(cond).ToSql()
 
 	var count int64
 	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows {
@@ -293,10 +485,26 @@ func (repo *pondRepository) Delete(ctx context.Context, params *pondQuery) (err
 		return
 	}
 
+	// NOW() is fixed within a transaction, thus every record shares the pond's deletion timestamp
+	for _, dep := range pondRecords {
+		stmt, args, _ = pgSquirrel.Update(dep.table).SetMap(map[string]interface{}{
+			"updated_at": squirrel.Expr("NOW()"),
+			"deleted_at": squirrel.Expr("NOW()"),
+		}).Where(squirrel.And{
+			squirrel.Expr(dep.owner, params.ID),
+			squirrel.Eq{"deleted_at": nil},
+		}).ToSql()
+
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			logger.Error().Err(err).Str("table", dep.table).Msg("failed to delete data")
+			return
+		}
+	}
+
 	stmt, args, _ = pgSquirrel.Update("ponds").SetMap(map[string]interface{}{
 		"updated_at": squirrel.Expr("NOW()"),
 		"deleted_at": squirrel.Expr("NOW()"),
-	}).Where(squirrel.Eq{"id": params.ID}).ToSql()
+	}).Where(squirrel.Eq{"id": params.ID, "farm_id": params.FarmID}).ToSql()
 
 	_, err = tx.ExecContext(ctx, stmt, args...)
 	if err != nil {
@@ -311,3 +519,132 @@ func (repo *pondRepository) Delete(ctx context.Context, params *pondQuery) (err
 
 	return
 }
+
+// Restore bring back a soft-deleted pond of an active farm along with records deleted together with it, records deleted
+// on their own stay deleted. Restore is rejected when an active pond of the tenant already took its name
+func (repo *pondRepository) Restore(ctx context.Context, params *pondQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var stmt string
+	var args []any
+
+	// check for deleted row existence
+	stmt, args, _ = pgSquirrel.Select("p.name", "f.organization_id", "p.deleted_at").From("ponds p").
+		Join("farms f on p.farm_id = f.id").
+		Where(params.scope(squirrel.And{
+			squirrel.Eq{"p.id": params.ID},
+			squirrel.Eq{"p.farm_id": params.FarmID},
+			squirrel.Eq{"f.deleted_at": nil},
+			squirrel.NotEq{"p.deleted_at": nil},
+		})).ToSql()
+
+	var name string
+	var organizationID int64
+	var deletedAt time.Time
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&name, &organizationID, &deletedAt); err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return errs.ErrNotFound
+	}
+
+	// check for duplicated name existence within the tenant
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+		squirrel.Eq{"name": name},
+		squirrel.Eq{"deleted_at": nil},
+		tenantFarms(organizationID),
+	}).ToSql()
+
+	var count int64
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
+		return
+	}
+
+	if count != 0 {
+		return errs.ErrDuplicatedResources
+	}
+
+	for _, dep := range pondRecords {
+		stmt, args, _ = pgSquirrel.Update(dep.table).SetMap(map[string]interface{}{
+			"updated_at": squirrel.Expr("NOW()"),
+			"deleted_at": nil,
+		}).Where(squirrel.And{
+			squirrel.Expr(dep.owner, params.ID),
+			squirrel.Eq{"deleted_at": deletedAt},
+		}).ToSql()
+
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			// ex: sensor already registered into another pond
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				err = errs.ErrDuplicatedResources
+			}
+
+			logger.Error().Err(err).Str("table", dep.table).Msg("failed to update data")
+			return
+		}
+	}
+
+	stmt, args, _ = pgSquirrel.Update("ponds").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": nil,
+	}).Where(squirrel.Eq{"id": params.ID}).ToSql()
+
+	if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+// Purge permanently remove ponds deleted before the cutoff, return number of purged ponds
+func (repo *pondRepository) Purge(ctx context.Context, cutoff time.Time) (res int64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	// records are matched through ponds and equipments, thus children goes first
+	deps := slices.Concat(pondRecords, pondLogs)
+	for i := len(deps) - 1; i >= 0; i-- {
+		stmt, args, _ := pgSquirrel.Delete(deps[i].table).Where(purgeable[deps[i].owner], cutoff).ToSql()
+
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			logger.Error().Err(err).Str("table", deps[i].table).Msg("failed to delete data")
+			return
+		}
+	}
+
+	stmt, args, _ := pgSquirrel.Delete("ponds").Where(squirrel.Lt{"deleted_at": cutoff}).ToSql()
+
+	result, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return result.RowsAffected()
+}
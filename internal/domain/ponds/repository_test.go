@@ -2,12 +2,15 @@ package ponds
 
 import (
 	"context"
+	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/listquery"
 )
 
 func TestShouldGetPondWithResult(t *testing.T) {
@@ -24,7 +27,7 @@ func TestShouldGetPondWithResult(t *testing.T) {
 		AddRow(1, 1, "Pond A", "Farm A").
 		AddRow(2, 1, "Pond B", "Farm A")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, f.id farm_id, p.name, f.name farm_name FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.farm_id = $1 AND f.deleted_at IS NULL AND p.deleted_at IS NULL) LIMIT 100 OFFSET 0")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, f.id farm_id, p.name, f.name farm_name, p.area, p.depth, p.volume, p.type, p.aeration_capacity, p.status, p.boundary FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.farm_id = $1 AND f.deleted_at IS NULL AND p.deleted_at IS NULL) LIMIT 100 OFFSET 0")).
 		WithArgs(1).
 		WillReturnRows(rows)
 
@@ -35,6 +38,92 @@ func TestShouldGetPondWithResult(t *testing.T) {
 	}
 }
 
+func TestShouldSearchPondOrderedByRelevance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "farm_id", "name", "farm_name"})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, f.id farm_id, p.name, f.name farm_name, p.area, p.depth, p.volume, p.type, p.aeration_capacity, p.status, p.boundary FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.farm_id = $1 AND f.deleted_at IS NULL AND p.deleted_at IS NULL AND (p.search @@ to_tsquery('simple', $2) OR p.name % $3)) ORDER BY ts_rank(p.search, to_tsquery('simple', $4)) + similarity(p.name, $5) DESC LIMIT 100 OFFSET 0")).
+		WithArgs(1, "kolam:* & ud:*", "Kolam Ud", "kolam:* & ud:*", "Kolam Ud").
+		WillReturnRows(rows)
+
+	pondRepo.GetAll(context.Background(), &pondQuery{FarmID: 1, Keyword: " Kolam Ud", Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldGetPondSortedAndFilteredByListQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	values, _ := url.ParseQuery("sort=-created_at,name&filter[status]=stocked&filter[created_at][gte]=2024-08-01&fields=name,status")
+	list, err := listquery.Parse(values, pondFields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// expected queries, only selected columns along with id are fetched and requested sort takes precedence over relevance
+	rows := sqlmock.NewRows([]string{"id", "name", "status"}).AddRow(1, "Kolam A", StatusStocked)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, p.name, p.status FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.farm_id = $1 AND f.deleted_at IS NULL AND p.deleted_at IS NULL AND (p.search @@ to_tsquery('simple', $2) OR p.name % $3) AND p.created_at >= $4 AND p.status = $5) ORDER BY p.created_at DESC, p.name ASC LIMIT 100 OFFSET 0")).
+		WithArgs(1, "kolam:*", "Kolam", time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC), "stocked").
+		WillReturnRows(rows)
+
+	res, err := pondRepo.GetAll(context.Background(), &pondQuery{FarmID: 1, Keyword: "Kolam", List: list, Limit: 100, Page: 1})
+	if err != nil || len(res) != 1 || res[0].Name != "Kolam A" || res[0].Status != StatusStocked {
+		t.Errorf("unexpected ponds: %+v, err: %v", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldCountPondFilteredByListQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	values, _ := url.ParseQuery("filter[type]=earthen,ras&sort=name")
+	list, err := listquery.Parse(values, pondFields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(2)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.farm_id = $1 AND f.deleted_at IS NULL AND p.deleted_at IS NULL AND p.type IN ($2,$3))")).
+		WithArgs(1, "earthen", "ras").
+		WillReturnRows(rows)
+
+	if count, err := pondRepo.Count(context.Background(), &pondQuery{FarmID: 1, List: list}); err != nil || count != 2 {
+		t.Errorf("unexpected count: %d, err: %v", count, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
 func TestShouldCountPondAboveZero(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -93,14 +182,17 @@ func TestShouldGetPond(t *testing.T) {
 	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	// expected queries
-	rows := sqlmock.NewRows([]string{"p.id", "farm_id", "p.name", "farm_name"}).
-		AddRow(1, 1, "Pond A", "Farm A")
+	rows := sqlmock.NewRows([]string{"id", "farm_id", "name", "farm_name", "area", "depth", "volume", "type", "aeration_capacity", "status", "boundary"}).
+		AddRow(1, 1, "Pond A", "Farm A", 1000, 1.2, 1200, TypeEarthen, 4, StatusIdle, []byte(`{"type":"Polygon","coordinates":[[[115.22,-8.65],[115.2203,-8.65],[115.2203,-8.6503],[115.22,-8.65]]]}`))
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, f.id farm_id, p.name, f.name farm_name FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.id = $1 AND p.farm_id = $2 AND f.deleted_at IS NULL AND p.deleted_at IS NULL)")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, f.id farm_id, p.name, f.name farm_name, p.area, p.depth, p.volume, p.type, p.aeration_capacity, p.status, p.boundary FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.id = $1 AND p.farm_id = $2 AND f.deleted_at IS NULL AND p.deleted_at IS NULL)")).
 		WithArgs(1, 1).
 		WillReturnRows(rows)
 
-	pondRepo.GetOne(context.Background(), &pondQuery{ID: 1, FarmID: 1})
+	res, err := pondRepo.GetOne(context.Background(), &pondQuery{ID: 1, FarmID: 1})
+	if err != nil || res == nil || res.Name != "Pond A" || res.Boundary == nil || len(res.Boundary.Coordinates[0]) != 4 {
+		t.Errorf("unexpected pond: %+v, err: %s", res, err)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -119,7 +211,7 @@ func TestShouldNOTGetPond(t *testing.T) {
 	// expected queries
 	rows := sqlmock.NewRows([]string{"p.id", "farm_id", "p.name", "farm_name"})
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, f.id farm_id, p.name, f.name farm_name FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.id = $1 AND p.farm_id = $2 AND f.deleted_at IS NULL AND p.deleted_at IS NULL)")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, f.id farm_id, p.name, f.name farm_name, p.area, p.depth, p.volume, p.type, p.aeration_capacity, p.status, p.boundary FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.id = $1 AND p.farm_id = $2 AND f.deleted_at IS NULL AND p.deleted_at IS NULL)")).
 		WithArgs(1, 2).
 		WillReturnRows(rows)
 
@@ -130,6 +222,57 @@ func TestShouldNOTGetPond(t *testing.T) {
 	}
 }
 
+func TestShouldNOTGetPondOfAnotherTenant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "farm_id", "name", "farm_name", "area", "depth", "volume", "type", "aeration_capacity", "status", "boundary"})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, f.id farm_id, p.name, f.name farm_name, p.area, p.depth, p.volume, p.type, p.aeration_capacity, p.status, p.boundary FROM ponds p LEFT JOIN farms f on p.farm_id = f.id WHERE (p.id = $1 AND p.farm_id = $2 AND f.deleted_at IS NULL AND p.deleted_at IS NULL AND f.organization_id = $3)")).
+		WithArgs(1, 1, 2).
+		WillReturnRows(rows)
+
+	res, err := pondRepo.GetOne(context.Background(), &pondQuery{ID: 1, FarmID: 1, OrganizationID: 2})
+	if err != nil || res != nil {
+		t.Errorf("expected no pond, got: %+v, err: %v", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTDeletePondOfAnotherTenant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL AND farm_id in (select id from farms where organization_id = $3))")).
+		WithArgs(1, 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectRollback()
+
+	if err := pondRepo.Delete(context.Background(), &pondQuery{ID: 1, FarmID: 1, OrganizationID: 2}); err != errs.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
 func TestShouldStorePond(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -144,11 +287,12 @@ func TestShouldStorePond(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (name = $1 AND deleted_at IS NULL)")).WithArgs("Pond A").
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO ponds (farm_id,name) VALUES ($1,$2)")).WithArgs(1, "Pond A").
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO ponds (farm_id,name,area,depth,volume,type,aeration_capacity,status,boundary) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id")).
+		WithArgs(1, "Pond A", 1000.0, 1.2, 1200.0, TypeEarthen, 4.0, StatusIdle, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectCommit()
 
-	pondRepo.Store(context.Background(), &PondType{FarmID: 1, Name: "Pond A"})
+	pondRepo.Store(context.Background(), &PondType{FarmID: 1, Name: "Pond A", Area: 1000, Depth: 1.2, Volume: 1200, Type: TypeEarthen, AerationCapacity: 4, Status: StatusIdle})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -213,13 +357,14 @@ func TestShouldInsertOnUpdatePond(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id <> $1 AND name = $2 AND deleted_at IS NULL)")).WithArgs(1, "Pond A").
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO ponds (farm_id,name) VALUES ($1,$2)")).WithArgs(1, "Pond A").
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT farm_id FROM ponds WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO ponds (farm_id,name,area,depth,volume,type,aeration_capacity,status,boundary) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id")).
+		WithArgs(1, "Pond A", 1000.0, 1.2, 1200.0, TypeEarthen, 4.0, StatusIdle, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectCommit()
 
-	pondRepo.Upsert(context.Background(), &PondType{ID: 1, FarmID: 1, Name: "Pond A"})
+	pondRepo.Upsert(context.Background(), &PondType{ID: 1, FarmID: 1, Name: "Pond A", Area: 1000, Depth: 1.2, Volume: 1200, Type: TypeEarthen, AerationCapacity: 4, Status: StatusIdle})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -284,13 +429,43 @@ func TestShouldUpdatePond(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id <> $1 AND name = $2 AND deleted_at IS NULL)")).WithArgs(1, "Pond A").
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE ponds SET name = $1, updated_at = NOW() WHERE (id = $2)")).WithArgs("Pond A", 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT farm_id FROM ponds WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE ponds SET aeration_capacity = $1, area = $2, boundary = $3, depth = $4, name = $5, status = $6, type = $7, updated_at = NOW(), volume = $8 WHERE (id = $9 AND farm_id = $10)")).
+		WithArgs(4.0, 1000.0, nil, 1.2, "Pond A", StatusStocked, TypeEarthen, 1200.0, 1, 1).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	pondRepo.Upsert(context.Background(), &PondType{ID: 1, FarmID: 1, Name: "Pond A"})
+	pondRepo.Upsert(context.Background(), &PondType{ID: 1, FarmID: 1, Name: "Pond A", Area: 1000, Depth: 1.2, Volume: 1200, Type: TypeEarthen, AerationCapacity: 4, Status: StatusStocked})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTUpdatePondOfAnotherFarm(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// pond 3 belongs to farm 2 of the same tenant, thus neither updated nor re-created through farm 1
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM farms WHERE (id = $1 AND deleted_at IS NULL AND organization_id = $2)")).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id <> $1 AND name = $2 AND deleted_at IS NULL AND farm_id in (select id from farms where organization_id = $3))")).WithArgs(3, "Pond A", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT farm_id FROM ponds WHERE (id = $1 AND deleted_at IS NULL AND farm_id in (select id from farms where organization_id = $2))")).WithArgs(3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id"}).AddRow(2))
+	mock.ExpectRollback()
+
+	err = pondRepo.Upsert(context.Background(), &PondType{ID: 3, FarmID: 1, Name: "Pond A", Status: StatusIdle, OrganizationID: 1})
+	if err != errs.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -307,13 +482,21 @@ func TestShouldDeletePond(t *testing.T) {
 	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).WithArgs(1, 1).
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE ponds SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1")).WithArgs(1).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE water_quality_readings SET deleted_at = NOW(), updated_at = NOW() WHERE (pond_id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	for _, dep := range pondRecords[1:] {
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE " + dep.table + " SET deleted_at = NOW(), updated_at = NOW() WHERE")).WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE ponds SET deleted_at = NOW(), updated_at = NOW() WHERE farm_id = $1 AND id = $2")).WithArgs(1, 1).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	pondRepo.Delete(context.Background(), &pondQuery{ID: 1})
+	if err := pondRepo.Delete(context.Background(), &pondQuery{ID: 1, FarmID: 1}); err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
@@ -330,11 +513,167 @@ func TestShouldNOTDeletePondDuePondNotExisted(t *testing.T) {
 	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND deleted_at IS NULL)")).WithArgs(1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).WithArgs(1, 1).
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0)).WillReturnError(errs.ErrNotFound)
 	mock.ExpectRollback()
 
-	pondRepo.Delete(context.Background(), &pondQuery{ID: 1})
+	pondRepo.Delete(context.Background(), &pondQuery{ID: 1, FarmID: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTDeletePondOfAnotherFarm(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// pond 3 belongs to farm 2 of the same tenant, thus not found through farm 1
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL AND farm_id in (select id from farms where organization_id = $3))")).
+		WithArgs(3, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectRollback()
+
+	if err := pondRepo.Delete(context.Background(), &pondQuery{ID: 3, FarmID: 1, OrganizationID: 1}); err != errs.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldRestorePondAlongWithItsRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	deletedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.name, f.organization_id, p.deleted_at FROM ponds p JOIN farms f on p.farm_id = f.id WHERE (p.id = $1 AND p.farm_id = $2 AND f.deleted_at IS NULL AND p.deleted_at IS NOT NULL)`)).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "organization_id", "deleted_at"}).AddRow("Pond A", 1, deletedAt))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM ponds WHERE (name = $1 AND deleted_at IS NULL AND farm_id in (select id from farms where organization_id = $2))`)).
+		WithArgs("Pond A", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+
+	// only records deleted along with the pond come back
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE water_quality_readings SET deleted_at = $1, updated_at = NOW() WHERE (pond_id = $2 AND deleted_at = $3)")).WithArgs(nil, 2, deletedAt).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	for _, dep := range pondRecords[1:] {
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE "+dep.table+" SET deleted_at = $1, updated_at = NOW() WHERE")).WithArgs(nil, 2, deletedAt).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE ponds SET deleted_at = $1, updated_at = NOW() WHERE id = $2")).WithArgs(nil, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err = pondRepo.Restore(context.Background(), &pondQuery{ID: 2, FarmID: 1}); err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTRestorePondDueNameTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.name, f.organization_id, p.deleted_at FROM ponds p JOIN farms f on p.farm_id = f.id WHERE (p.id = $1 AND p.farm_id = $2 AND f.deleted_at IS NULL AND p.deleted_at IS NOT NULL AND f.organization_id = $3)`)).
+		WithArgs(2, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "organization_id", "deleted_at"}).AddRow("Pond A", 1, time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM ponds WHERE (name = $1 AND deleted_at IS NULL AND farm_id in (select id from farms where organization_id = $2))`)).
+		WithArgs("Pond A", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectRollback()
+
+	if err = pondRepo.Restore(context.Background(), &pondQuery{ID: 2, FarmID: 1, OrganizationID: 1}); err != errs.ErrDuplicatedResources {
+		t.Errorf("expected ErrDuplicatedResources, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTRestorePondNotDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.name, f.organization_id, p.deleted_at FROM ponds p JOIN farms f on p.farm_id = f.id WHERE (p.id = $1 AND p.farm_id = $2 AND f.deleted_at IS NULL AND p.deleted_at IS NOT NULL)`)).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "organization_id", "deleted_at"}))
+	mock.ExpectRollback()
+
+	if err = pondRepo.Restore(context.Background(), &pondQuery{ID: 2, FarmID: 1}); err != errs.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldPurgePondAlongWithItsRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	pondRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	cutoff := time.Now()
+
+	// every table referring the purged ponds, directly or through their equipments, is cleared before the ponds
+	mock.ExpectBegin()
+	for _, table := range []string{"sensor_readings_1d", "sensor_readings_1h", "sensor_readings_5m", "sensor_readings"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE pond_id in (select id from ponds where deleted_at < $1)")).WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM equipment_runtimes WHERE equipment_id in (select id from equipments where pond_id in (select id from ponds where deleted_at < $1))")).WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	for _, table := range []string{"checklist_tasks", "checklist_templates"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE pond_id in (select id from ponds where deleted_at < $1)")).WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM work_orders WHERE equipment_id in (select id from equipments where pond_id in (select id from ponds where deleted_at < $1))")).WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	for _, table := range []string{"work_orders", "equipments", "devices", "alerts", "alert_rules", "growth_samples", "harvests", "mortalities", "feedings", "stockings", "water_quality_readings"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE pond_id in (select id from ponds where deleted_at < $1)")).WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM ponds WHERE deleted_at < $1")).WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	count, err := pondRepo.Purge(context.Background(), cutoff)
+	if err != nil || count != 2 {
+		t.Errorf("unexpected purge result: %d, err: %v", count, err)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("%s", err)
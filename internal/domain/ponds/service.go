@@ -2,9 +2,15 @@ package ponds
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"github.com/nmluci/da-farm-be/internal/core/auth"
 	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/geo"
 	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/nmluci/da-farm-be/internal/domain/audit"
+	"github.com/nmluci/da-farm-be/internal/domain/growth"
 	"github.com/rs/zerolog"
 )
 
@@ -12,28 +18,37 @@ import (
 type PondService interface {
 	GetAll(context.Context, *PondRequestQuery) (*ListPondResponse, error)
 	GetOne(context.Context, *PondRequestQuery) (*PondResponse, error)
+	GetGeoJSON(context.Context, *PondRequestQuery) (*geo.FeatureCollection, error)
 	Create(context.Context, *PondPayload) error
 	Update(context.Context, *PondPayload) error
 	Delete(context.Context, *PondRequestQuery) error
+	SetMaintenance(context.Context, *PondMaintenancePayload) error
+	Restore(context.Context, *PondRequestQuery) error
+	Purge(context.Context, *PurgePayload) error
 }
 
 type pondService struct {
-	repo PondRepository
+	repo      PondRepository
+	growthSvc growth.GrowthService
+	auditSvc  audit.AuditService
 }
 
 // NewService return an instance of PondService containing available usecases
-func NewService(repo PondRepository) PondService {
-	return &pondService{repo: repo}
+func NewService(repo PondRepository, growthSvc growth.GrowthService, auditSvc audit.AuditService) PondService {
+	return &pondService{repo: repo, growthSvc: growthSvc, auditSvc: auditSvc}
 }
 
 func (svc *pondService) GetAll(ctx context.Context, params *PondRequestQuery) (res *ListPondResponse, err error) {
 	logger := zerolog.Ctx(ctx)
 
 	repoParams := &pondQuery{
-		FarmID:  params.FarmID,
-		Keyword: params.Keyword,
-		Limit:   params.Limit,
-		Page:    params.Page,
+		FarmID:         params.FarmID,
+		Keyword:        params.Keyword,
+		OrganizationID: auth.OrganizationFromContext(ctx),
+		Deleted:        params.Deleted,
+		List:           params.List,
+		Limit:          params.Limit,
+		Page:           params.Page,
 	}
 
 	if params.Limit >= 100 || params.Limit <= 0 {
@@ -48,7 +63,7 @@ func (svc *pondService) GetAll(ctx context.Context, params *PondRequestQuery) (r
 		Ponds: []*PondResponse{},
 		Meta: httpres.ListPagination{
 			Limit:     repoParams.Limit,
-			Page:      0,
+			Page:      repoParams.Page,
 			TotalPage: 0,
 		},
 	}
@@ -62,7 +77,7 @@ func (svc *pondService) GetAll(ctx context.Context, params *PondRequestQuery) (r
 	if count == 0 {
 		return nil, errs.ErrNotFound
 	}
-	res.Meta.TotalPage = count / repoParams.Limit
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
 
 	ponds, err := svc.repo.GetAll(ctx, repoParams)
 	if err != nil {
@@ -71,12 +86,10 @@ func (svc *pondService) GetAll(ctx context.Context, params *PondRequestQuery) (r
 	}
 
 	for _, pond := range ponds {
-		res.Ponds = append(res.Ponds, &PondResponse{
-			ID:       pond.ID,
-			FarmID:   pond.FarmID,
-			FarmName: pond.FarmName,
-			Name:     pond.Name,
-		})
+		pondRes := toPondResponse(pond)
+		pondRes.list = params.List
+
+		res.Ponds = append(res.Ponds, pondRes)
 	}
 
 	return
@@ -85,7 +98,7 @@ func (svc *pondService) GetAll(ctx context.Context, params *PondRequestQuery) (r
 func (svc *pondService) GetOne(ctx context.Context, params *PondRequestQuery) (res *PondResponse, err error) {
 	logger := zerolog.Ctx(ctx)
 
-	repoParams := &pondQuery{ID: params.ID}
+	repoParams := &pondQuery{ID: params.ID, FarmID: params.FarmID, OrganizationID: auth.OrganizationFromContext(ctx)}
 
 	pond, err := svc.repo.GetOne(ctx, repoParams)
 	if err != nil {
@@ -97,11 +110,57 @@ func (svc *pondService) GetOne(ctx context.Context, params *PondRequestQuery) (r
 		return nil, errs.ErrNotFound
 	}
 
-	res = &PondResponse{
-		ID:       pond.ID,
-		FarmID:   pond.FarmID,
-		FarmName: pond.FarmName,
-		Name:     pond.Name,
+	res = toPondResponse(pond)
+
+	if params.Expand == ExpandGrowth {
+		res.Growth, err = svc.growthSvc.GetEstimate(ctx, &growth.EstimateRequestQuery{
+			FarmID: pond.FarmID,
+			PondID: pond.ID,
+		})
+
+		// pond without sampled active batch simply has no estimate
+		if err == errs.ErrNotFound {
+			err = nil
+		} else if err != nil {
+			logger.Error().Err(err).Send()
+			return nil, err
+		}
+	}
+
+	return
+}
+
+func (svc *pondService) GetGeoJSON(ctx context.Context, params *PondRequestQuery) (res *geo.FeatureCollection, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	// map overlay needs the whole farm at once, thus no paging
+	ponds, err := svc.repo.GetAll(ctx, &pondQuery{FarmID: params.FarmID, OrganizationID: auth.OrganizationFromContext(ctx)})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if len(ponds) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	res = geo.NewFeatureCollection()
+	for _, pond := range ponds {
+		res.Features = append(res.Features, &geo.Feature{
+			Type:     geo.TypeFeature,
+			ID:       pond.ID,
+			Geometry: pond.Boundary,
+			Properties: &PondProperties{
+				Name:             pond.Name,
+				FarmID:           pond.FarmID,
+				Area:             pond.Area,
+				Depth:            pond.Depth,
+				Volume:           pond.Volume,
+				Type:             pond.Type,
+				AerationCapacity: pond.AerationCapacity,
+				Status:           pond.Status,
+			},
+		})
 	}
 
 	return
@@ -110,9 +169,15 @@ func (svc *pondService) GetOne(ctx context.Context, params *PondRequestQuery) (r
 func (svc *pondService) Create(ctx context.Context, payload *PondPayload) (err error) {
 	logger := zerolog.Ctx(ctx)
 
-	data := &PondType{
-		FarmID: payload.FarmID,
-		Name:   payload.Name,
+	data, err := toPondType(payload)
+	if err != nil {
+		return
+	}
+	data.OrganizationID = auth.OrganizationFromContext(ctx)
+
+	// newly created pond starts idle unless told otherwise
+	if data.Status == "" {
+		data.Status = StatusIdle
 	}
 
 	err = svc.repo.Store(ctx, data)
@@ -121,16 +186,36 @@ func (svc *pondService) Create(ctx context.Context, payload *PondPayload) (err e
 		return
 	}
 
+	svc.record(ctx, audit.ActionCreate, nil, &PondFarmType{PondType: *data})
+
 	return
 }
 
 func (svc *pondService) Update(ctx context.Context, payload *PondPayload) (err error) {
 	logger := zerolog.Ctx(ctx)
 
-	data := &PondType{
-		ID:     payload.ID,
-		FarmID: payload.FarmID,
-		Name:   payload.Name,
+	data, err := toPondType(payload)
+	if err != nil {
+		return
+	}
+	data.OrganizationID = auth.OrganizationFromContext(ctx)
+
+	pond, err := svc.repo.GetOne(ctx, &pondQuery{ID: payload.ID, FarmID: payload.FarmID, OrganizationID: data.OrganizationID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	switch {
+	case pond == nil && data.Status == "":
+		// pond will be inserted, thus starts idle
+		data.Status = StatusIdle
+	case pond != nil && data.Status == "":
+		data.Status = pond.Status
+	case pond != nil && data.Status != pond.Status:
+		if !slices.Contains(statusTransitions[pond.Status], data.Status) {
+			return errs.ErrInvalidStateTransition
+		}
 	}
 
 	err = svc.repo.Upsert(ctx, data)
@@ -139,6 +224,13 @@ func (svc *pondService) Update(ctx context.Context, payload *PondPayload) (err e
 		return
 	}
 
+	// upsert on non-existing pond creates a new one
+	if pond == nil {
+		svc.record(ctx, audit.ActionCreate, nil, &PondFarmType{PondType: *data})
+	} else {
+		svc.record(ctx, audit.ActionUpdate, pond, &PondFarmType{PondType: *data, FarmName: pond.FarmName})
+	}
+
 	return
 }
 
@@ -146,7 +238,15 @@ func (svc *pondService) Delete(ctx context.Context, params *PondRequestQuery) (e
 	logger := zerolog.Ctx(ctx)
 
 	repoParams := &pondQuery{
-		ID: params.ID,
+		ID:             params.ID,
+		FarmID:         params.FarmID,
+		OrganizationID: auth.OrganizationFromContext(ctx),
+	}
+
+	pond, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
 	}
 
 	err = svc.repo.Delete(ctx, repoParams)
@@ -155,5 +255,189 @@ func (svc *pondService) Delete(ctx context.Context, params *PondRequestQuery) (e
 		return
 	}
 
+	if pond != nil {
+		svc.record(ctx, audit.ActionDelete, pond, nil)
+	}
+
+	return
+}
+
+// SetMaintenance move pond into maintenance while it's blocked, and back into idle once it's no longer blocked.
+// Pond already in the desired state is left as is
+func (svc *pondService) SetMaintenance(ctx context.Context, payload *PondMaintenancePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	organizationID := auth.OrganizationFromContext(ctx)
+
+	pond, err := svc.repo.GetOne(ctx, &pondQuery{ID: payload.ID, FarmID: payload.FarmID, OrganizationID: organizationID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if pond == nil {
+		return errs.ErrNotFound
+	}
+
+	before := *pond
+
+	switch {
+	case payload.Blocked && pond.Status != StatusMaintenance:
+		if !slices.Contains(statusTransitions[pond.Status], StatusMaintenance) {
+			return errs.ErrInvalidStateTransition
+		}
+		pond.Status = StatusMaintenance
+	case !payload.Blocked && pond.Status == StatusMaintenance:
+		pond.Status = StatusIdle
+	default:
+		return
+	}
+	pond.OrganizationID = organizationID
+
+	err = svc.repo.Upsert(ctx, &pond.PondType)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	svc.record(ctx, audit.ActionUpdate, &before, pond)
+
 	return
 }
+
+// Restore bring back a soft-deleted pond as long as its name isn't taken by an active pond
+func (svc *pondService) Restore(ctx context.Context, params *PondRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &pondQuery{
+		ID:             params.ID,
+		FarmID:         params.FarmID,
+		OrganizationID: auth.OrganizationFromContext(ctx),
+	}
+
+	err = svc.repo.Restore(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	pond, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if pond != nil {
+		svc.record(ctx, audit.ActionRestore, nil, pond)
+	}
+
+	return
+}
+
+// Purge permanently remove ponds which stayed deleted longer than the retention, meant to be run by scheduler
+func (svc *pondService) Purge(ctx context.Context, payload *PurgePayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.Retention <= 0 {
+		return errs.ErrBadRequest
+	}
+
+	count, err := svc.repo.Purge(ctx, time.Now().Add(-payload.Retention))
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count != 0 {
+		logger.Info().Int64("count", count).Msg("purged deleted ponds")
+	}
+
+	return
+}
+
+// record keep the mutation in audit trail, the mutation is already committed so failure is only logged
+func (svc *pondService) record(ctx context.Context, action string, before, after *PondFarmType) {
+	payload := &audit.RecordPayload{
+		Entity: audit.EntityPond,
+		Action: action,
+	}
+
+	if before != nil {
+		payload.FarmID, payload.EntityID = before.FarmID, before.ID
+		payload.Before = toPondResponse(before)
+	}
+
+	if after != nil {
+		payload.FarmID, payload.EntityID = after.FarmID, after.ID
+		payload.After = toPondResponse(after)
+	}
+
+	if err := svc.auditSvc.Record(ctx, payload); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("failed to record audit")
+	}
+}
+
+func toPondType(payload *PondPayload) (res *PondType, err error) {
+	if payload.Area < 0 || payload.Depth < 0 || payload.Volume < 0 || payload.AerationCapacity < 0 {
+		return nil, errs.ErrBadRequest
+	}
+
+	if payload.Type != "" && !slices.Contains(pondTypes, payload.Type) {
+		return nil, errs.ErrBadRequest
+	}
+
+	if _, ok := statusTransitions[payload.Status]; payload.Status != "" && !ok {
+		return nil, errs.ErrBadRequest
+	}
+
+	if payload.Boundary != nil {
+		if err = payload.Boundary.Validate(); err != nil {
+			return
+		}
+	}
+
+	res = &PondType{
+		ID:               payload.ID,
+		FarmID:           payload.FarmID,
+		Name:             payload.Name,
+		Area:             payload.Area,
+		Depth:            payload.Depth,
+		Volume:           payload.Volume,
+		Type:             payload.Type,
+		AerationCapacity: payload.AerationCapacity,
+		Status:           payload.Status,
+		Boundary:         payload.Boundary,
+	}
+
+	if res.Type == "" {
+		res.Type = TypeEarthen
+	}
+
+	// surveyed area takes precedence, otherwise derive it from the boundary
+	if res.Area == 0 && res.Boundary != nil {
+		res.Area = res.Boundary.Area()
+	}
+
+	// volume can be derived from surface area and depth when not measured separately
+	if res.Volume == 0 {
+		res.Volume = res.Area * res.Depth
+	}
+
+	return
+}
+
+func toPondResponse(pond *PondFarmType) *PondResponse {
+	return &PondResponse{
+		ID:               pond.ID,
+		FarmID:           pond.FarmID,
+		FarmName:         pond.FarmName,
+		Name:             pond.Name,
+		Area:             pond.Area,
+		Depth:            pond.Depth,
+		Volume:           pond.Volume,
+		Type:             pond.Type,
+		AerationCapacity: pond.AerationCapacity,
+		Status:           pond.Status,
+		Boundary:         pond.Boundary,
+	}
+}
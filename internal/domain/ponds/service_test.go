@@ -0,0 +1,221 @@
+package ponds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/geo"
+	"github.com/nmluci/da-farm-be/internal/domain/audit"
+)
+
+type stubPondRepository struct {
+	PondRepository
+	pond     *PondFarmType
+	ponds    []*PondFarmType
+	upserted *PondType
+	deleted  *pondQuery
+}
+
+func (repo *stubPondRepository) GetAll(context.Context, *pondQuery) ([]*PondFarmType, error) {
+	return repo.ponds, nil
+}
+
+func (repo *stubPondRepository) Count(context.Context, *pondQuery) (uint64, error) {
+	return uint64(len(repo.ponds)), nil
+}
+
+// GetOne behave like the repository, pond of another farm isn't found
+func (repo *stubPondRepository) GetOne(_ context.Context, params *pondQuery) (*PondFarmType, error) {
+	if repo.pond == nil || repo.pond.FarmID != params.FarmID {
+		return nil, nil
+	}
+
+	return repo.pond, nil
+}
+
+func (repo *stubPondRepository) Upsert(_ context.Context, payload *PondType) error {
+	repo.upserted = payload
+	return nil
+}
+
+func (repo *stubPondRepository) Delete(_ context.Context, params *pondQuery) error {
+	repo.deleted = params
+	return nil
+}
+
+type stubAuditService struct {
+	audit.AuditService
+	recorded []*audit.RecordPayload
+}
+
+func (svc *stubAuditService) Record(_ context.Context, payload *audit.RecordPayload) error {
+	svc.recorded = append(svc.recorded, payload)
+	return nil
+}
+
+func TestShouldUpdatePondStatusOnValidTransition(t *testing.T) {
+	repo := &stubPondRepository{pond: &PondFarmType{PondType: PondType{ID: 1, FarmID: 1, Status: StatusPreparing}}}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	err := svc.Update(context.Background(), &PondPayload{ID: 1, FarmID: 1, Name: "Pond A", Area: 1000, Depth: 1.2, Status: StatusStocked})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.upserted.Status != StatusStocked || repo.upserted.Volume != 1200 || repo.upserted.Type != TypeEarthen {
+		t.Errorf("unexpected upserted pond: %+v", repo.upserted)
+	}
+}
+
+func TestShouldKeepPondStatusWhenOmitted(t *testing.T) {
+	repo := &stubPondRepository{pond: &PondFarmType{PondType: PondType{ID: 1, FarmID: 1, Status: StatusHarvesting}}}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	if err := svc.Update(context.Background(), &PondPayload{ID: 1, FarmID: 1, Name: "Pond A"}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.upserted.Status != StatusHarvesting {
+		t.Errorf("expected status to be kept, got: %s", repo.upserted.Status)
+	}
+}
+
+func TestShouldNOTUpdatePondStatusOnInvalidTransition(t *testing.T) {
+	repo := &stubPondRepository{pond: &PondFarmType{PondType: PondType{ID: 1, FarmID: 1, Status: StatusIdle}}}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	err := svc.Update(context.Background(), &PondPayload{ID: 1, FarmID: 1, Name: "Pond A", Status: StatusHarvesting})
+	if err != errs.ErrInvalidStateTransition {
+		t.Errorf("expected invalid state transition, got: %v", err)
+	}
+}
+
+func TestShouldNOTUpdatePondWithUnknownType(t *testing.T) {
+	svc := NewService(&stubPondRepository{}, nil, &stubAuditService{})
+
+	if err := svc.Update(context.Background(), &PondPayload{ID: 1, FarmID: 1, Name: "Pond A", Type: "glass"}); err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+}
+
+func TestShouldDerivePondAreaFromBoundary(t *testing.T) {
+	repo := &stubPondRepository{}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	// roughly 100m x 100m square around the equator
+	boundary := &geo.Polygon{Type: geo.TypePolygon, Coordinates: [][][]float64{{{0, 0}, {0.0009, 0}, {0.0009, 0.0009}, {0, 0.0009}, {0, 0}}}}
+	if err := svc.Update(context.Background(), &PondPayload{ID: 1, FarmID: 1, Name: "Pond A", Depth: 1, Boundary: boundary}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.upserted.Area < 10000 || repo.upserted.Area > 10030 || repo.upserted.Volume != repo.upserted.Area {
+		t.Errorf("unexpected derived area: %+v", repo.upserted)
+	}
+}
+
+func TestShouldGetPondGeoJSON(t *testing.T) {
+	boundary := &geo.Polygon{Type: geo.TypePolygon, Coordinates: [][][]float64{{{0, 0}, {0.0009, 0}, {0.0009, 0.0009}, {0, 0}}}}
+	repo := &stubPondRepository{ponds: []*PondFarmType{
+		{PondType: PondType{ID: 1, FarmID: 1, Name: "Pond A", Boundary: boundary}},
+		{PondType: PondType{ID: 2, FarmID: 1, Name: "Pond B"}},
+	}}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	res, err := svc.GetGeoJSON(context.Background(), &PondRequestQuery{FarmID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if res.Type != geo.TypeFeatureCollection || len(res.Features) != 2 || res.Features[0].Geometry != boundary || res.Features[1].Geometry != nil {
+		t.Errorf("unexpected feature collection: %+v", res)
+	}
+}
+
+func TestShouldNOTGetPondGeoJSONOnEmptyFarm(t *testing.T) {
+	svc := NewService(&stubPondRepository{ponds: []*PondFarmType{}}, nil, &stubAuditService{})
+
+	if _, err := svc.GetGeoJSON(context.Background(), &PondRequestQuery{FarmID: 1}); err != errs.ErrNotFound {
+		t.Errorf("expected not found, got: %v", err)
+	}
+}
+
+func TestShouldMovePondIntoMaintenanceWhenBlocked(t *testing.T) {
+	repo := &stubPondRepository{pond: &PondFarmType{PondType: PondType{ID: 1, FarmID: 1, Status: StatusStocked}}}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	if err := svc.SetMaintenance(context.Background(), &PondMaintenancePayload{ID: 1, FarmID: 1, Blocked: true}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.upserted == nil || repo.upserted.Status != StatusMaintenance {
+		t.Errorf("unexpected upserted pond: %+v", repo.upserted)
+	}
+}
+
+func TestShouldNOTMoveHarvestingPondIntoMaintenance(t *testing.T) {
+	repo := &stubPondRepository{pond: &PondFarmType{PondType: PondType{ID: 1, FarmID: 1, Status: StatusHarvesting}}}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	if err := svc.SetMaintenance(context.Background(), &PondMaintenancePayload{ID: 1, FarmID: 1, Blocked: true}); err != errs.ErrInvalidStateTransition {
+		t.Errorf("expected invalid state transition, got: %v", err)
+	}
+}
+
+func TestShouldReleasePondFromMaintenanceWhenUnblocked(t *testing.T) {
+	repo := &stubPondRepository{pond: &PondFarmType{PondType: PondType{ID: 1, FarmID: 1, Status: StatusMaintenance}}}
+	svc := NewService(repo, nil, &stubAuditService{})
+
+	if err := svc.SetMaintenance(context.Background(), &PondMaintenancePayload{ID: 1, FarmID: 1}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.upserted == nil || repo.upserted.Status != StatusIdle {
+		t.Errorf("unexpected upserted pond: %+v", repo.upserted)
+	}
+}
+
+func TestShouldRecordPondStatusChangeOnMaintenance(t *testing.T) {
+	repo := &stubPondRepository{pond: &PondFarmType{PondType: PondType{ID: 1, FarmID: 2, Status: StatusStocked}}}
+	auditSvc := &stubAuditService{}
+	svc := NewService(repo, nil, auditSvc)
+
+	if err := svc.SetMaintenance(context.Background(), &PondMaintenancePayload{ID: 1, FarmID: 2, Blocked: true}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(auditSvc.recorded) != 1 || auditSvc.recorded[0].FarmID != 2 || auditSvc.recorded[0].Entity != audit.EntityPond {
+		t.Fatalf("unexpected recorded audit: %+v", auditSvc.recorded)
+	}
+
+	changes, _ := audit.Diff(auditSvc.recorded[0].Before, auditSvc.recorded[0].After)
+	if len(changes) != 1 || changes["status"].Before != StatusStocked || changes["status"].After != StatusMaintenance {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestShouldRecordPondDelete(t *testing.T) {
+	repo := &stubPondRepository{pond: &PondFarmType{PondType: PondType{ID: 1, FarmID: 2, Name: "Pond A", Status: StatusIdle}}}
+	auditSvc := &stubAuditService{}
+	svc := NewService(repo, nil, auditSvc)
+
+	if err := svc.Delete(context.Background(), &PondRequestQuery{ID: 1, FarmID: 2}); err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if repo.deleted == nil || repo.deleted.ID != 1 || repo.deleted.FarmID != 2 {
+		t.Errorf("unexpected deleted pond: %+v", repo.deleted)
+	}
+
+	if len(auditSvc.recorded) != 1 || auditSvc.recorded[0].Action != audit.ActionDelete || auditSvc.recorded[0].FarmID != 2 || auditSvc.recorded[0].EntityID != 1 {
+		t.Errorf("unexpected recorded audit: %+v", auditSvc.recorded)
+	}
+}
+
+func TestShouldNOTPurgePondWithoutRetention(t *testing.T) {
+	svc := NewService(&stubPondRepository{}, nil, &stubAuditService{})
+
+	if err := svc.Purge(context.Background(), &PurgePayload{}); err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+}
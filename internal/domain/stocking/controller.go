@@ -0,0 +1,35 @@
+package stocking
+
+import "github.com/labstack/echo/v4"
+
+type StockingController struct {
+	svc StockingService
+}
+
+func NewController(svc StockingService) *StockingController {
+	return &StockingController{
+		svc: svc,
+	}
+}
+
+const (
+	stockingBasepath = "/farms/:farmID/ponds/:pondID/stockings"
+	stockingIDPath   = "/:stockingID"
+)
+
+func (sc *StockingController) Route(grp *echo.Group) {
+	subrouter := grp.Group(stockingBasepath)
+
+	subrouter.GET("", HandleGetAllStocking(sc.svc.GetAll))
+	subrouter.OPTIONS("", HandleGetAllStocking(sc.svc.GetAll))
+	subrouter.GET(stockingIDPath, HandleGetOneStocking(sc.svc.GetOne))
+	subrouter.OPTIONS(stockingIDPath, HandleGetOneStocking(sc.svc.GetOne))
+	subrouter.POST("", HandleCreateStocking(sc.svc.Create))
+	subrouter.OPTIONS("", HandleCreateStocking(sc.svc.Create))
+	subrouter.PUT(stockingIDPath, HandleUpdateStocking(sc.svc.Update))
+	subrouter.OPTIONS(stockingIDPath, HandleUpdateStocking(sc.svc.Update))
+	subrouter.DELETE(stockingIDPath, HandleDeleteStocking(sc.svc.Delete))
+	subrouter.OPTIONS(stockingIDPath, HandleDeleteStocking(sc.svc.Delete))
+
+	return
+}
@@ -0,0 +1,51 @@
+package stocking
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// StockingRequestQuery represent query parameters fetch from request
+type StockingRequestQuery struct {
+	ID     int64  `param:"stockingID" example:"1"`
+	FarmID int64  `param:"farmID" example:"1"`
+	PondID int64  `param:"pondID" example:"1"`
+	Active bool   `query:"active" example:"true"` // only return batch which hasn't been totally harvested
+	Limit  uint64 `query:"limit" example:"100"`
+	Page   uint64 `query:"page" example:"2"`
+}
+
+// StockingPayload represent payload fetch from request body
+type StockingPayload struct {
+	ID           int64     `param:"stockingID" json:"-" example:"1"`
+	FarmID       int64     `param:"farmID" json:"-" example:"1"`
+	PondID       int64     `param:"pondID" json:"-" example:"1"`
+	BatchName    string    `json:"batch_name" example:"2024-A"`
+	Species      string    `json:"species" example:"Litopenaeus vannamei"`
+	Hatchery     string    `json:"hatchery" example:"Hatchery A"`
+	InitialCount int64     `json:"initial_count" example:"100000"`
+	AvgWeight    float64   `json:"avg_weight" example:"0.02"`
+	Cost         float64   `json:"cost" example:"3500000"`
+	StockedAt    time.Time `json:"stocked_at" example:"2024-07-01T00:00:00Z"`
+}
+
+// StockingResponse represent domain response for Stocking entity
+type StockingResponse struct {
+	ID           int64      `json:"id" example:"1"`
+	PondID       int64      `json:"pond_id" example:"1"`
+	BatchName    string     `json:"batch_name" example:"2024-A"`
+	Species      string     `json:"species" example:"Litopenaeus vannamei"`
+	Hatchery     string     `json:"hatchery" example:"Hatchery A"`
+	InitialCount int64      `json:"initial_count" example:"100000"`
+	AvgWeight    float64    `json:"avg_weight" example:"0.02"`
+	Cost         float64    `json:"cost" example:"3500000"`
+	StockedAt    time.Time  `json:"stocked_at" example:"2024-07-01T00:00:00Z"`
+	ClosedAt     *time.Time `json:"closed_at" example:"2024-10-01T00:00:00Z"`
+}
+
+// ListStockingResponse represent domain response for bulk Stocking entities
+type ListStockingResponse struct {
+	Stockings []*StockingResponse    `json:"stockings"`
+	Meta      httpres.ListPagination `json:"meta"`
+}
@@ -0,0 +1,197 @@
+package stocking
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllStockingHandler func(context.Context, *StockingRequestQuery) (*ListStockingResponse, error)
+
+// Get All Stocking godoc
+//
+//	@Summary	get all stocking batches of a pond
+//	@Tags		Stocking
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pondID	path		int		true	"Pond ID"
+//	@Param		active	query		bool	false	"only return batch which hasn't been totally harvested"
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListStockingResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/stockings [get]
+func HandleGetAllStocking(handler GetAllStockingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &StockingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneStockingHandler func(context.Context, *StockingRequestQuery) (*StockingResponse, error)
+
+// Get One Stocking godoc
+//
+//	@Summary	get specific stocking batch by ID
+//	@Tags		Stocking
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		stockingID	path		int	true	"Stocking ID"
+//	@Success	200			{object}	StockingResponse
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/stockings/{stockingID} [get]
+func HandleGetOneStocking(handler GetOneStockingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &StockingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateStockingHandler func(context.Context, *StockingPayload) error
+
+// CreateStocking godoc
+//
+//	@Summary	record a new stocking batch into a pond
+//	@Tags		Stocking
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int				true	"Farm ID"
+//	@Param		pondID	path		int				true	"Pond ID"
+//	@Param		payload	body		StockingPayload	true	"stocking payload"
+//	@Success	201		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"pond not existed"
+//	@Failure	409		{object}	httpres.ErrorResponse	"batch with same name already exists"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/stockings [post]
+func HandleCreateStocking(handler CreateStockingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &StockingPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type UpdateStockingHandler func(context.Context, *StockingPayload) error
+
+// Update Stocking godoc
+//
+//	@Summary	update stocking batch data
+//	@Tags		Stocking
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int				true	"Farm ID"
+//	@Param		pondID		path		int				true	"Pond ID"
+//	@Param		stockingID	path		int				true	"Stocking ID"
+//	@Param		payload		body		StockingPayload	true	"stocking payload"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"stocking not existed"
+//	@Failure	409			{object}	httpres.ErrorResponse	"duplicated batch found"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/stockings/{stockingID} [put]
+func HandleUpdateStocking(handler UpdateStockingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &StockingPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type DeleteStockingHandler func(context.Context, *StockingRequestQuery) error
+
+// DeleteStocking godoc
+//
+//	@Summary	delete specific stocking batch by ID
+//	@Tags		Stocking
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		stockingID	path		int	true	"Stocking ID"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"stocking not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/stockings/{stockingID} [delete]
+func HandleDeleteStocking(handler DeleteStockingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &StockingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,19 @@
+package stocking
+
+import (
+	"database/sql"
+	"time"
+)
+
+type StockingType struct {
+	ID           int64        `db:"id"`
+	PondID       int64        `db:"pond_id"`
+	BatchName    string       `db:"batch_name"`
+	Species      string       `db:"species"`
+	Hatchery     string       `db:"hatchery"`
+	InitialCount int64        `db:"initial_count"`
+	AvgWeight    float64      `db:"avg_weight"`
+	Cost         float64      `db:"cost"`
+	StockedAt    time.Time    `db:"stocked_at"`
+	ClosedAt     sql.NullTime `db:"closed_at"` // set once the batch is totally harvested
+}
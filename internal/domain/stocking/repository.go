@@ -0,0 +1,277 @@
+package stocking
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// StockingRepository contain contract that defined all necessary public function available to be interact with
+type StockingRepository interface {
+	GetAll(context.Context, *stockingQuery) ([]*StockingType, error)
+	Count(context.Context, *stockingQuery) (uint64, error)
+	GetOne(context.Context, *stockingQuery) (*StockingType, error)
+	Store(context.Context, int64, *StockingType) error
+	Update(context.Context, int64, *StockingType) error
+	Delete(context.Context, *stockingQuery) error
+}
+
+type stockingRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of stockingRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) StockingRepository {
+	return &stockingRepository{db: db}
+}
+
+type stockingQuery struct {
+	ID, FarmID, PondID int64
+	Active             bool
+	Limit, Page        uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var stockingColumns = []string{
+	"s.id", "s.pond_id", "s.batch_name", "s.species", "s.hatchery", "s.initial_count", "s.avg_weight", "s.cost", "s.stocked_at", "s.closed_at",
+}
+
+func (params *stockingQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"s.pond_id": params.PondID},
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"s.deleted_at": nil},
+	}
+
+	if params.Active {
+		cond = append(cond, squirrel.Eq{"s.closed_at": nil})
+	}
+
+	return cond
+}
+
+func (repo *stockingRepository) GetAll(ctx context.Context, params *stockingQuery) (res []*StockingType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(stockingColumns...).From("stockings s").
+		Join("ponds p on s.pond_id = p.id").
+		Where(params.filter()).
+		OrderBy("s.stocked_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*StockingType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &StockingType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *stockingRepository) Count(ctx context.Context, params *stockingQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("stockings s").
+		Join("ponds p on s.pond_id = p.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *stockingRepository) GetOne(ctx context.Context, params *stockingQuery) (res *StockingType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(stockingColumns...).From("stockings s").
+		Join("ponds p on s.pond_id = p.id").
+		Where(append(params.filter(), squirrel.Eq{"s.id": params.ID})).ToSql()
+
+	res = &StockingType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// validate make sure the pond exists within the farm and no other active batch in the pond share the same name
+func (repo *stockingRepository) validate(ctx context.Context, tx *sqlx.Tx, farmID int64, payload *StockingType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	var count int64
+
+	// check for pond existence within the farm
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+		squirrel.Eq{"id": payload.PondID},
+		squirrel.Eq{"farm_id": farmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate pond data existence")
+		return
+	}
+
+	// if selected pond doesn't exists, bail out from here
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	// check for duplicated batch name within the pond
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("stockings").Where(squirrel.And{
+		squirrel.NotEq{"id": payload.ID},
+		squirrel.Eq{"pond_id": payload.PondID},
+		squirrel.Eq{"batch_name": payload.BatchName},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
+		return
+	}
+
+	// if active (non-deleted) batch exist with such name, return duplicated err
+	if count != 0 {
+		return errs.ErrDuplicatedResources
+	}
+
+	return
+}
+
+func (repo *stockingRepository) Store(ctx context.Context, farmID int64, payload *StockingType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Insert("stockings").
+		Columns("pond_id", "batch_name", "species", "hatchery", "initial_count", "avg_weight", "cost", "stocked_at").
+		Values(payload.PondID, payload.BatchName, payload.Species, payload.Hatchery, payload.InitialCount, payload.AvgWeight, payload.Cost, payload.StockedAt).ToSql()
+
+	_, err = tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *stockingRepository) Update(ctx context.Context, farmID int64, payload *StockingType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if err = repo.validate(ctx, tx, farmID, payload); err != nil {
+		return
+	}
+
+	stmt, args, _ := pgSquirrel.Update("stockings").SetMap(map[string]interface{}{
+		"batch_name":    payload.BatchName,
+		"species":       payload.Species,
+		"hatchery":      payload.Hatchery,
+		"initial_count": payload.InitialCount,
+		"avg_weight":    payload.AvgWeight,
+		"cost":          payload.Cost,
+		"stocked_at":    payload.StockedAt,
+		"updated_at":    squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"pond_id": payload.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *stockingRepository) Delete(ctx context.Context, params *stockingQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("stockings").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"pond_id": params.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("stocking doesn't exists")
+		return
+	}
+
+	return
+}
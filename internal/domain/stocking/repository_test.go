@@ -0,0 +1,114 @@
+package stocking
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestShouldGetStockingWithResult(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	stockingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "pond_id", "batch_name", "species", "hatchery", "initial_count", "avg_weight", "cost", "stocked_at", "closed_at"}).
+		AddRow(1, 1, "2024-A", "Litopenaeus vannamei", "Hatchery A", 100000, 0.02, 3500000, time.Now(), nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT s.id, s.pond_id, s.batch_name, s.species, s.hatchery, s.initial_count, s.avg_weight, s.cost, s.stocked_at, s.closed_at FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND s.deleted_at IS NULL) ORDER BY s.stocked_at desc LIMIT 100 OFFSET 0")).
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	stockingRepo.GetAll(context.Background(), &stockingQuery{FarmID: 1, PondID: 1, Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreStocking(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	stockingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	stockedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM stockings WHERE (id <> $1 AND pond_id = $2 AND batch_name = $3 AND deleted_at IS NULL)")).WithArgs(0, 1, "2024-A").
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stockings (pond_id,batch_name,species,hatchery,initial_count,avg_weight,cost,stocked_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)")).
+		WithArgs(1, "2024-A", "Litopenaeus vannamei", "Hatchery A", 100000, 0.02, 3500000.0, stockedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	stockingRepo.Store(context.Background(), 1, &StockingType{
+		PondID:       1,
+		BatchName:    "2024-A",
+		Species:      "Litopenaeus vannamei",
+		Hatchery:     "Hatchery A",
+		InitialCount: 100000,
+		AvgWeight:    0.02,
+		Cost:         3500000,
+		StockedAt:    stockedAt,
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreStockingDueBatchDuplicated(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	stockingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM stockings WHERE (id <> $1 AND pond_id = $2 AND batch_name = $3 AND deleted_at IS NULL)")).WithArgs(0, 1, "2024-A").
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectRollback()
+
+	stockingRepo.Store(context.Background(), 1, &StockingType{PondID: 1, BatchName: "2024-A"})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldDeleteStocking(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	stockingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stockings SET deleted_at = NOW(), updated_at = NOW() WHERE (id = $1 AND pond_id = $2 AND deleted_at IS NULL)")).WithArgs(1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	stockingRepo.Delete(context.Background(), &stockingQuery{ID: 1, PondID: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
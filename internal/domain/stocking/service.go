@@ -0,0 +1,214 @@
+package stocking
+
+import (
+	"context"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// StockingService contains public API available to be interacted with
+type StockingService interface {
+	GetAll(context.Context, *StockingRequestQuery) (*ListStockingResponse, error)
+	GetOne(context.Context, *StockingRequestQuery) (*StockingResponse, error)
+	Create(context.Context, *StockingPayload) error
+	Update(context.Context, *StockingPayload) error
+	Delete(context.Context, *StockingRequestQuery) error
+}
+
+type stockingService struct {
+	repo StockingRepository
+}
+
+// NewService return an instance of StockingService containing available usecases
+func NewService(repo StockingRepository) StockingService {
+	return &stockingService{repo: repo}
+}
+
+func (svc *stockingService) GetAll(ctx context.Context, params *StockingRequestQuery) (res *ListStockingResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &stockingQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		Active: params.Active,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListStockingResponse{
+		Stockings: []*StockingResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	stockings, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, stocking := range stockings {
+		res.Stockings = append(res.Stockings, toStockingResponse(stocking))
+	}
+
+	return
+}
+
+func (svc *stockingService) GetOne(ctx context.Context, params *StockingRequestQuery) (res *StockingResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &stockingQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	stocking, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if stocking == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toStockingResponse(stocking), nil
+}
+
+func (svc *stockingService) Create(ctx context.Context, payload *StockingPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toStockingType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Store(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *stockingService) Update(ctx context.Context, payload *StockingPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data, err := toStockingType(payload)
+	if err != nil {
+		return
+	}
+
+	err = svc.repo.Update(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func (svc *stockingService) Delete(ctx context.Context, params *StockingRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &stockingQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	// make sure the batch belongs to requested farm and pond
+	stocking, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if stocking == nil {
+		return errs.ErrNotFound
+	}
+
+	err = svc.repo.Delete(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func toStockingType(payload *StockingPayload) (res *StockingType, err error) {
+	if payload.BatchName == "" || payload.Species == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	if payload.InitialCount <= 0 || payload.AvgWeight < 0 || payload.Cost < 0 {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &StockingType{
+		ID:           payload.ID,
+		PondID:       payload.PondID,
+		BatchName:    payload.BatchName,
+		Species:      payload.Species,
+		Hatchery:     payload.Hatchery,
+		InitialCount: payload.InitialCount,
+		AvgWeight:    payload.AvgWeight,
+		Cost:         payload.Cost,
+		StockedAt:    payload.StockedAt,
+	}
+
+	// batch without explicit stocking date is assumed to be stocked today
+	if res.StockedAt.IsZero() {
+		res.StockedAt = time.Now()
+	}
+
+	return
+}
+
+func toStockingResponse(stocking *StockingType) *StockingResponse {
+	res := &StockingResponse{
+		ID:           stocking.ID,
+		PondID:       stocking.PondID,
+		BatchName:    stocking.BatchName,
+		Species:      stocking.Species,
+		Hatchery:     stocking.Hatchery,
+		InitialCount: stocking.InitialCount,
+		AvgWeight:    stocking.AvgWeight,
+		Cost:         stocking.Cost,
+		StockedAt:    stocking.StockedAt,
+	}
+
+	if stocking.ClosedAt.Valid {
+		res.ClosedAt = &stocking.ClosedAt.Time
+	}
+
+	return res
+}
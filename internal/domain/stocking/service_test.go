@@ -0,0 +1,145 @@
+package stocking
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+var stockingRows = []string{"id", "pond_id", "batch_name", "species", "hatchery", "initial_count", "avg_weight", "cost", "stocked_at", "closed_at"}
+
+func TestShouldGetActiveStockingOfPond(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	svc := NewService(NewRepository(sqlx.NewDb(db, "sqlmock")))
+	stockedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// expected queries, closed batch is left out of active ones
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND s.deleted_at IS NULL AND s.closed_at IS NULL)")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT s.id, s.pond_id, s.batch_name, s.species, s.hatchery, s.initial_count, s.avg_weight, s.cost, s.stocked_at, s.closed_at FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND s.deleted_at IS NULL AND s.closed_at IS NULL) ORDER BY s.stocked_at desc LIMIT 2 OFFSET 2")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows(stockingRows).
+			AddRow(1, 1, "2024-A", "Litopenaeus vannamei", "Hatchery A", 100000, 0.02, 3500000, stockedAt, nil))
+
+	res, err := svc.GetAll(context.Background(), &StockingRequestQuery{FarmID: 1, PondID: 1, Active: true, Limit: 2, Page: 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if len(res.Stockings) != 1 || res.Stockings[0].BatchName != "2024-A" || res.Stockings[0].ClosedAt != nil {
+		t.Errorf("unexpected stockings: %+v", res.Stockings)
+	}
+
+	if res.Meta.Page != 2 || res.Meta.TotalPage != 2 {
+		t.Errorf("unexpected meta: %+v", res.Meta)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTGetStockingOfPondWithoutBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	svc := NewService(NewRepository(sqlx.NewDb(db, "sqlmock")))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND s.deleted_at IS NULL)")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+
+	if _, err := svc.GetAll(context.Background(), &StockingRequestQuery{FarmID: 1, PondID: 1}); err != errs.ErrNotFound {
+		t.Errorf("expected not found, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldGetClosedStocking(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	svc := NewService(NewRepository(sqlx.NewDb(db, "sqlmock")))
+	closedAt := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT s.id, s.pond_id, s.batch_name, s.species, s.hatchery, s.initial_count, s.avg_weight, s.cost, s.stocked_at, s.closed_at FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND s.deleted_at IS NULL AND s.id = $3)")).
+		WithArgs(1, 1, 1).
+		WillReturnRows(sqlmock.NewRows(stockingRows).
+			AddRow(1, 1, "2024-A", "Litopenaeus vannamei", "Hatchery A", 100000, 0.02, 3500000, closedAt.AddDate(0, -3, 0), closedAt))
+
+	res, err := svc.GetOne(context.Background(), &StockingRequestQuery{ID: 1, FarmID: 1, PondID: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %s", err)
+	}
+
+	if res.ClosedAt == nil || !res.ClosedAt.Equal(closedAt) {
+		t.Errorf("unexpected stocking: %+v", res)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTCreateStockingWithoutCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	svc := NewService(NewRepository(sqlx.NewDb(db, "sqlmock")))
+
+	// invalid batch never reaches the DB
+	err = svc.Create(context.Background(), &StockingPayload{FarmID: 1, PondID: 1, BatchName: "2024-A", Species: "Litopenaeus vannamei"})
+	if err != errs.ErrBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTDeleteStockingOfAnotherPond(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	svc := NewService(NewRepository(sqlx.NewDb(db, "sqlmock")))
+
+	// batch is looked up within the requested pond first, thus nothing is deleted
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT s.id, s.pond_id, s.batch_name, s.species, s.hatchery, s.initial_count, s.avg_weight, s.cost, s.stocked_at, s.closed_at FROM stockings s JOIN ponds p on s.pond_id = p.id WHERE (s.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND s.deleted_at IS NULL AND s.id = $3)")).
+		WithArgs(2, 1, 1).
+		WillReturnRows(sqlmock.NewRows(stockingRows))
+
+	if err := svc.Delete(context.Background(), &StockingRequestQuery{ID: 1, FarmID: 1, PondID: 2}); err != errs.ErrNotFound {
+		t.Errorf("expected not found, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -15,8 +15,9 @@ type GetRequestMetricHandler func(context.Context) (*ListRequestMetricResponse,
 //	@Summary	get request metrics for all registered API
 //	@Tags		Misc
 //	@Produce	json
+//	@Security	BearerAuth
 //	@Success	200	{object}	ListRequestMetricResponse
-//@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	404	{object}	httpres.ErrorResponse
 //	@Router		/telemetry/request-metrics [get]
 func HandleGetRequestMetric(handler GetRequestMetricHandler) echo.HandlerFunc {
 	return func(c echo.Context) (err error) {
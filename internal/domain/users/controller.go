@@ -0,0 +1,51 @@
+package users
+
+import "github.com/labstack/echo/v4"
+
+type UserController struct {
+	svc UserService
+}
+
+func NewController(svc UserService) *UserController {
+	return &UserController{
+		svc: svc,
+	}
+}
+
+const (
+	authBasepath = "/auth"
+	loginPath    = "/login"
+	refreshPath  = "/refresh"
+	logoutPath   = "/logout"
+
+	userBasepath = "/users"
+	mePath       = "/me"
+	passwordPath = "/me/password"
+	userIDPath   = "/:userID"
+)
+
+func (uc *UserController) Route(grp *echo.Group) {
+	authrouter := grp.Group(authBasepath)
+
+	authrouter.POST(loginPath, HandleLogin(uc.svc.Login))
+	authrouter.OPTIONS(loginPath, HandleLogin(uc.svc.Login))
+	authrouter.POST(refreshPath, HandleRefresh(uc.svc.Refresh))
+	authrouter.OPTIONS(refreshPath, HandleRefresh(uc.svc.Refresh))
+	authrouter.POST(logoutPath, HandleLogout(uc.svc.Logout))
+	authrouter.OPTIONS(logoutPath, HandleLogout(uc.svc.Logout))
+
+	subrouter := grp.Group(userBasepath)
+
+	subrouter.GET("", HandleGetAllUser(uc.svc.GetAll))
+	subrouter.OPTIONS("", HandleGetAllUser(uc.svc.GetAll))
+	subrouter.GET(mePath, HandleGetMe(uc.svc.GetMe))
+	subrouter.OPTIONS(mePath, HandleGetMe(uc.svc.GetMe))
+	subrouter.GET(userIDPath, HandleGetOneUser(uc.svc.GetOne))
+	subrouter.OPTIONS(userIDPath, HandleGetOneUser(uc.svc.GetOne))
+	subrouter.POST("", HandleCreateUser(uc.svc.Create))
+	subrouter.OPTIONS("", HandleCreateUser(uc.svc.Create))
+	subrouter.PUT(passwordPath, HandleChangePassword(uc.svc.ChangePassword))
+	subrouter.OPTIONS(passwordPath, HandleChangePassword(uc.svc.ChangePassword))
+
+	return
+}
@@ -0,0 +1,70 @@
+package users
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// UserRequestQuery represent query parameters fetch from request
+type UserRequestQuery struct {
+	ID    int64  `param:"userID" example:"1"`
+	Limit uint64 `query:"limit" example:"100"`
+	Page  uint64 `query:"page" example:"2"`
+}
+
+// UserPayload represent payload fetch from request body
+type UserPayload struct {
+	Username string `json:"username" example:"budi"`
+	Name     string `json:"name" example:"Budi Santoso"`
+	Password string `json:"password" example:"s3cr3t-pa55"`
+	Admin    bool   `json:"admin" example:"false"` // admin isn't bound by farm roles
+
+	OrganizationID int64 `json:"organization_id" example:"1"` // only honoured for superadmin, default to organization of the creator
+}
+
+// PasswordPayload represent password change of the logged in user
+type PasswordPayload struct {
+	OldPassword string `json:"old_password" example:"s3cr3t-pa55"`
+	NewPassword string `json:"new_password" example:"n3w-s3cr3t-pa55"`
+}
+
+// UserResponse represent domain response for User entity
+type UserResponse struct {
+	ID             int64     `json:"id" example:"1"`
+	OrganizationID int64     `json:"organization_id" example:"1"`
+	Username       string    `json:"username" example:"budi"`
+	Name           string    `json:"name" example:"Budi Santoso"`
+	Admin          bool      `json:"admin" example:"false"`
+	CreatedAt      time.Time `json:"created_at" example:"2024-08-15T02:10:40Z"`
+}
+
+// ListUserResponse represent domain response for bulk User entities
+type ListUserResponse struct {
+	Users []*UserResponse        `json:"users"`
+	Meta  httpres.ListPagination `json:"meta"`
+}
+
+// LoginPayload represent credential exchanged for a token pair
+type LoginPayload struct {
+	Username string `json:"username" example:"budi"`
+	Password string `json:"password" example:"s3cr3t-pa55"`
+}
+
+// RefreshPayload represent refresh token exchanged for a new token pair
+type RefreshPayload struct {
+	RefreshToken string `json:"refresh_token" example:"b64c2Vzc2lvbi1yZWZyZXNo..."`
+}
+
+// LogoutPayload represent logout request of the logged in user
+type LogoutPayload struct {
+	All bool `json:"all" example:"false"` // end every session of the user instead of the current one
+}
+
+// TokenResponse represent issued token pair, refresh token is only valid for a single exchange
+type TokenResponse struct {
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"b64c2Vzc2lvbi1yZWZyZXNo..."`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"` // lifetime of access token in seconds
+}
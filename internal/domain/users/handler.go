@@ -0,0 +1,282 @@
+package users
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type LoginHandler func(context.Context, *LoginPayload) (*TokenResponse, error)
+
+// Login godoc
+//
+//	@Summary	exchange username and password for an access and refresh token pair
+//	@Tags		Auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		payload	body		LoginPayload	true	"credential"
+//	@Success	200		{object}	TokenResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"missing username or password"
+//	@Failure	401		{object}	httpres.ErrorResponse	"invalid credential"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/auth/login [post]
+func HandleLogin(handler LoginHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &LoginPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type RefreshHandler func(context.Context, *RefreshPayload) (*TokenResponse, error)
+
+// Refresh godoc
+//
+//	@Summary	exchange a refresh token for a new token pair, each refresh token can only be exchanged once
+//	@Tags		Auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		payload	body		RefreshPayload	true	"refresh token"
+//	@Success	200		{object}	TokenResponse
+//	@Failure	401		{object}	httpres.ErrorResponse	"expired, revoked or already exchanged refresh token"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/auth/refresh [post]
+func HandleRefresh(handler RefreshHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &RefreshPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type LogoutHandler func(context.Context, *LogoutPayload) error
+
+// Logout godoc
+//
+//	@Summary	end the current session, or every session of the user
+//	@Tags		Auth
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		payload	body		LogoutPayload	false	"logout option"
+//	@Success	200		{object}	string
+//	@Failure	401		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/auth/logout [post]
+func HandleLogout(handler LogoutHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &LogoutPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
+
+type GetAllUserHandler func(context.Context, *UserRequestQuery) (*ListUserResponse, error)
+
+// Get All User godoc
+//
+//	@Summary	get all users
+//	@Tags		Users
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Success	200		{object}	ListUserResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/users [get]
+func HandleGetAllUser(handler GetAllUserHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &UserRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetMeHandler func(context.Context, *UserRequestQuery) (*UserResponse, error)
+
+// Get Me godoc
+//
+//	@Summary	get the logged in user
+//	@Tags		Users
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200	{object}	UserResponse
+//	@Failure	401	{object}	httpres.ErrorResponse
+//	@Failure	500	{object}	httpres.ErrorResponse
+//	@Router		/users/me [get]
+func HandleGetMe(handler GetMeHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &UserRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneUserHandler func(context.Context, *UserRequestQuery) (*UserResponse, error)
+
+// Get One User godoc
+//
+//	@Summary	get specific user by ID
+//	@Tags		Users
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		userID	path		int	true	"User ID"
+//	@Success	200		{object}	UserResponse
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/users/{userID} [get]
+func HandleGetOneUser(handler GetOneUserHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &UserRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateUserHandler func(context.Context, *UserPayload) error
+
+// CreateUser godoc
+//
+//	@Summary	create a new user within organization of the admin, only superadmin may pick another one
+//	@Tags		Users
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		payload	body		UserPayload	true	"user payload"
+//	@Success	201		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"password shorter than 8 or longer than 72 characters"
+//	@Failure	403		{object}	httpres.ErrorResponse	"caller isn't an admin"
+//	@Failure	404		{object}	httpres.ErrorResponse	"organization not existed"
+//	@Failure	409		{object}	httpres.ErrorResponse	"username already taken"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/users [post]
+func HandleCreateUser(handler CreateUserHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &UserPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type ChangePasswordHandler func(context.Context, *PasswordPayload) error
+
+// Change Password godoc
+//
+//	@Summary	change password of the logged in user, ending every other session
+//	@Tags		Users
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		payload	body		PasswordPayload	true	"password payload"
+//	@Success	200		{object}	string
+//	@Failure	400		{object}	httpres.ErrorResponse	"password shorter than 8 or longer than 72 characters"
+//	@Failure	401		{object}	httpres.ErrorResponse	"invalid old password"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/users/me/password [put]
+func HandleChangePassword(handler ChangePasswordHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &PasswordPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
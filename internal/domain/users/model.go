@@ -0,0 +1,47 @@
+package users
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	TokenTypeBearer = "Bearer"
+
+	MinPasswordLength = 8
+	MaxPasswordLength = 72 // bcrypt ignores anything beyond 72 bytes
+
+	DefaultOrganizationID = 1 // created by migration, owns every record predating tenancy
+)
+
+type UserType struct {
+	ID             int64     `db:"id"`
+	OrganizationID int64     `db:"organization_id"`
+	Username       string    `db:"username"`
+	Name           string    `db:"name"`
+	PasswordHash   string    `db:"password_hash"`
+	IsAdmin        bool      `db:"is_admin"`
+	IsSuperadmin   bool      `db:"is_superadmin"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// SessionType represent a login, kept alive by exchanging its refresh token
+type SessionType struct {
+	ID          int64        `db:"id"`
+	UserID      int64        `db:"user_id"`
+	RefreshHash string       `db:"refresh_hash"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	RevokedAt   sql.NullTime `db:"revoked_at"`
+}
+
+// accessClaims represent claims of a signed access token, subject is the user ID
+type accessClaims struct {
+	Username     string `json:"username"`
+	SessionID    int64  `json:"sid"`
+	Organization int64  `json:"org"`
+	Admin        bool   `json:"admin,omitempty"`
+	Superadmin   bool   `json:"superadmin,omitempty"`
+	jwt.StandardClaims
+}
@@ -0,0 +1,325 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// UserRepository contain contract that defined all necessary public function available to be interact with
+type UserRepository interface {
+	GetAll(context.Context, *userQuery) ([]*UserType, error)
+	Count(context.Context, *userQuery) (uint64, error)
+	GetOne(context.Context, *userQuery) (*UserType, error)
+	Store(context.Context, *UserType) error
+	UpdatePassword(context.Context, *UserType) error
+	StoreSession(context.Context, *SessionType) error
+	GetOneSession(context.Context, *sessionQuery) (*SessionType, error)
+	RotateSession(context.Context, string, *SessionType) error
+	RevokeSession(context.Context, *sessionQuery) error
+}
+
+type userRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of userRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+type userQuery struct {
+	ID             int64
+	Username       string
+	OrganizationID int64 // only users of the tenant, when non-zero
+	Limit, Page    uint64
+}
+
+// sessionQuery select active sessions, ExcludeID keeps a session out of bulk revocation
+type sessionQuery struct {
+	ID, UserID, ExcludeID int64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var userColumns = []string{"u.id", "u.organization_id", "u.username", "u.name", "u.password_hash", "u.is_admin", "u.is_superadmin", "u.created_at"}
+
+var sessionColumns = []string{"s.id", "s.user_id", "s.refresh_hash", "s.expires_at", "s.revoked_at"}
+
+func (params *userQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"u.deleted_at": nil},
+	}
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"u.id": params.ID})
+	}
+
+	// username is case-insensitive
+	if params.Username != "" {
+		cond = append(cond, squirrel.Eq{"lower(u.username)": strings.ToLower(params.Username)})
+	}
+
+	if params.OrganizationID != 0 {
+		cond = append(cond, squirrel.Eq{"u.organization_id": params.OrganizationID})
+	}
+
+	return cond
+}
+
+func (params *sessionQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"s.revoked_at": nil},
+		squirrel.Expr("s.expires_at > NOW()"),
+	}
+
+	if params.ID != 0 {
+		cond = append(cond, squirrel.Eq{"s.id": params.ID})
+	}
+
+	if params.UserID != 0 {
+		cond = append(cond, squirrel.Eq{"s.user_id": params.UserID})
+	}
+
+	if params.ExcludeID != 0 {
+		cond = append(cond, squirrel.NotEq{"s.id": params.ExcludeID})
+	}
+
+	return cond
+}
+
+func (repo *userRepository) GetAll(ctx context.Context, params *userQuery) (res []*UserType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(userColumns...).From("users u").
+		Where(params.filter()).
+		OrderBy("u.id").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*UserType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &UserType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *userRepository) Count(ctx context.Context, params *userQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("users u").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *userRepository) GetOne(ctx context.Context, params *userQuery) (res *UserType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(userColumns...).From("users u").
+		Where(params.filter()).ToSql()
+
+	res = &UserType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// Store save a new user, the generated ID will be assigned back into payload
+func (repo *userRepository) Store(ctx context.Context, payload *UserType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var count int64
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("users").Where(squirrel.And{
+		squirrel.Eq{"lower(username)": strings.ToLower(payload.Username)},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate duplicated data existence")
+		return
+	}
+
+	// if active (non-deleted) user exist with such username, return duplicated err
+	if count != 0 {
+		return errs.ErrDuplicatedResources
+	}
+
+	// check for organization existence
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("organizations").Where(squirrel.And{
+		squirrel.Eq{"id": payload.OrganizationID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate organization data existence")
+		return
+	}
+
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	stmt, args, _ = pgSquirrel.Insert("users").
+		Columns("organization_id", "username", "name", "password_hash", "is_admin", "is_superadmin").
+		Values(payload.OrganizationID, payload.Username, payload.Name, payload.PasswordHash, payload.IsAdmin, payload.IsSuperadmin).
+		Suffix("RETURNING id, created_at").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID, &payload.CreatedAt); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *userRepository) UpdatePassword(ctx context.Context, payload *UserType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("users").SetMap(map[string]interface{}{
+		"password_hash": payload.PasswordHash,
+		"updated_at":    squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": payload.ID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return
+}
+
+// StoreSession save a new session, the generated ID will be assigned back into payload
+func (repo *userRepository) StoreSession(ctx context.Context, payload *SessionType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Insert("user_sessions").
+		Columns("user_id", "refresh_hash", "expires_at").
+		Values(payload.UserID, payload.RefreshHash, payload.ExpiresAt).
+		Suffix("RETURNING id").ToSql()
+
+	if err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	return
+}
+
+// GetOneSession return an active session, nil when it's expired, revoked or never existed
+func (repo *userRepository) GetOneSession(ctx context.Context, params *sessionQuery) (res *SessionType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(sessionColumns...).From("user_sessions s").
+		Where(params.filter()).ToSql()
+
+	res = &SessionType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// RotateSession replace refresh token of the active session owning refreshHash, ID and UserID of the session
+// will be assigned back into payload. Since the swap is atomic, a refresh token can only be exchanged once
+func (repo *userRepository) RotateSession(ctx context.Context, refreshHash string, payload *SessionType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("user_sessions s").SetMap(map[string]interface{}{
+		"refresh_hash": payload.RefreshHash,
+		"expires_at":   payload.ExpiresAt,
+		"updated_at":   squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"s.refresh_hash": refreshHash},
+		squirrel.Eq{"s.revoked_at": nil},
+		squirrel.Expr("s.expires_at > NOW()"),
+	}).Suffix("RETURNING s.id, s.user_id").ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID, &payload.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	} else if err == sql.ErrNoRows {
+		return errs.ErrInvalidCred
+	}
+
+	return
+}
+
+// RevokeSession end every active session matching params
+func (repo *userRepository) RevokeSession(ctx context.Context, params *sessionQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("user_sessions s").SetMap(map[string]interface{}{
+		"revoked_at": squirrel.Expr("NOW()"),
+		"updated_at": squirrel.Expr("NOW()"),
+	}).Where(params.filter()).ToSql()
+
+	if _, err = repo.db.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Error().Err(err).Msg("failed to update data")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,63 @@
+package users
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+)
+
+func TestShouldNOTStoreDuplicatedUsername(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	userRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM users WHERE (lower(username) = $1 AND deleted_at IS NULL)")).
+		WithArgs("budi").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	err = userRepo.Store(context.Background(), &UserType{Username: "Budi", PasswordHash: "hash"})
+	if err != errs.ErrDuplicatedResources {
+		t.Errorf("expected ErrDuplicatedResources, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTRotateExchangedRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	userRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+	expiresAt := time.Now().Add(time.Hour)
+
+	// expected queries
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE user_sessions s SET expires_at = $1, refresh_hash = $2, updated_at = NOW() WHERE (s.refresh_hash = $3 AND s.revoked_at IS NULL AND s.expires_at > NOW()) RETURNING s.id, s.user_id")).
+		WithArgs(expiresAt, "new-hash", "old-hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+
+	err = userRepo.RotateSession(context.Background(), "old-hash", &SessionType{RefreshHash: "new-hash", ExpiresAt: expiresAt})
+	if err != errs.ErrInvalidCred {
+		t.Errorf("expected ErrInvalidCred, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -0,0 +1,487 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UserService contains public API available to be interacted with
+type UserService interface {
+	GetAll(context.Context, *UserRequestQuery) (*ListUserResponse, error)
+	GetOne(context.Context, *UserRequestQuery) (*UserResponse, error)
+	GetMe(context.Context, *UserRequestQuery) (*UserResponse, error)
+	Create(context.Context, *UserPayload) error
+	ChangePassword(context.Context, *PasswordPayload) error
+	Login(context.Context, *LoginPayload) (*TokenResponse, error)
+	Refresh(context.Context, *RefreshPayload) (*TokenResponse, error)
+	Logout(context.Context, *LogoutPayload) error
+	Authenticate(context.Context, string) (*auth.Identity, error)
+	Bootstrap(context.Context, *UserPayload) error
+}
+
+type userService struct {
+	repo UserRepository
+	conf *auth.JWTConfig
+}
+
+// NewService return an instance of UserService containing available usecases
+func NewService(repo UserRepository, conf *auth.JWTConfig) UserService {
+	if conf.AccessTTL <= 0 {
+		conf.AccessTTL = auth.DefaultAccessTTL
+	}
+
+	if conf.RefreshTTL <= 0 {
+		conf.RefreshTTL = auth.DefaultRefreshTTL
+	}
+
+	return &userService{repo: repo, conf: conf}
+}
+
+// dummyHash is compared against on login of unknown user, so response time doesn't leak which username exists
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+
+func (svc *userService) GetAll(ctx context.Context, params *UserRequestQuery) (res *ListUserResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &userQuery{
+		OrganizationID: tenantOf(ctx),
+		Limit:          params.Limit,
+		Page:           params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	res = &ListUserResponse{
+		Users: []*UserResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	users, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, user := range users {
+		res.Users = append(res.Users, toUserResponse(user))
+	}
+
+	return
+}
+
+func (svc *userService) GetOne(ctx context.Context, params *UserRequestQuery) (res *UserResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	user, err := svc.repo.GetOne(ctx, &userQuery{ID: params.ID, OrganizationID: tenantOf(ctx)})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if user == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toUserResponse(user), nil
+}
+
+// GetMe return the logged in user
+func (svc *userService) GetMe(ctx context.Context, _ *UserRequestQuery) (res *UserResponse, err error) {
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	return svc.GetOne(ctx, &UserRequestQuery{ID: identity.UserID})
+}
+
+// Create register a new user, only admin may do so. The user joins organization of the admin,
+// only superadmin may place it into another one
+func (svc *userService) Create(ctx context.Context, payload *UserPayload) (err error) {
+	identity := auth.FromContext(ctx)
+	if identity == nil || !identity.Admin {
+		return errs.ErrNoAccess
+	}
+
+	data, err := toUserType(payload)
+	if err != nil {
+		return
+	}
+
+	if !identity.Superadmin || data.OrganizationID == 0 {
+		data.OrganizationID = identity.OrganizationID
+	}
+
+	return svc.create(ctx, data)
+}
+
+func (svc *userService) create(ctx context.Context, data *UserType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	err = svc.repo.Store(ctx, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// ChangePassword replace password of the logged in user and end every other session of the user
+func (svc *userService) ChangePassword(ctx context.Context, payload *PasswordPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return errs.ErrInvalidCred
+	}
+
+	user, err := svc.repo.GetOne(ctx, &userQuery{ID: identity.UserID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if user == nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(payload.OldPassword)) != nil {
+		return errs.ErrInvalidCred
+	}
+
+	if user.PasswordHash, err = hashPassword(payload.NewPassword); err != nil {
+		return
+	}
+
+	err = svc.repo.UpdatePassword(ctx, user)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	err = svc.repo.RevokeSession(ctx, &sessionQuery{UserID: user.ID, ExcludeID: identity.SessionID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// Login exchange username and password for a new session
+func (svc *userService) Login(ctx context.Context, payload *LoginPayload) (res *TokenResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.Username == "" || payload.Password == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	user, err := svc.repo.GetOne(ctx, &userQuery{Username: payload.Username})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if user == nil {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(payload.Password))
+		return nil, errs.ErrInvalidCred
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(payload.Password)) != nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	refreshToken, err := generateToken()
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	session := &SessionType{
+		UserID:      user.ID,
+		RefreshHash: hashToken(refreshToken),
+		ExpiresAt:   time.Now().Add(svc.conf.RefreshTTL),
+	}
+
+	err = svc.repo.StoreSession(ctx, session)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return svc.issue(user, session, refreshToken)
+}
+
+// Refresh exchange a refresh token for a new token pair, the exchanged refresh token is no longer valid afterward
+func (svc *userService) Refresh(ctx context.Context, payload *RefreshPayload) (res *TokenResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.RefreshToken == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	refreshToken, err := generateToken()
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	session := &SessionType{
+		RefreshHash: hashToken(refreshToken),
+		ExpiresAt:   time.Now().Add(svc.conf.RefreshTTL),
+	}
+
+	err = svc.repo.RotateSession(ctx, hashToken(payload.RefreshToken), session)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	user, err := svc.repo.GetOne(ctx, &userQuery{ID: session.UserID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	// user removed while the session is still alive
+	if user == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	return svc.issue(user, session, refreshToken)
+}
+
+// Logout end the current session of the logged in user, or every session of the user
+func (svc *userService) Logout(ctx context.Context, payload *LogoutPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return errs.ErrInvalidCred
+	}
+
+	params := &sessionQuery{UserID: identity.UserID}
+	if !payload.All {
+		params.ID = identity.SessionID
+	}
+
+	err = svc.repo.RevokeSession(ctx, params)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+// Authenticate verify an access token, and make sure its session hasn't been ended
+func (svc *userService) Authenticate(ctx context.Context, token string) (res *auth.Identity, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	claims := &accessClaims{}
+
+	_, err = jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errs.ErrInvalidCred
+		}
+
+		return []byte(svc.conf.Secret), nil
+	})
+	if err != nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	// token issued before tenancy isn't bound to any organization, thus has to be reissued
+	if claims.Organization == 0 {
+		return nil, errs.ErrInvalidCred
+	}
+
+	session, err := svc.repo.GetOneSession(ctx, &sessionQuery{ID: claims.SessionID, UserID: userID})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if session == nil {
+		return nil, errs.ErrInvalidCred
+	}
+
+	return &auth.Identity{
+		UserID:         userID,
+		Username:       claims.Username,
+		SessionID:      session.ID,
+		Admin:          claims.Admin,
+		Superadmin:     claims.Superadmin,
+		OrganizationID: claims.Organization,
+	}, nil
+}
+
+// Bootstrap create the first user as superadmin of the default organization, so a fresh deployment has someone able to log in. It's a no-op once any user exists
+func (svc *userService) Bootstrap(ctx context.Context, payload *UserPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	if payload.Username == "" {
+		return
+	}
+
+	count, err := svc.repo.Count(ctx, &userQuery{})
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count != 0 {
+		return
+	}
+
+	data, err := toUserType(payload)
+	if err != nil {
+		return
+	}
+	data.OrganizationID = DefaultOrganizationID
+	data.IsAdmin, data.IsSuperadmin = true, true
+
+	if err = svc.create(ctx, data); err != nil {
+		return
+	}
+
+	logger.Info().Str("username", payload.Username).Msg("initial user created")
+
+	return
+}
+
+// tenantOf return organization whose users are visible to the caller, everyone is bound by its own
+func tenantOf(ctx context.Context) int64 {
+	if identity := auth.FromContext(ctx); identity != nil {
+		return identity.OrganizationID
+	}
+
+	return 0
+}
+
+// issue sign an access token for the session and pair it with the refresh token
+func (svc *userService) issue(user *UserType, session *SessionType, refreshToken string) (res *TokenResponse, err error) {
+	now := time.Now()
+
+	claims := &accessClaims{
+		Username:     user.Username,
+		SessionID:    session.ID,
+		Organization: user.OrganizationID,
+		Admin:        user.IsAdmin,
+		Superadmin:   user.IsSuperadmin,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   strconv.FormatInt(user.ID, 10),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(svc.conf.AccessTTL).Unix(),
+		},
+	}
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(svc.conf.Secret))
+	if err != nil {
+		return
+	}
+
+	return &TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    TokenTypeBearer,
+		ExpiresIn:    int64(svc.conf.AccessTTL / time.Second),
+	}, nil
+}
+
+func toUserType(payload *UserPayload) (res *UserType, err error) {
+	payload.Username = strings.TrimSpace(payload.Username)
+	if payload.Username == "" || payload.Password == "" {
+		return nil, errs.ErrMissingRequiredAttribute
+	}
+
+	res = &UserType{
+		OrganizationID: payload.OrganizationID,
+		Username:       payload.Username,
+		Name:           payload.Name,
+		IsAdmin:        payload.Admin,
+	}
+
+	if res.PasswordHash, err = hashPassword(payload.Password); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func toUserResponse(user *UserType) *UserResponse {
+	return &UserResponse{
+		ID:             user.ID,
+		OrganizationID: user.OrganizationID,
+		Username:       user.Username,
+		Name:           user.Name,
+		Admin:          user.IsAdmin,
+		CreatedAt:      user.CreatedAt,
+	}
+}
+
+// hashPassword return bcrypt hash of the password, password outside the accepted length is rejected
+func hashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return "", errs.ErrBadRequest
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// generateToken return a random url-safe 256 bit refresh token
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken return hex-encoded sha256 of the token, refresh token has enough entropy thus no salt needed
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,273 @@
+package users
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/nmluci/da-farm-be/internal/core/auth"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type stubUserRepository struct {
+	UserRepository
+	user     *UserType
+	session  *SessionType
+	stored   *SessionType
+	revoked  *sessionQuery
+	rotateOf string
+	created  *UserType
+	listed   *userQuery
+}
+
+func (repo *stubUserRepository) GetOne(_ context.Context, params *userQuery) (*UserType, error) {
+	if repo.user == nil || (params.ID != 0 && params.ID != repo.user.ID) || (params.Username != "" && !strings.EqualFold(params.Username, repo.user.Username)) ||
+		(params.OrganizationID != 0 && params.OrganizationID != repo.user.OrganizationID) {
+		return nil, nil
+	}
+
+	return repo.user, nil
+}
+
+func (repo *stubUserRepository) Count(_ context.Context, params *userQuery) (uint64, error) {
+	repo.listed = params
+	return 1, nil
+}
+
+func (repo *stubUserRepository) GetAll(_ context.Context, params *userQuery) ([]*UserType, error) {
+	return []*UserType{repo.user}, nil
+}
+
+func (repo *stubUserRepository) Store(_ context.Context, payload *UserType) error {
+	repo.created = payload
+	return nil
+}
+
+func (repo *stubUserRepository) StoreSession(_ context.Context, payload *SessionType) error {
+	payload.ID = 7
+	repo.stored = payload
+	return nil
+}
+
+func (repo *stubUserRepository) GetOneSession(context.Context, *sessionQuery) (*SessionType, error) {
+	return repo.session, nil
+}
+
+func (repo *stubUserRepository) RotateSession(_ context.Context, refreshHash string, payload *SessionType) error {
+	repo.rotateOf = refreshHash
+	if repo.session == nil {
+		return errs.ErrInvalidCred
+	}
+
+	payload.ID, payload.UserID = repo.session.ID, repo.session.UserID
+	return nil
+}
+
+func (repo *stubUserRepository) RevokeSession(_ context.Context, params *sessionQuery) error {
+	repo.revoked = params
+	return nil
+}
+
+func newStubUser(t *testing.T) *UserType {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cr3t-pa55"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	return &UserType{ID: 1, OrganizationID: 1, Username: "budi", PasswordHash: string(hash)}
+}
+
+func TestShouldLoginAndAuthenticateIssuedToken(t *testing.T) {
+	repo := &stubUserRepository{user: newStubUser(t)}
+	svc := NewService(repo, &auth.JWTConfig{Secret: "secret"})
+
+	res, err := svc.Login(context.Background(), &LoginPayload{Username: "budi", Password: "s3cr3t-pa55"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if res.TokenType != TokenTypeBearer || res.ExpiresIn != int64(auth.DefaultAccessTTL/time.Second) || res.RefreshToken == "" {
+		t.Errorf("unexpected token: %+v", res)
+	}
+
+	if repo.stored == nil || repo.stored.RefreshHash != hashToken(res.RefreshToken) {
+		t.Errorf("refresh token should be stored hashed, got: %+v", repo.stored)
+	}
+
+	repo.session = repo.stored
+
+	identity, err := svc.Authenticate(context.Background(), res.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if identity.UserID != 1 || identity.Username != "budi" || identity.SessionID != 7 || identity.OrganizationID != 1 {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+}
+
+func TestShouldNOTLoginWithInvalidCredential(t *testing.T) {
+	svc := NewService(&stubUserRepository{user: newStubUser(t)}, &auth.JWTConfig{Secret: "secret"})
+
+	for _, payload := range []*LoginPayload{
+		{Username: "budi", Password: "wrong-password"},
+		{Username: "andi", Password: "s3cr3t-pa55"},
+	} {
+		if _, err := svc.Login(context.Background(), payload); err != errs.ErrInvalidCred {
+			t.Errorf("expected ErrInvalidCred for %s, got: %v", payload.Username, err)
+		}
+	}
+}
+
+func TestShouldNOTAuthenticateInvalidToken(t *testing.T) {
+	repo := &stubUserRepository{session: &SessionType{ID: 7, UserID: 1}}
+	svc := NewService(repo, &auth.JWTConfig{Secret: "secret"})
+
+	sign := func(secret string, expiresAt time.Time) string {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &accessClaims{
+			Username:       "budi",
+			SessionID:      7,
+			Organization:   1,
+			StandardClaims: jwt.StandardClaims{Subject: "1", ExpiresAt: expiresAt.Unix()},
+		}).SignedString([]byte(secret))
+		return token
+	}
+
+	// issued before tenancy
+	tenantless, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &accessClaims{
+		Username:       "budi",
+		SessionID:      7,
+		StandardClaims: jwt.StandardClaims{Subject: "1", ExpiresAt: time.Now().Add(time.Minute).Unix()},
+	}).SignedString([]byte("secret"))
+
+	tokens := map[string]string{
+		"malformed":    "not-a-jwt",
+		"wrong secret": sign("other-secret", time.Now().Add(time.Minute)),
+		"expired":      sign("secret", time.Now().Add(-time.Minute)),
+		"tenantless":   tenantless,
+	}
+
+	for name, token := range tokens {
+		if _, err := svc.Authenticate(context.Background(), token); err != errs.ErrInvalidCred {
+			t.Errorf("expected ErrInvalidCred for %s token, got: %v", name, err)
+		}
+	}
+
+	// session ended by logout
+	repo.session = nil
+	if _, err := svc.Authenticate(context.Background(), sign("secret", time.Now().Add(time.Minute))); err != errs.ErrInvalidCred {
+		t.Errorf("expected ErrInvalidCred for revoked session, got: %v", err)
+	}
+}
+
+func TestShouldRotateRefreshToken(t *testing.T) {
+	repo := &stubUserRepository{user: newStubUser(t), session: &SessionType{ID: 7, UserID: 1}}
+	svc := NewService(repo, &auth.JWTConfig{Secret: "secret"})
+
+	res, err := svc.Refresh(context.Background(), &RefreshPayload{RefreshToken: "old-token"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.rotateOf != hashToken("old-token") {
+		t.Errorf("session should be looked up by hashed refresh token, got: %s", repo.rotateOf)
+	}
+
+	if res.RefreshToken == "" || res.RefreshToken == "old-token" {
+		t.Errorf("refresh token should be replaced, got: %s", res.RefreshToken)
+	}
+}
+
+func TestShouldLogoutCurrentSessionOnly(t *testing.T) {
+	repo := &stubUserRepository{}
+	svc := NewService(repo, &auth.JWTConfig{Secret: "secret"})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, SessionID: 7})
+
+	if err := svc.Logout(ctx, &LogoutPayload{}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.revoked.ID != 7 || repo.revoked.UserID != 1 {
+		t.Errorf("unexpected revocation: %+v", repo.revoked)
+	}
+
+	if err := svc.Logout(ctx, &LogoutPayload{All: true}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.revoked.ID != 0 || repo.revoked.UserID != 1 {
+		t.Errorf("unexpected revocation: %+v", repo.revoked)
+	}
+}
+
+func TestShouldNOTCreateUserWithShortPassword(t *testing.T) {
+	svc := NewService(&stubUserRepository{}, &auth.JWTConfig{Secret: "secret"})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, Admin: true})
+
+	if err := svc.Create(ctx, &UserPayload{Username: "budi", Password: "short"}); err != errs.ErrBadRequest {
+		t.Errorf("expected ErrBadRequest, got: %v", err)
+	}
+}
+
+func TestShouldNOTCreateUserByNonAdmin(t *testing.T) {
+	svc := NewService(&stubUserRepository{}, &auth.JWTConfig{Secret: "secret"})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 2})
+
+	if err := svc.Create(ctx, &UserPayload{Username: "andi", Password: "s3cr3t-pa55"}); err != errs.ErrNoAccess {
+		t.Errorf("expected ErrNoAccess, got: %v", err)
+	}
+}
+
+func TestShouldCreateUserWithinOrganizationOfCreator(t *testing.T) {
+	repo := &stubUserRepository{}
+	svc := NewService(repo, &auth.JWTConfig{Secret: "secret"})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, Admin: true, OrganizationID: 3})
+
+	if err := svc.Create(ctx, &UserPayload{Username: "andi", Password: "s3cr3t-pa55"}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.created.OrganizationID != 3 {
+		t.Errorf("expected user to join organization 3, got: %d", repo.created.OrganizationID)
+	}
+}
+
+func TestShouldNOTManageUserOfAnotherOrganizationByAdmin(t *testing.T) {
+	repo := &stubUserRepository{user: &UserType{ID: 5, OrganizationID: 2, Username: "andi"}}
+	svc := NewService(repo, &auth.JWTConfig{Secret: "secret"})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, Admin: true, OrganizationID: 1})
+
+	// organization of the payload is ignored, the user joins organization of the admin instead
+	if err := svc.Create(ctx, &UserPayload{Username: "citra", Password: "s3cr3t-pa55", OrganizationID: 2}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.created.OrganizationID != 1 {
+		t.Errorf("expected user to join organization 1, got: %d", repo.created.OrganizationID)
+	}
+
+	if _, err := svc.GetAll(ctx, &UserRequestQuery{}); err != nil || repo.listed.OrganizationID != 1 {
+		t.Errorf("expected listing scoped to organization 1, got: %+v, err: %v", repo.listed, err)
+	}
+
+	if _, err := svc.GetOne(ctx, &UserRequestQuery{ID: 5}); err != errs.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestShouldCreateUserWithinAnotherOrganizationBySuperadmin(t *testing.T) {
+	repo := &stubUserRepository{}
+	svc := NewService(repo, &auth.JWTConfig{Secret: "secret"})
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: 1, Admin: true, Superadmin: true, OrganizationID: 1})
+
+	if err := svc.Create(ctx, &UserPayload{Username: "citra", Password: "s3cr3t-pa55", OrganizationID: 2}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if repo.created.OrganizationID != 2 {
+		t.Errorf("expected user to join organization 2, got: %d", repo.created.OrganizationID)
+	}
+}
@@ -0,0 +1,33 @@
+package waterquality
+
+import "github.com/labstack/echo/v4"
+
+type ReadingController struct {
+	svc ReadingService
+}
+
+func NewController(svc ReadingService) *ReadingController {
+	return &ReadingController{
+		svc: svc,
+	}
+}
+
+const (
+	readingBasepath = "/farms/:farmID/ponds/:pondID/readings"
+	readingIDPath   = "/:readingID"
+)
+
+func (rc *ReadingController) Route(grp *echo.Group) {
+	subrouter := grp.Group(readingBasepath)
+
+	subrouter.GET("", HandleGetAllReading(rc.svc.GetAll))
+	subrouter.OPTIONS("", HandleGetAllReading(rc.svc.GetAll))
+	subrouter.GET(readingIDPath, HandleGetOneReading(rc.svc.GetOne))
+	subrouter.OPTIONS(readingIDPath, HandleGetOneReading(rc.svc.GetOne))
+	subrouter.POST("", HandleCreateReading(rc.svc.Create))
+	subrouter.OPTIONS("", HandleCreateReading(rc.svc.Create))
+	subrouter.DELETE(readingIDPath, HandleDeleteReading(rc.svc.Delete))
+	subrouter.OPTIONS(readingIDPath, HandleDeleteReading(rc.svc.Delete))
+
+	return
+}
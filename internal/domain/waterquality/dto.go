@@ -0,0 +1,52 @@
+package waterquality
+
+import (
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+)
+
+// ReadingRequestQuery represent query parameters fetch from request
+type ReadingRequestQuery struct {
+	ID     int64     `param:"readingID" example:"1"`
+	FarmID int64     `param:"farmID" example:"1"`
+	PondID int64     `param:"pondID" example:"1"`
+	From   time.Time `query:"from" example:"2024-07-01T00:00:00Z"`
+	To     time.Time `query:"to" example:"2024-07-02T00:00:00Z"`
+	Limit  uint64    `query:"limit" example:"100"`
+	Page   uint64    `query:"page" example:"2"`
+}
+
+// ReadingPayload represent payload fetch from request body
+type ReadingPayload struct {
+	FarmID          int64     `param:"farmID" json:"-" example:"1"`
+	PondID          int64     `param:"pondID" json:"-" example:"1"`
+	DissolvedOxygen *float64  `json:"dissolved_oxygen" example:"5.2"`
+	PH              *float64  `json:"ph" example:"7.4"`
+	Temperature     *float64  `json:"temperature" example:"28.5"`
+	Salinity        *float64  `json:"salinity" example:"15"`
+	Ammonia         *float64  `json:"ammonia" example:"0.02"`
+	Nitrite         *float64  `json:"nitrite" example:"0.1"`
+	Turbidity       *float64  `json:"turbidity" example:"30"`
+	MeasuredAt      time.Time `json:"measured_at" example:"2024-07-01T06:00:00Z"`
+}
+
+// ReadingResponse represent domain response for Water Quality Reading entity
+type ReadingResponse struct {
+	ID              int64     `json:"id" example:"1"`
+	PondID          int64     `json:"pond_id" example:"1"`
+	DissolvedOxygen *float64  `json:"dissolved_oxygen" example:"5.2"`
+	PH              *float64  `json:"ph" example:"7.4"`
+	Temperature     *float64  `json:"temperature" example:"28.5"`
+	Salinity        *float64  `json:"salinity" example:"15"`
+	Ammonia         *float64  `json:"ammonia" example:"0.02"`
+	Nitrite         *float64  `json:"nitrite" example:"0.1"`
+	Turbidity       *float64  `json:"turbidity" example:"30"`
+	MeasuredAt      time.Time `json:"measured_at" example:"2024-07-01T06:00:00Z"`
+}
+
+// ListReadingResponse represent domain response for bulk Water Quality Reading entities
+type ListReadingResponse struct {
+	Readings []*ReadingResponse     `json:"readings"`
+	Meta     httpres.ListPagination `json:"meta"`
+}
@@ -0,0 +1,159 @@
+package waterquality
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nmluci/da-farm-be/internal/core/httputil"
+	"github.com/rs/zerolog"
+)
+
+type GetAllReadingHandler func(context.Context, *ReadingRequestQuery) (*ListReadingResponse, error)
+
+// Get All Reading godoc
+//
+//	@Summary	get all water quality readings of a pond
+//	@Tags		Water Quality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Success	200		{object}	ListReadingResponse
+//	@Failure	400		{object}	httpres.ErrorResponse	"invalid time window"
+//	@Failure	404		{object}	httpres.ErrorResponse
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Param		farmID	path		int		true	"Farm ID"
+//	@Param		pondID	path		int		true	"Pond ID"
+//	@Param		from	query		string	false	"start of time window (inclusive), RFC3339"
+//	@Param		to		query		string	false	"end of time window (exclusive), RFC3339"
+//	@Param		limit	query		string	false	"number of entity per page"
+//	@Param		page	query		string	false	"n-th page"
+//	@Router		/farms/{farmID}/ponds/{pondID}/readings [get]
+func HandleGetAllReading(handler GetAllReadingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &ReadingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type GetOneReadingHandler func(context.Context, *ReadingRequestQuery) (*ReadingResponse, error)
+
+// Get One Reading godoc
+//
+//	@Summary	get specific water quality reading by ID
+//	@Tags		Water Quality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		readingID	path		int	true	"Reading ID"
+//	@Success	200			{object}	ReadingResponse
+//	@Failure	404			{object}	httpres.ErrorResponse
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/readings/{readingID} [get]
+func HandleGetOneReading(handler GetOneReadingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &ReadingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		data, err := handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, data)
+	}
+}
+
+type CreateReadingHandler func(context.Context, *ReadingPayload) error
+
+// CreateReading godoc
+//
+//	@Summary	record a new water quality reading
+//	@Tags		Water Quality
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID	path		int				true	"Farm ID"
+//	@Param		pondID	path		int				true	"Pond ID"
+//	@Param		payload	body		ReadingPayload	true	"reading payload"
+//	@Success	201		{object}	string
+//	@Failure	404		{object}	httpres.ErrorResponse	"pond not existed"
+//	@Failure	500		{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/readings [post]
+func HandleCreateReading(handler CreateReadingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &ReadingPayload{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusCreated, nil)
+	}
+}
+
+type DeleteReadingHandler func(context.Context, *ReadingRequestQuery) error
+
+// DeleteReading godoc
+//
+//	@Summary	delete specific water quality reading by ID
+//	@Tags		Water Quality
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		farmID		path		int	true	"Farm ID"
+//	@Param		pondID		path		int	true	"Pond ID"
+//	@Param		readingID	path		int	true	"Reading ID"
+//	@Success	200			{object}	string
+//	@Failure	404			{object}	httpres.ErrorResponse	"reading not existed"
+//	@Failure	500			{object}	httpres.ErrorResponse
+//	@Router		/farms/{farmID}/ponds/{pondID}/readings/{readingID} [delete]
+func HandleDeleteReading(handler DeleteReadingHandler) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		ctx := c.Request().Context()
+		logger := zerolog.Ctx(ctx)
+		params := &ReadingRequestQuery{}
+
+		if err = c.Bind(params); err != nil {
+			logger.Err(err).Send()
+			return httputil.WriteErrorResponseWithStatus(c, http.StatusBadRequest, err)
+		}
+
+		err = handler(ctx, params)
+		if err != nil {
+			return httputil.WriteErrorResponse(c, err)
+		}
+
+		return httputil.WriteSuccessResponse(c, http.StatusOK, nil)
+	}
+}
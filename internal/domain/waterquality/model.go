@@ -0,0 +1,16 @@
+package waterquality
+
+import "time"
+
+type ReadingType struct {
+	ID              int64     `db:"id"`
+	PondID          int64     `db:"pond_id"`
+	DissolvedOxygen *float64  `db:"dissolved_oxygen"`
+	PH              *float64  `db:"ph"`
+	Temperature     *float64  `db:"temperature"`
+	Salinity        *float64  `db:"salinity"`
+	Ammonia         *float64  `db:"ammonia"`
+	Nitrite         *float64  `db:"nitrite"`
+	Turbidity       *float64  `db:"turbidity"`
+	MeasuredAt      time.Time `db:"measured_at"`
+}
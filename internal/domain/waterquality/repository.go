@@ -0,0 +1,217 @@
+package waterquality
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/rs/zerolog"
+)
+
+// ReadingRepository contain contract that defined all necessary public function available to be interact with
+type ReadingRepository interface {
+	GetAll(context.Context, *readingQuery) ([]*ReadingType, error)
+	Count(context.Context, *readingQuery) (uint64, error)
+	GetOne(context.Context, *readingQuery) (*ReadingType, error)
+	Store(context.Context, int64, *ReadingType) error
+	Delete(context.Context, *readingQuery) error
+}
+
+type readingRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository return an instance of readingRepository containing interface to DB layer
+func NewRepository(db *sqlx.DB) ReadingRepository {
+	return &readingRepository{db: db}
+}
+
+type readingQuery struct {
+	ID, FarmID, PondID int64
+	From, To           time.Time
+	Limit, Page        uint64
+}
+
+var pgSquirrel = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+var readingColumns = []string{
+	"r.id", "r.pond_id", "r.dissolved_oxygen", "r.ph", "r.temperature", "r.salinity",
+	"r.ammonia", "r.nitrite", "r.turbidity", "r.measured_at",
+}
+
+// filter build the where clause shared by both listing and counting readings of a pond
+func (params *readingQuery) filter() squirrel.And {
+	cond := squirrel.And{
+		squirrel.Eq{"r.pond_id": params.PondID},
+		squirrel.Eq{"p.farm_id": params.FarmID},
+		squirrel.Eq{"p.deleted_at": nil},
+		squirrel.Eq{"r.deleted_at": nil},
+	}
+
+	if !params.From.IsZero() {
+		cond = append(cond, squirrel.GtOrEq{"r.measured_at": params.From})
+	}
+
+	if !params.To.IsZero() {
+		cond = append(cond, squirrel.Lt{"r.measured_at": params.To})
+	}
+
+	return cond
+}
+
+func (repo *readingRepository) GetAll(ctx context.Context, params *readingQuery) (res []*ReadingType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(readingColumns...).From("water_quality_readings r").
+		Join("ponds p on r.pond_id = p.id").
+		Where(params.filter()).
+		OrderBy("r.measured_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).ToSql()
+
+	res = []*ReadingType{}
+
+	rows, err := repo.db.QueryxContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		col := &ReadingType{}
+
+		if err = rows.StructScan(col); err != nil {
+			logger.Error().Err(err).Msg("failed to map row")
+			return
+		}
+
+		res = append(res, col)
+	}
+
+	return
+}
+
+func (repo *readingRepository) Count(ctx context.Context, params *readingQuery) (res uint64, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select("count(*)").From("water_quality_readings r").
+		Join("ponds p on r.pond_id = p.id").
+		Where(params.filter()).ToSql()
+
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).Scan(&res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+func (repo *readingRepository) GetOne(ctx context.Context, params *readingQuery) (res *ReadingType, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Select(readingColumns...).From("water_quality_readings r").
+		Join("ponds p on r.pond_id = p.id").
+		Where(squirrel.And{
+			squirrel.Eq{"r.id": params.ID},
+			squirrel.Eq{"r.pond_id": params.PondID},
+			squirrel.Eq{"p.farm_id": params.FarmID},
+			squirrel.Eq{"p.deleted_at": nil},
+			squirrel.Eq{"r.deleted_at": nil},
+		}).ToSql()
+
+	res = &ReadingType{}
+	err = repo.db.QueryRowxContext(ctx, stmt, args...).StructScan(res)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error().Err(err).Msg("failed to fetch data")
+		return
+	} else if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return
+}
+
+// Store save a new reading for given pond, the generated ID will be assigned back into payload
+func (repo *readingRepository) Store(ctx context.Context, farmID int64, payload *ReadingType) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var stmt string
+	var args []any
+	var count int64
+
+	// check for pond existence within the farm
+	stmt, args, _ = pgSquirrel.Select("count(*)").From("ponds").Where(squirrel.And{
+		squirrel.Eq{"id": payload.PondID},
+		squirrel.Eq{"farm_id": farmID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&count); err != nil && err != sql.ErrNoRows { // make sure it's not an err from non-existing result
+		logger.Error().Err(err).Msg("failed to validate pond data existence")
+		return
+	}
+
+	// if selected pond doesn't exists, bail out from here
+	if count == 0 {
+		return errs.ErrNotFound
+	}
+
+	stmt, args, _ = pgSquirrel.Insert("water_quality_readings").
+		Columns("pond_id", "dissolved_oxygen", "ph", "temperature", "salinity", "ammonia", "nitrite", "turbidity", "measured_at").
+		Values(payload.PondID, payload.DissolvedOxygen, payload.PH, payload.Temperature, payload.Salinity,
+			payload.Ammonia, payload.Nitrite, payload.Turbidity, payload.MeasuredAt).
+		Suffix("RETURNING id").ToSql()
+
+	if err = tx.QueryRowxContext(ctx, stmt, args...).Scan(&payload.ID); err != nil {
+		logger.Error().Err(err).Msg("failed to save data")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error().Err(err).Msg("failed to commit transaction")
+		return
+	}
+
+	return
+}
+
+func (repo *readingRepository) Delete(ctx context.Context, params *readingQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	stmt, args, _ := pgSquirrel.Update("water_quality_readings").SetMap(map[string]interface{}{
+		"updated_at": squirrel.Expr("NOW()"),
+		"deleted_at": squirrel.Expr("NOW()"),
+	}).Where(squirrel.And{
+		squirrel.Eq{"id": params.ID},
+		squirrel.Eq{"pond_id": params.PondID},
+		squirrel.Eq{"deleted_at": nil},
+	}).ToSql()
+
+	res, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete data")
+		return
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = errs.ErrNotFound
+		logger.Error().Err(err).Msg("reading doesn't exists")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,160 @@
+package waterquality
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestShouldGetReadingWithResult(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	readingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "pond_id", "dissolved_oxygen", "ph", "temperature", "salinity", "ammonia", "nitrite", "turbidity", "measured_at"}).
+		AddRow(1, 1, 5.2, 7.4, 28.5, 15, 0.02, 0.1, 30, time.Now()).
+		AddRow(2, 1, 4.8, nil, nil, nil, nil, nil, nil, time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT r.id, r.pond_id, r.dissolved_oxygen, r.ph, r.temperature, r.salinity, r.ammonia, r.nitrite, r.turbidity, r.measured_at FROM water_quality_readings r JOIN ponds p on r.pond_id = p.id WHERE (r.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND r.deleted_at IS NULL) ORDER BY r.measured_at desc LIMIT 100 OFFSET 0")).
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	readingRepo.GetAll(context.Background(), &readingQuery{FarmID: 1, PondID: 1, Limit: 100, Page: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldCountReadingWithinTimeWindow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	readingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"count(*)"}).AddRow(24)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM water_quality_readings r JOIN ponds p on r.pond_id = p.id WHERE (r.pond_id = $1 AND p.farm_id = $2 AND p.deleted_at IS NULL AND r.deleted_at IS NULL AND r.measured_at >= $3 AND r.measured_at < $4)")).
+		WithArgs(1, 1, from, to).
+		WillReturnRows(rows)
+
+	readingRepo.Count(context.Background(), &readingQuery{FarmID: 1, PondID: 1, From: from, To: to})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTGetReading(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	readingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	// expected queries
+	rows := sqlmock.NewRows([]string{"id", "pond_id", "dissolved_oxygen", "ph", "temperature", "salinity", "ammonia", "nitrite", "turbidity", "measured_at"})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT r.id, r.pond_id, r.dissolved_oxygen, r.ph, r.temperature, r.salinity, r.ammonia, r.nitrite, r.turbidity, r.measured_at FROM water_quality_readings r JOIN ponds p on r.pond_id = p.id WHERE (r.id = $1 AND r.pond_id = $2 AND p.farm_id = $3 AND p.deleted_at IS NULL AND r.deleted_at IS NULL)")).
+		WithArgs(1, 1, 1).
+		WillReturnRows(rows)
+
+	res, err := readingRepo.GetOne(context.Background(), &readingQuery{ID: 1, FarmID: 1, PondID: 1})
+	if err != nil || res != nil {
+		t.Errorf("expected empty result, got %+v, err: %s", res, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldStoreReading(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	readingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	do := 5.2
+	measuredAt := time.Date(2024, 7, 1, 6, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO water_quality_readings (pond_id,dissolved_oxygen,ph,temperature,salinity,ammonia,nitrite,turbidity,measured_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id")).
+		WithArgs(1, &do, nil, nil, nil, nil, nil, nil, measuredAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	mock.ExpectCommit()
+
+	payload := &ReadingType{PondID: 1, DissolvedOxygen: &do, MeasuredAt: measuredAt}
+	readingRepo.Store(context.Background(), 1, payload)
+
+	if payload.ID != 10 {
+		t.Errorf("expected generated id to be assigned, got %d", payload.ID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldNOTStoreReadingDuePondNotExisted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	readingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM ponds WHERE (id = $1 AND farm_id = $2 AND deleted_at IS NULL)")).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectRollback()
+
+	readingRepo.Store(context.Background(), 1, &ReadingType{PondID: 1, MeasuredAt: time.Now()})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldDeleteReading(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub connection, err: %s", err)
+	}
+	defer db.Close()
+
+	readingRepo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE water_quality_readings SET deleted_at = NOW(), updated_at = NOW() WHERE (id = $1 AND pond_id = $2 AND deleted_at IS NULL)")).WithArgs(1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	readingRepo.Delete(context.Background(), &readingQuery{ID: 1, PondID: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%s", err)
+	}
+}
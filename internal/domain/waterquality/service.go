@@ -0,0 +1,192 @@
+package waterquality
+
+import (
+	"context"
+	"time"
+
+	"github.com/nmluci/da-farm-be/internal/core/errs"
+	"github.com/nmluci/da-farm-be/internal/core/httpres"
+	"github.com/rs/zerolog"
+)
+
+// ReadingService contains public API available to be interacted with
+type ReadingService interface {
+	GetAll(context.Context, *ReadingRequestQuery) (*ListReadingResponse, error)
+	GetOne(context.Context, *ReadingRequestQuery) (*ReadingResponse, error)
+	Create(context.Context, *ReadingPayload) error
+	Delete(context.Context, *ReadingRequestQuery) error
+}
+
+// ReadingEvaluator inspect every newly stored reading, ex: to raise an alert on threshold breach
+type ReadingEvaluator interface {
+	EvaluateReading(context.Context, int64, *ReadingResponse) error
+}
+
+type readingService struct {
+	repo      ReadingRepository
+	evaluator ReadingEvaluator
+}
+
+// NewService return an instance of ReadingService containing available usecases
+func NewService(repo ReadingRepository, evaluator ReadingEvaluator) ReadingService {
+	return &readingService{repo: repo, evaluator: evaluator}
+}
+
+func (svc *readingService) GetAll(ctx context.Context, params *ReadingRequestQuery) (res *ListReadingResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &readingQuery{
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+		From:   params.From,
+		To:     params.To,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+
+	if params.Limit >= 100 || params.Limit <= 0 {
+		repoParams.Limit = 100
+	}
+
+	if params.Page <= 0 {
+		repoParams.Page = 1
+	}
+
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return nil, errs.ErrBadRequest
+	}
+
+	res = &ListReadingResponse{
+		Readings: []*ReadingResponse{},
+		Meta: httpres.ListPagination{
+			Limit:     repoParams.Limit,
+			Page:      repoParams.Page,
+			TotalPage: 0,
+		},
+	}
+
+	count, err := svc.repo.Count(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if count == 0 {
+		return nil, errs.ErrNotFound
+	}
+	res.Meta.TotalPage = (count + repoParams.Limit - 1) / repoParams.Limit
+
+	readings, err := svc.repo.GetAll(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	for _, reading := range readings {
+		res.Readings = append(res.Readings, toReadingResponse(reading))
+	}
+
+	return
+}
+
+func (svc *readingService) GetOne(ctx context.Context, params *ReadingRequestQuery) (res *ReadingResponse, err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &readingQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	reading, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if reading == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return toReadingResponse(reading), nil
+}
+
+func (svc *readingService) Create(ctx context.Context, payload *ReadingPayload) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	data := &ReadingType{
+		PondID:          payload.PondID,
+		DissolvedOxygen: payload.DissolvedOxygen,
+		PH:              payload.PH,
+		Temperature:     payload.Temperature,
+		Salinity:        payload.Salinity,
+		Ammonia:         payload.Ammonia,
+		Nitrite:         payload.Nitrite,
+		Turbidity:       payload.Turbidity,
+		MeasuredAt:      payload.MeasuredAt,
+	}
+
+	// reading without explicit timestamp is assumed to be taken right now
+	if data.MeasuredAt.IsZero() {
+		data.MeasuredAt = time.Now()
+	}
+
+	err = svc.repo.Store(ctx, payload.FarmID, data)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	// reading is already persisted, failing evaluation shouldn't reject it
+	if svc.evaluator != nil {
+		if evalErr := svc.evaluator.EvaluateReading(ctx, payload.FarmID, toReadingResponse(data)); evalErr != nil {
+			logger.Warn().Err(evalErr).Msg("failed to evaluate reading")
+		}
+	}
+
+	return
+}
+
+func (svc *readingService) Delete(ctx context.Context, params *ReadingRequestQuery) (err error) {
+	logger := zerolog.Ctx(ctx)
+
+	repoParams := &readingQuery{
+		ID:     params.ID,
+		FarmID: params.FarmID,
+		PondID: params.PondID,
+	}
+
+	// make sure the reading belongs to requested farm and pond
+	reading, err := svc.repo.GetOne(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	if reading == nil {
+		return errs.ErrNotFound
+	}
+
+	err = svc.repo.Delete(ctx, repoParams)
+	if err != nil {
+		logger.Error().Err(err).Send()
+		return
+	}
+
+	return
+}
+
+func toReadingResponse(reading *ReadingType) *ReadingResponse {
+	return &ReadingResponse{
+		ID:              reading.ID,
+		PondID:          reading.PondID,
+		DissolvedOxygen: reading.DissolvedOxygen,
+		PH:              reading.PH,
+		Temperature:     reading.Temperature,
+		Salinity:        reading.Salinity,
+		Ammonia:         reading.Ammonia,
+		Nitrite:         reading.Nitrite,
+		Turbidity:       reading.Turbidity,
+		MeasuredAt:      reading.MeasuredAt,
+	}
+}
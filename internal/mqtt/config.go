@@ -0,0 +1,8 @@
+package mqtt
+
+type MQTTConfig struct {
+	Broker   string // ex: tcp://localhost:1883, subscriber is disabled when empty
+	ClientID string
+	Username string
+	Password string
+}
@@ -0,0 +1,134 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/google/uuid"
+	"github.com/nmluci/da-farm-be/internal/domain/devices"
+	"github.com/rs/zerolog"
+)
+
+// SensorTopic is the topic filter of every sensor reading, ex: farm/1/pond/2/sensor/dissolved_oxygen
+const SensorTopic = "farm/+/pond/+/sensor/+"
+
+const (
+	qos            = 1 // at least once, duplicated reading is dropped by the storage
+	connectTimeout = 10 * time.Second
+	ingestTimeout  = 30 * time.Second
+)
+
+var ErrInvalidTopic = errors.New("invalid sensor topic")
+
+// Subscriber persist sensor readings published into the broker
+type Subscriber struct {
+	client paho.Client
+	svc    devices.DeviceService
+	logger zerolog.Logger
+}
+
+// New return a Subscriber connected into the broker, subscription is restored on every reconnect
+func New(logger zerolog.Logger, conf *MQTTConfig, svc devices.DeviceService) (sub *Subscriber, err error) {
+	sub = &Subscriber{
+		svc:    svc,
+		logger: logger.With().Str("component", "mqtt").Logger(),
+	}
+
+	clientID := conf.ClientID
+	if clientID == "" {
+		clientID = "da-farm-be-" + uuid.NewString()
+	}
+
+	opts := paho.NewClientOptions().
+		AddBroker(conf.Broker).
+		SetClientID(clientID).
+		SetUsername(conf.Username).
+		SetPassword(conf.Password).
+		SetAutoReconnect(true).
+		SetOnConnectHandler(sub.subscribe).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			sub.logger.Warn().Err(err).Msg("connection to broker lost")
+		})
+
+	sub.client = paho.NewClient(opts)
+
+	token := sub.client.Connect()
+	if !token.WaitTimeout(connectTimeout) {
+		err = errors.New("timeout connecting to broker")
+		sub.logger.Error().Err(err).Send()
+		return nil, err
+	}
+
+	if err = token.Error(); err != nil {
+		sub.logger.Error().Err(err).Msg("failed to connect to broker")
+		return nil, err
+	}
+
+	return
+}
+
+// Close disconnect from the broker, waiting for in-flight message to be handled
+func (sub *Subscriber) Close() {
+	sub.client.Disconnect(250)
+}
+
+func (sub *Subscriber) subscribe(client paho.Client) {
+	token := client.Subscribe(SensorTopic, qos, sub.handle)
+	if token.Wait() && token.Error() != nil {
+		sub.logger.Error().Err(token.Error()).Msg("failed to subscribe")
+		return
+	}
+
+	sub.logger.Info().Msgf("subscribed to %s", SensorTopic)
+}
+
+func (sub *Subscriber) handle(_ paho.Client, msg paho.Message) {
+	logger := sub.logger.With().Str("topic", msg.Topic()).Logger()
+
+	ctx, cancel := context.WithTimeout(logger.WithContext(context.Background()), ingestTimeout)
+	defer cancel()
+
+	payload := &devices.TopicIngestPayload{}
+
+	var err error
+	if payload.FarmID, payload.PondID, payload.Type, err = ParseTopic(msg.Topic()); err != nil {
+		logger.Warn().Err(err).Msg("message dropped")
+		return
+	}
+
+	if err = json.Unmarshal(msg.Payload(), payload); err != nil {
+		logger.Warn().Err(err).Msg("message dropped, malformed payload")
+		return
+	}
+
+	res, err := sub.svc.IngestTopic(ctx, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to ingest readings")
+		return
+	}
+
+	logger.Debug().Int64("received", res.Received).Int64("stored", res.Stored).Send()
+}
+
+// ParseTopic extract farm ID, pond ID and sensor type from farm/{farmID}/pond/{pondID}/sensor/{type}
+func ParseTopic(topic string) (farmID, pondID int64, sensorType string, err error) {
+	parts := strings.Split(topic, "/")
+	if len(parts) != 6 || parts[0] != "farm" || parts[2] != "pond" || parts[4] != "sensor" || parts[5] == "" {
+		return 0, 0, "", ErrInvalidTopic
+	}
+
+	if farmID, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return 0, 0, "", ErrInvalidTopic
+	}
+
+	if pondID, err = strconv.ParseInt(parts[3], 10, 64); err != nil {
+		return 0, 0, "", ErrInvalidTopic
+	}
+
+	return farmID, pondID, parts[5], nil
+}
@@ -0,0 +1,77 @@
+package mqtt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/nmluci/da-farm-be/internal/domain/devices"
+	"github.com/rs/zerolog"
+)
+
+type stubDeviceService struct {
+	devices.DeviceService
+	received chan *devices.TopicIngestPayload
+}
+
+func (svc *stubDeviceService) IngestTopic(_ context.Context, payload *devices.TopicIngestPayload) (*devices.IngestResponse, error) {
+	svc.received <- payload
+	return &devices.IngestResponse{Received: 1, Stored: 1}, nil
+}
+
+// startBroker run an in-process broker listening on random local port
+func startBroker(t *testing.T) (*mochi.Server, string) {
+	t.Helper()
+
+	server := mochi.New(&mochi.Options{InlineClient: true})
+	if err := server.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatalf("failed to add auth hook, err: %s", err)
+	}
+
+	tcp := listeners.NewTCP(listeners.Config{ID: "test", Address: "127.0.0.1:0"})
+	if err := server.AddListener(tcp); err != nil {
+		t.Fatalf("failed to add listener, err: %s", err)
+	}
+
+	go server.Serve()
+	t.Cleanup(func() { server.Close() })
+
+	return server, "tcp://" + tcp.Address()
+}
+
+func TestShouldIngestReadingFromTopic(t *testing.T) {
+	broker, address := startBroker(t)
+	svc := &stubDeviceService{received: make(chan *devices.TopicIngestPayload, 1)}
+
+	// retained, so it's delivered as soon as the subscription is established
+	err := broker.Publish("farm/1/pond/2/sensor/dissolved_oxygen", []byte(`{"key":"secret","value":5.35,"measured_at":"2024-08-05T06:00:00Z"}`), true, 1)
+	if err != nil {
+		t.Fatalf("failed to publish, err: %s", err)
+	}
+
+	sub, err := New(zerolog.Nop(), &MQTTConfig{Broker: address}, svc)
+	if err != nil {
+		t.Fatalf("failed to start subscriber, err: %s", err)
+	}
+	defer sub.Close()
+
+	select {
+	case payload := <-svc.received:
+		if payload.FarmID != 1 || payload.PondID != 2 || payload.Type != devices.TypeDissolvedOxygen || payload.Key != "secret" || *payload.Value != 5.35 {
+			t.Errorf("unexpected payload: %+v", payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reading wasn't received")
+	}
+}
+
+func TestShouldNOTParseMalformedTopic(t *testing.T) {
+	for _, topic := range []string{"farm/1/pond/2/sensor", "farm/a/pond/2/sensor/ph", "farm/1/ponds/2/sensor/ph", "farm/1/pond/2/sensor/"} {
+		if _, _, _, err := ParseTopic(topic); err != ErrInvalidTopic {
+			t.Errorf("expected invalid topic on %s, got: %v", topic, err)
+		}
+	}
+}
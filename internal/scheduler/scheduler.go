@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job is a unit of background work, error is logged and the job is retried on the next tick
+type Job func(context.Context) error
+
+// Every run the job right away and then on every interval until ctx is cancelled,
+// runs never overlap thus a slow run delays the next one
+func Every(ctx context.Context, logger zerolog.Logger, name string, interval time.Duration, job Job) {
+	logger = logger.With().Str("job", name).Logger()
+	ctx = logger.WithContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(ctx); err != nil {
+				logger.Error().Err(err).Msg("failed to run job")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
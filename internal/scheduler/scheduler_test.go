@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestShouldRunJobRepeatedly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs atomic.Int64
+	done := make(chan struct{})
+
+	Every(ctx, zerolog.Nop(), "test", 10*time.Millisecond, func(ctx context.Context) error {
+		if runs.Add(1) == 3 {
+			close(done)
+		}
+
+		// failing run shouldn't stop the schedule
+		return errors.New("failed")
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("job wasn't run repeatedly")
+	}
+}
+
+func TestShouldStopJobOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var runs atomic.Int64
+	Every(ctx, zerolog.Nop(), "test", 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	count := runs.Load()
+
+	time.Sleep(50 * time.Millisecond)
+	if runs.Load() != count {
+		t.Errorf("job still running after cancel")
+	}
+}
@@ -0,0 +1 @@
+drop table water_quality_readings;
\ No newline at end of file
@@ -0,0 +1,17 @@
+create table water_quality_readings (
+    id bigserial primary key,
+    pond_id bigint not null,
+    dissolved_oxygen real, -- dissolved oxygen in mg/L
+    ph real,
+    temperature real, -- water temperature in celsius
+    salinity real, -- salinity in ppt
+    ammonia real, -- total ammonia nitrogen in mg/L
+    nitrite real, -- nitrite in mg/L
+    turbidity real, -- turbidity in NTU
+    measured_at timestamp with time zone not null default now(),
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index water_quality_readings_pond_measured_idx on water_quality_readings (pond_id, measured_at);
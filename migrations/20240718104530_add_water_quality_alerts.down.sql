@@ -0,0 +1,2 @@
+drop table alerts;
+drop table alert_rules;
\ No newline at end of file
@@ -0,0 +1,34 @@
+create table alert_rules (
+    id bigserial primary key,
+    farm_id bigint not null,
+    pond_id bigint, -- null means the rule applies to every pond within the farm
+    name varchar(50) not null,
+    parameter varchar(30) not null, -- water quality parameter, ex: dissolved_oxygen, ph
+    min_value real, -- reading below this value is considered a breach
+    max_value real, -- reading above this value is considered a breach
+    is_active boolean not null default true,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index alert_rules_farm_pond_idx on alert_rules (farm_id, pond_id);
+
+create table alerts (
+    id bigserial primary key,
+    rule_id bigint not null,
+    farm_id bigint not null,
+    pond_id bigint not null,
+    reading_id bigint not null, -- reading which opened the alert
+    parameter varchar(30) not null,
+    value real not null,
+    status varchar(20) not null default 'open', -- open, acknowledged, resolved
+    opened_at timestamp with time zone not null default now(),
+    acknowledged_at timestamp with time zone,
+    resolved_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index alerts_farm_status_idx on alerts (farm_id, status);
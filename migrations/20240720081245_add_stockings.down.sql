@@ -0,0 +1 @@
+drop table stockings;
\ No newline at end of file
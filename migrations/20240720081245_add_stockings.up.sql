@@ -0,0 +1,16 @@
+create table stockings (
+    id bigserial primary key,
+    pond_id bigint not null,
+    batch_name varchar(50) not null, -- cohort name referenced by later pond events
+    species varchar(50) not null,
+    hatchery varchar(100) not null default '', -- source hatchery of the fry/fingerling
+    initial_count bigint not null, -- number of fish stocked
+    avg_weight real not null, -- average body weight at stocking in gram
+    cost numeric(14, 2) not null default 0,
+    stocked_at date not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index stockings_pond_idx on stockings (pond_id);
@@ -0,0 +1 @@
+drop table feedings;
\ No newline at end of file
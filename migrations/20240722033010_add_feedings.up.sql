@@ -0,0 +1,15 @@
+create table feedings (
+    id bigserial primary key,
+    pond_id bigint not null,
+    stocking_id bigint not null, -- batch being fed
+    feed_type varchar(50) not null, -- ex: starter, grower, finisher
+    brand varchar(50) not null default '',
+    quantity numeric(10, 3) not null, -- feed given in kg
+    fed_at timestamp with time zone not null default now(),
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index feedings_pond_fed_idx on feedings (pond_id, fed_at);
+create index feedings_stocking_idx on feedings (stocking_id);
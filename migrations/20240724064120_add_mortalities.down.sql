@@ -0,0 +1 @@
+drop table mortalities;
\ No newline at end of file
@@ -0,0 +1,13 @@
+create table mortalities (
+    id bigserial primary key,
+    pond_id bigint not null,
+    stocking_id bigint not null, -- batch where the dead fish belongs to
+    count bigint not null, -- number of dead fish found
+    cause varchar(100) not null default '', -- suspected cause, ex: low DO, disease
+    recorded_on date not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index mortalities_stocking_idx on mortalities (stocking_id, recorded_on);
@@ -0,0 +1,3 @@
+drop table harvests;
+
+alter table stockings drop column closed_at;
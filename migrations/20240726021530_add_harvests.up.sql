@@ -0,0 +1,20 @@
+alter table stockings add column closed_at date; -- filled once the batch is totally harvested
+
+create table harvests (
+    id bigserial primary key,
+    pond_id bigint not null,
+    stocking_id bigint not null, -- batch being harvested
+    type varchar(10) not null, -- partial or total, total harvest close out the batch
+    weight numeric(12, 3) not null, -- harvested biomass in kg
+    count bigint not null default 0, -- number of fish harvested
+    size_grade varchar(20) not null default '', -- ex: 50 (pcs/kg), A, B
+    buyer varchar(100) not null default '',
+    price_per_kg numeric(14, 2) not null default 0,
+    harvested_on date not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index harvests_stocking_idx on harvests (stocking_id);
+create index harvests_pond_idx on harvests (pond_id, harvested_on);
@@ -0,0 +1 @@
+drop table growth_samples;
@@ -0,0 +1,14 @@
+create table growth_samples (
+    id bigserial primary key,
+    pond_id bigint not null,
+    stocking_id bigint not null, -- batch being sampled
+    sample_size bigint not null, -- number of fish caught for sampling
+    total_weight real not null, -- total weight of sampled fish in gram
+    lengths jsonb not null default '[]', -- individual length of sampled fish in cm
+    sampled_at date not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index growth_samples_stocking_idx on growth_samples (stocking_id, sampled_at);
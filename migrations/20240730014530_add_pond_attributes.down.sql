@@ -0,0 +1,7 @@
+alter table ponds
+    drop column area,
+    drop column depth,
+    drop column volume,
+    drop column type,
+    drop column aeration_capacity,
+    drop column status;
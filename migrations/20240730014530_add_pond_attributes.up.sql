@@ -0,0 +1,7 @@
+alter table ponds
+    add column area real not null default 0, -- surface area in m2
+    add column depth real not null default 0, -- water depth in m
+    add column volume real not null default 0, -- water volume in m3
+    add column type varchar(20) not null default 'earthen', -- earthen, concrete, tarpaulin or ras
+    add column aeration_capacity real not null default 0, -- total installed aerator power in hp
+    add column status varchar(20) not null default 'idle'; -- idle, preparing, stocked, harvesting or maintenance
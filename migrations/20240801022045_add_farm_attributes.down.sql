@@ -0,0 +1,9 @@
+alter table farms
+    drop column latitude,
+    drop column longitude,
+    drop column address,
+    drop column region,
+    drop column timezone,
+    drop column owner_name,
+    drop column contact,
+    drop column metadata;
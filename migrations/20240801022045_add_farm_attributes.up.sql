@@ -0,0 +1,11 @@
+alter table farms
+    add column latitude double precision,
+    add column longitude double precision,
+    add column address text not null default '',
+    add column region varchar(50) not null default '', -- island or province used to group farms
+    add column timezone varchar(50) not null default 'Asia/Makassar', -- IANA timezone to interpret daily logs
+    add column owner_name varchar(100) not null default '',
+    add column contact varchar(100) not null default '', -- phone or email of the owner
+    add column metadata jsonb not null default '{}'; -- free-form attributes
+
+create index farms_region_idx on farms (region);
@@ -0,0 +1,8 @@
+drop index if exists farms_coordinate_idx;
+
+alter table ponds
+    drop column boundary;
+
+alter table farms
+    drop column area,
+    drop column boundary;
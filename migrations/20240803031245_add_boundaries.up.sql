@@ -0,0 +1,8 @@
+alter table farms
+    add column area real not null default 0, -- in m2
+    add column boundary jsonb; -- GeoJSON Polygon in WGS84
+
+alter table ponds
+    add column boundary jsonb; -- GeoJSON Polygon in WGS84
+
+create index farms_coordinate_idx on farms (latitude, longitude);
@@ -0,0 +1,2 @@
+drop table sensor_readings;
+drop table devices;
@@ -0,0 +1,31 @@
+create table devices (
+    id bigserial primary key,
+    farm_id bigint not null,
+    pond_id bigint not null, -- pond where the device is currently installed
+    sensor_id varchar(64) not null, -- serial number reported by the device
+    type varchar(30) not null, -- measured parameter, ex: dissolved_oxygen, aerator_power
+    calibration_offset real not null default 0, -- added into every raw value
+    key_hash varchar(64) not null, -- sha256 of the device key
+    last_seen_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create unique index devices_sensor_id_idx on devices (sensor_id) where deleted_at is null;
+create index devices_farm_pond_idx on devices (farm_id, pond_id);
+
+-- append-only, thus no surrogate key nor soft delete
+create table sensor_readings (
+    device_id bigint not null,
+    pond_id bigint not null, -- pond the device was bound to at the time of measurement
+    type varchar(30) not null,
+    value real not null, -- calibrated value
+    raw_value real not null, -- value as reported by the device
+    measured_at timestamp with time zone not null,
+    received_at timestamp with time zone not null default now(),
+    primary key (device_id, measured_at) -- resent batch is deduplicated
+);
+
+create index sensor_readings_pond_type_measured_idx on sensor_readings (pond_id, type, measured_at desc);
+create index sensor_readings_measured_brin_idx on sensor_readings using brin (measured_at);
@@ -0,0 +1,4 @@
+drop table sensor_rollup_watermarks;
+drop table sensor_readings_1d;
+drop table sensor_readings_1h;
+drop table sensor_readings_5m;
@@ -0,0 +1,22 @@
+create table sensor_readings_5m (
+    device_id bigint not null,
+    pond_id bigint not null,
+    type varchar(30) not null,
+    bucket timestamp with time zone not null, -- start of the bucket
+    min_value real not null,
+    avg_value real not null,
+    max_value real not null,
+    count bigint not null, -- number of raw readings within the bucket, used to weight coarser average
+    primary key (device_id, pond_id, type, bucket)
+);
+
+create index sensor_readings_5m_pond_type_bucket_idx on sensor_readings_5m (pond_id, type, bucket);
+
+create table sensor_readings_1h (like sensor_readings_5m including all);
+create table sensor_readings_1d (like sensor_readings_5m including all); -- bucket starts at midnight of farm's timezone
+
+-- track how far each resolution has been rolled up
+create table sensor_rollup_watermarks (
+    resolution varchar(10) primary key,
+    rolled_until timestamp with time zone not null
+);
@@ -0,0 +1,2 @@
+drop table equipment_runtimes;
+drop table equipments;
@@ -0,0 +1,29 @@
+create table equipments (
+    id bigserial primary key,
+    farm_id bigint not null,
+    pond_id bigint, -- null for farm-wide equipment, ex: central blower
+    name varchar(50) not null,
+    type varchar(20) not null, -- aerator, pump, feeder or blower
+    make varchar(100) not null default '', -- manufacturer and model
+    power_rating real not null default 0, -- rated power draw in kW
+    install_date date,
+    status varchar(20) not null default 'active',
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index equipments_farm_pond_idx on equipments (farm_id, pond_id);
+
+-- a single row per on/off cycle, stopped_at is null while the unit is running
+create table equipment_runtimes (
+    id bigserial primary key,
+    equipment_id bigint not null,
+    started_at timestamp with time zone not null,
+    stopped_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+create index equipment_runtimes_equipment_started_idx on equipment_runtimes (equipment_id, started_at desc);
+create unique index equipment_runtimes_running_idx on equipment_runtimes (equipment_id) where stopped_at is null;
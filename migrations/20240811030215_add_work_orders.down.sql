@@ -0,0 +1 @@
+drop table work_orders;
@@ -0,0 +1,25 @@
+create table work_orders (
+    id bigserial primary key,
+    farm_id bigint not null,
+    pond_id bigint, -- at least one of pond or equipment is set
+    equipment_id bigint,
+    parent_id bigint, -- previous occurrence of a recurring work order
+    title varchar(100) not null,
+    description text not null default '',
+    type varchar(20) not null, -- ex: drying, liming, liner_repair, servicing
+    blocking boolean not null default false, -- pond can't be cultivated while it's unfinished
+    assignee varchar(100) not null default '',
+    recurrence varchar(50) not null default '', -- subset of RFC 5545 RRULE, ex: FREQ=WEEKLY;INTERVAL=2
+    status varchar(20) not null,
+    note text not null default '', -- remark on the latest status transition
+    due_at timestamp with time zone,
+    started_at timestamp with time zone,
+    completed_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index work_orders_farm_status_due_idx on work_orders (farm_id, status, due_at);
+create index work_orders_pond_idx on work_orders (pond_id) where pond_id is not null;
+create index work_orders_equipment_idx on work_orders (equipment_id) where equipment_id is not null;
@@ -0,0 +1,2 @@
+drop table checklist_tasks;
+drop table checklist_templates;
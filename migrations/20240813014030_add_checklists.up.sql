@@ -0,0 +1,36 @@
+create table checklist_templates (
+    id bigserial primary key,
+    farm_id bigint not null,
+    pond_id bigint, -- null for farm-wide task, ex: pond walk
+    name varchar(100) not null,
+    description text not null default '',
+    due_time varchar(5) not null, -- HH:MM in farm's timezone
+    reading_type varchar(30) not null default '', -- parameter to be measured on completion, ex: dissolved_oxygen
+    active boolean not null default true,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create index checklist_templates_farm_idx on checklist_templates (farm_id);
+
+create table checklist_tasks (
+    id bigserial primary key,
+    template_id bigint not null,
+    farm_id bigint not null,
+    pond_id bigint,
+    name varchar(100) not null, -- copied from template, so renaming it doesn't rewrite history
+    reading_type varchar(30) not null default '',
+    task_date date not null, -- day in farm's timezone
+    due_at timestamp with time zone not null,
+    status varchar(20) not null default 'pending',
+    completed_by varchar(100) not null default '',
+    completed_at timestamp with time zone,
+    note text not null default '',
+    reading real,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    unique (template_id, task_date) -- generation is idempotent
+);
+
+create index checklist_tasks_farm_date_idx on checklist_tasks (farm_id, task_date);
@@ -0,0 +1,24 @@
+create table users (
+    id bigserial primary key,
+    username varchar(50) not null,
+    name varchar(100) not null default '',
+    password_hash varchar(72) not null, -- bcrypt
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create unique index users_username_idx on users (lower(username)) where deleted_at is null;
+
+create table user_sessions (
+    id bigserial primary key,
+    user_id bigint not null,
+    refresh_hash varchar(64) not null, -- sha256 of current refresh token, replaced on every refresh
+    expires_at timestamp with time zone not null,
+    revoked_at timestamp with time zone, -- set on logout
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+create unique index user_sessions_refresh_idx on user_sessions (refresh_hash);
+create index user_sessions_user_idx on user_sessions (user_id);
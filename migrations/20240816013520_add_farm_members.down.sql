@@ -0,0 +1,2 @@
+drop table farm_members;
+alter table users drop column is_admin;
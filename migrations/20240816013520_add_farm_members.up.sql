@@ -0,0 +1,12 @@
+alter table users add column is_admin boolean not null default false; -- admin isn't bound by farm roles
+
+create table farm_members (
+    farm_id bigint not null,
+    user_id bigint not null,
+    role varchar(20) not null, -- owner, manager, technician, viewer or auditor
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    primary key (farm_id, user_id)
+);
+
+create index farm_members_user_idx on farm_members (user_id);
@@ -0,0 +1,16 @@
+create table api_keys (
+    id bigserial primary key,
+    user_id bigint not null, -- key acts on behalf of its owner
+    name varchar(100) not null,
+    prefix varchar(12) not null, -- leading characters of the key, shown to tell keys apart
+    key_hash varchar(64) not null, -- sha256 of the key
+    scopes varchar(200) not null, -- comma separated, ex: read:farms,write:ponds
+    expires_at timestamp with time zone, -- null for non-expiring key
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+create unique index api_keys_hash_idx on api_keys (key_hash);
+create index api_keys_user_idx on api_keys (user_id);
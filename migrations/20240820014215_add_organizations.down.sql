@@ -0,0 +1,4 @@
+drop index farms_organization_name_idx;
+alter table farms drop column organization_id;
+alter table users drop column organization_id;
+drop table organizations;
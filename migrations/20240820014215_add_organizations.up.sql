@@ -0,0 +1,22 @@
+create table organizations (
+    id bigserial primary key,
+    name varchar(100) not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+create unique index organizations_name_idx on organizations (lower(name)) where deleted_at is null;
+
+-- every farm and user predating tenancy belongs to the default organization
+insert into organizations (id, name) values (1, 'Default');
+select setval('organizations_id_seq', 1);
+
+alter table users add column organization_id bigint not null default 1;
+alter table farms add column organization_id bigint not null default 1;
+
+alter table users alter column organization_id drop default;
+alter table farms alter column organization_id drop default;
+
+create index users_organization_idx on users (organization_id);
+create unique index farms_organization_name_idx on farms (organization_id, name) where deleted_at is null; -- farm name is unique per tenant
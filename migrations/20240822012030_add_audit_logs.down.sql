@@ -0,0 +1,2 @@
+drop table audit_logs;
+drop function reject_audit_log_change;
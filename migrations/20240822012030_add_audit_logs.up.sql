@@ -0,0 +1,27 @@
+create table audit_logs (
+    id bigserial primary key,
+    organization_id bigint not null,
+    farm_id bigint not null, -- farm of the entity, used to authorize reader
+    entity varchar(20) not null, -- farm or pond
+    entity_id bigint not null,
+    action varchar(10) not null, -- create, update or delete
+    actor_id bigint, -- null for change made by scheduled job
+    actor varchar(50) not null default '', -- username at the time of change
+    api_key_id bigint, -- set when the change is made through an API key
+    request_id varchar(64) not null default '',
+    changes jsonb not null, -- changed fields, ex: {"name": {"before": "A", "after": "B"}}
+    created_at timestamp with time zone not null default now()
+);
+
+create index audit_logs_entity_idx on audit_logs (entity, entity_id);
+create index audit_logs_farm_idx on audit_logs (farm_id);
+
+-- audit trail is append-only
+create function reject_audit_log_change() returns trigger as $$
+begin
+    raise exception 'audit_logs is append-only';
+end;
+$$ language plpgsql;
+
+create trigger audit_logs_append_only before update or delete on audit_logs
+    for each row execute function reject_audit_log_change();
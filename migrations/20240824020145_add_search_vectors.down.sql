@@ -0,0 +1,7 @@
+drop index ponds_name_trgm_idx;
+drop index ponds_search_idx;
+drop index farms_name_trgm_idx;
+drop index farms_search_idx;
+
+alter table ponds drop column search;
+alter table farms drop column search;
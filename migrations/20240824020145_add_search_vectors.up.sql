@@ -0,0 +1,17 @@
+create extension if not exists pg_trgm;
+
+-- searchable text kept in sync by postgres, 'simple' config since names are mostly local words
+alter table farms
+    add column search tsvector generated always as (
+        to_tsvector('simple', name || ' ' || region || ' ' || address || ' ' || owner_name)
+    ) stored;
+
+alter table ponds
+    add column search tsvector generated always as (
+        to_tsvector('simple', name || ' ' || type)
+    ) stored;
+
+create index farms_search_idx on farms using gin (search);
+create index farms_name_trgm_idx on farms using gin (name gin_trgm_ops); -- fuzzy match on misspelled name
+create index ponds_search_idx on ponds using gin (search);
+create index ponds_name_trgm_idx on ponds using gin (name gin_trgm_ops);
@@ -0,0 +1,5 @@
+-- superadmin operates the platform across organizations, while admin is bound by its own
+alter table users add column is_superadmin boolean not null default false;
+
+-- the initial user bootstrapped the deployment, thus operates it
+update users set is_superadmin = true where id = (select min(id) from users);